@@ -5,16 +5,18 @@
 // 3. Store pending IDs to track in-flight requests
 // 4. Show loading state while request is pending
 // 5. Handle 409 Conflict (duplicate) or 429 (too many) responses
+// 6. Handle 422 Unprocessable Content, answered with the form re-rendered with its refused fields
+// It is a plain script, the class is global to the scripts of the page.
 
-export class RequestDeduplication {
-  private pendingRequests = new Map<string, { timestamp: number, loading: boolean }>();
-  private requestWindow = 5 * 60 * 1000; // 5 minutes in milliseconds
+class RequestDeduplication {
+  pendingRequests = new Map(); // id -> { timestamp, loading }
+  requestWindow = 5 * 60 * 1000; // 5 minutes in milliseconds
 
   /**
    * Generate a unique ID for this request
    * Uses UUID v4 format
    */
-  generateId(): string {
+  generateId() {
     return 'xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx'.replace(/[xy]/g, function(c) {
       const r = Math.random() * 16 | 0;
       const v = c == 'x' ? r : (r & 0x3 | 0x8);
@@ -26,7 +28,7 @@ export class RequestDeduplication {
    * Get or create a request ID from stored ID
    * Returns: same ID if still within time window, otherwise generates new one
    */
-  getRequestId(storedId: string | null): { id: string, isNew: boolean } {
+  getRequestId(storedId) {
     if (storedId) {
       const stored = this.pendingRequests.get(storedId);
       if (stored && stored.loading && (Date.now() - stored.timestamp < this.requestWindow)) {
@@ -40,7 +42,7 @@ export class RequestDeduplication {
   /**
    * Mark a request as pending (in-flight)
    */
-  markPending(id: string): void {
+  markPending(id) {
     this.pendingRequests.set(id, {
       timestamp: Date.now(),
       loading: true,
@@ -50,7 +52,7 @@ export class RequestDeduplication {
   /**
    * Mark a request as completed
    */
-  markComplete(id: string): void {
+  markComplete(id) {
     const stored = this.pendingRequests.get(id);
     if (stored) {
       stored.loading = false;
@@ -58,11 +60,24 @@ export class RequestDeduplication {
     }
   }
 
+  /**
+   * Get the CSRF token rendered by hr-web in the _csrf field of the form,
+   * or of any form of the page for data that doesn't come from one
+   */
+  csrfToken(data) {
+    const fromForm = data.get('_csrf');
+    if (typeof fromForm === 'string' && fromForm) {
+      return fromForm;
+    }
+    const field = document.querySelector('input[name="_csrf"]');
+    return field ? field.value : null;
+  }
+
   /**
    * Cleanup old pending requests (older than time window)
    * Call this periodically to clean up memory
    */
-  cleanupOldRequests(): void {
+  cleanupOldRequests() {
     const now = Date.now();
     for (const [id, request] of this.pendingRequests.entries()) {
       if (now - request.timestamp > this.requestWindow) {
@@ -74,22 +89,16 @@ export class RequestDeduplication {
   /**
    * Wrap form submission with deduplication logic
    * @param url - The API endpoint URL
-   * @param formData - The form data to submit
-   * @param options - Additional options
+   * @param formData - The form element to submit
+   * @param options - Additional options:
+   *   onSuccess(data, response), data is null unless the answer is JSON
+   *   onInvalid(html), the form answered back with its refused fields
+   *   onConflict(message), onError(error)
+   *   formId, submitButtonId, loadingMessage
+   *   headers, added to the request
    * @returns Promise with response data
    */
-  async submitForm(
-    url: string,
-    formData: FormData | Record<string, string>,
-    options: {
-      onSuccess?: (data: any) => void;
-      onConflict?: (message: string) => void;
-      onError?: (error: Error) => void;
-      formId?: string;
-      submitButtonId?: string;
-      loadingMessage?: string;
-    } = {}
-  ): Promise<any> {
+  async submitForm(url, formData, options = {}) {
     const formId = options.formId || this.generateId();
     const submitButtonId = options.submitButtonId || 'submit-btn';
 
@@ -98,9 +107,11 @@ export class RequestDeduplication {
     const requestIdData = this.getRequestId(stored ? formId : null);
 
     // Show loading state
+    let submitText = null;
     if (options.submitButtonId) {
       const btn = document.getElementById(submitButtonId);
       if (btn) {
+        submitText = btn.textContent;
         btn.disabled = true;
         btn.textContent = options.loadingMessage || 'Processing...';
       }
@@ -112,17 +123,24 @@ export class RequestDeduplication {
     // Prepare form data
     const data = new FormData(formData);
 
-    // Add deduplication headers
-    const headers: HeadersInit = {
+    // Add deduplication headers, and the CSRF token hr-web checks against its cookie
+    const headers = {
+      ...options.headers,
       'X-Idempotency-Key': requestIdData.id,
     };
+    const csrfToken = this.csrfToken(data);
+    if (csrfToken) {
+      headers['X-CSRF-Token'] = csrfToken;
+    }
 
+    let answered = false;
     try {
       const response = await fetch(url, {
         method: 'POST',
         headers,
         body: data,
       });
+      answered = true;
 
       // Mark as complete
       this.markComplete(requestIdData.id);
@@ -132,18 +150,40 @@ export class RequestDeduplication {
         const btn = document.getElementById(submitButtonId);
         if (btn) {
           btn.disabled = false;
-          btn.textContent = 'Submit'; // Restore original text or pass as option
+          btn.textContent = submitText;
         }
       }
 
       // Handle different response statuses
-      if (response.status === 409) {
+      if (response.redirected) {
+        // The server sent the browser elsewhere, like the login page once the session ended
+        window.location.assign(response.url);
+        return null;
+      } else if (response.status === 422) {
+        // Refused fields, the answer is the form showing why
+        const html = await response.text();
+        if (options.onInvalid) {
+          options.onInvalid(html);
+        }
+        return html;
+      } else if (response.status === 409) {
         // Duplicate detected - server already processed this ID
-        const conflictMessage = await response.text();
+        // errors are application/problem+json documents, detail is meant to be shown
+        const problem = await response.json().catch(() => ({}));
+        const conflictMessage = problem.detail || problem.title || 'Conflict';
         if (options.onConflict) {
           options.onConflict(conflictMessage);
         }
         throw new Error(`Duplicate request: ${conflictMessage}`);
+      } else if (response.status === 403) {
+        // CSRF check failed, the page was opened before the session changed,
+        // unless the server explains otherwise
+        const problem = await response.json().catch(() => ({}));
+        const message = problem.detail || 'This form has expired, reload the page and try again';
+        if (options.onError) {
+          options.onError(new Error(message));
+        }
+        throw new Error(message);
       } else if (response.status === 429) {
         // Too many requests
         if (options.onError) {
@@ -151,16 +191,19 @@ export class RequestDeduplication {
         }
         throw new Error('Too many requests');
       } else if (!response.ok) {
-        // Other errors
+        // Other errors, with the detail of their problem document when there is one
+        const problem = await response.json().catch(() => ({}));
+        const message = problem.detail || `HTTP ${response.status}`;
         if (options.onError) {
-          options.onError(new Error(`HTTP ${response.status}`));
+          options.onError(new Error(message));
         }
-        throw new Error(`HTTP ${response.status}`);
+        throw new Error(message);
       } else {
         // Success
-        const data = await response.json();
+        const isJSON = (response.headers.get('Content-Type') || '').includes('json');
+        const data = isJSON ? await response.json() : null;
         if (options.onSuccess) {
-          options.onSuccess(data);
+          options.onSuccess(data, response);
         }
         return data;
       }
@@ -173,11 +216,13 @@ export class RequestDeduplication {
         const btn = document.getElementById(submitButtonId);
         if (btn) {
           btn.disabled = false;
+          btn.textContent = submitText;
         }
       }
 
-      if (options.onError) {
-        options.onError(error as Error);
+      // The failed answers were already reported by their branch above
+      if (options.onError && !answered) {
+        options.onError(error);
       }
       throw error;
     }
@@ -187,7 +232,7 @@ export class RequestDeduplication {
    * Periodically clean up old requests
    * Call this when page loads and periodically
    */
-  initCleanup(intervalMs: number = 60000): void {
+  initCleanup(intervalMs = 60000) {
     // Clean up immediately
     this.cleanupOldRequests();
 
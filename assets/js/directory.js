@@ -0,0 +1,116 @@
+// Employee directory: replaces the results in place as the filters, sort or page change
+// Usage:
+// 1. The filters are a GET form with id "employee-filters", the results live in "#employee-results"
+// 2. Typing in the search is debounced, changing a select is sent right away
+// 3. Links marked with data-directory-link load their page into the results
+// 4. Requests carry the HX-Request header, hr-web then answers with the results only
+// Without this script the form and links work as plain page loads.
+
+(function () {
+  const searchDelay = 300; // milliseconds without typing before searching
+
+  let form;
+  let results;
+  let searchTimer;
+  let inFlight;
+
+  /**
+   * Load the results of url and show it in the address bar,
+   * a newer load cancels the one still in flight
+   */
+  async function load(url) {
+    if (inFlight) {
+      inFlight.abort();
+    }
+    const controller = new AbortController();
+    inFlight = controller;
+    results.setAttribute('aria-busy', 'true');
+
+    try {
+      const response = await fetch(url, {
+        headers: { 'HX-Request': 'true' },
+        signal: controller.signal,
+      });
+
+      // the session ended, hr-web sent the login page
+      if (response.redirected) {
+        window.location.assign(response.url);
+        return;
+      }
+
+      // failures come with a message in place of the results
+      results.innerHTML = await response.text();
+      history.replaceState(null, '', url);
+    } catch (error) {
+      if (error.name === 'AbortError') {
+        return;
+      }
+      // unreachable server, a full load shows what is wrong
+      window.location.assign(url);
+    } finally {
+      if (inFlight === controller) {
+        inFlight = null;
+        results.removeAttribute('aria-busy');
+      }
+    }
+  }
+
+  /**
+   * Url of the first page matching the filters, with the sort carried by the results
+   */
+  function filtersUrl() {
+    const params = new URLSearchParams();
+    for (const [name, value] of new FormData(form)) {
+      if (value !== '') {
+        params.append(name, value);
+      }
+    }
+    const query = params.toString();
+    return form.action + (query ? '?' + query : '');
+  }
+
+  function init() {
+    form = document.getElementById('employee-filters');
+    results = document.getElementById('employee-results');
+    if (!form || !results) {
+      return;
+    }
+
+    form.addEventListener('input', (event) => {
+      if (event.target.type !== 'search') {
+        return;
+      }
+      clearTimeout(searchTimer);
+      searchTimer = setTimeout(() => load(filtersUrl()), searchDelay);
+    });
+
+    form.addEventListener('change', (event) => {
+      if (event.target.tagName === 'SELECT') {
+        clearTimeout(searchTimer);
+        load(filtersUrl());
+      }
+    });
+
+    form.addEventListener('submit', (event) => {
+      event.preventDefault();
+      clearTimeout(searchTimer);
+      load(filtersUrl());
+    });
+
+    results.addEventListener('click', (event) => {
+      const link = event.target.closest('a[data-directory-link]');
+      // let the browser open links in a new tab or window
+      if (!link || event.button !== 0 || event.metaKey || event.ctrlKey || event.shiftKey || event.altKey) {
+        return;
+      }
+      event.preventDefault();
+      load(link.href);
+    });
+  }
+
+  if (document.readyState === 'loading') {
+    document.addEventListener('DOMContentLoaded', init);
+  } else {
+    init();
+  }
+})();
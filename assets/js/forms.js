@@ -0,0 +1,70 @@
+// Form submission through RequestDeduplication (deduplication.js)
+// Usage:
+// 1. Mark a POST form with data-deduplicate, give it an id and its submit button an id
+// 2. Add an element with data-form-message to the form, failures are shown in it
+// 3. Requests carry the HX-Request header, hr-web then answers:
+//    - 204 with an HX-Redirect header once saved
+//    - 422 with the form re-rendered with its refused fields and the posted values
+//    - a problem document for other failures, its detail is shown
+// Without this script the forms are posted as plain page loads.
+
+(function () {
+  const deduplication = new RequestDeduplication();
+  deduplication.initCleanup();
+
+  /**
+   * Show message in the message element of form
+   */
+  function showMessage(form, message) {
+    const element = form.querySelector('[data-form-message]');
+    if (!element) {
+      return;
+    }
+    element.textContent = message;
+    element.hidden = false;
+  }
+
+  /**
+   * Replace form with the one answered, and bring the user to its first refused field
+   */
+  function replaceForm(form, html) {
+    const template = document.createElement('template');
+    template.innerHTML = html.trim();
+    const replacement = template.content.firstElementChild;
+    if (!replacement) {
+      return;
+    }
+    form.replaceWith(replacement);
+
+    const invalid = replacement.querySelector('[aria-invalid="true"]');
+    if (invalid) {
+      invalid.focus();
+    }
+  }
+
+  document.addEventListener('submit', async (event) => {
+    const form = event.target.closest('form[data-deduplicate]');
+    if (!form) {
+      return;
+    }
+    event.preventDefault();
+
+    const button = form.querySelector('button[type="submit"]');
+    try {
+      await deduplication.submitForm(form.action, form, {
+        formId: form.id,
+        submitButtonId: button ? button.id : undefined,
+        loadingMessage: 'Saving...',
+        headers: { 'HX-Request': 'true' },
+        onSuccess: (data, response) => {
+          window.location.assign(response.headers.get('HX-Redirect') || window.location.href);
+        },
+        onInvalid: (html) => replaceForm(form, html),
+        onConflict: (message) => showMessage(form, message),
+        onError: (error) => showMessage(form, error.message),
+      });
+    } catch (error) {
+      // already shown by onConflict or onError
+    }
+  });
+})();
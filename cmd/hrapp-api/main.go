@@ -3,40 +3,198 @@ package main
 import (
 	"context"
 	"fmt"
-	"os"
+	"time"
 	"web-boilerplate/internal/hr-api/config"
 	"web-boilerplate/internal/hr-api/db"
+	"web-boilerplate/internal/hr-api/handlers"
 	"web-boilerplate/internal/hr-api/middlewares"
+	"web-boilerplate/internal/hr-api/pkg/idempotency"
+	"web-boilerplate/internal/hr-api/pkg/lockout"
 	loggerpkg "web-boilerplate/internal/hr-api/pkg/logger"
+	"web-boilerplate/internal/hr-api/pkg/metrics"
+	"web-boilerplate/internal/hr-api/pkg/migrate"
+	"web-boilerplate/internal/hr-api/pkg/notifier"
+	"web-boilerplate/internal/hr-api/pkg/ratelimit"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
+	"web-boilerplate/internal/hr-api/pkg/signing"
+	"web-boilerplate/internal/hr-api/repositories/migrations"
 	"web-boilerplate/internal/hr-api/routes"
+	"web-boilerplate/shared/health"
+	"web-boilerplate/shared/helpers"
+	"web-boilerplate/shared/lifecycle"
+	"web-boilerplate/shared/tracing"
 
 	"github.com/gofiber/fiber/v3"
 )
 
 func main() {
-	err := config.LoadAllConfig()
+	cfg, err := config.Load()
 	if err != nil {
-		fmt.Printf("Failed to load configs from environment, err: %v", err)
+		fmt.Printf("Failed to load configs, err: %v", err)
 		panic(err)
 	}
 
 	// Initialize Dependencies
-	logLvl := os.Getenv("LOG_LEVEL")
-	if logLvl == "" {
-		logLvl = "info"
+	logInst := loggerpkg.New(cfg.LogLevel)
+	lc := lifecycle.New(logInst, lifecycle.Config{
+		ShutdownTimeout: cfg.ShutdownTimeout,
+		DrainDelay:      cfg.ShutdownDrainDelay,
+	})
+
+	shutdownTracing, err := tracing.Setup(tracing.Config{
+		ServiceName: "hr-api",
+		Exporter:    cfg.TracingExporter,
+		File:        cfg.TracingFile,
+	})
+	if err != nil {
+		logInst.Fatal().Err(err).Msg("failed to initialize tracing")
 	}
-	logInst := loggerpkg.New(logLvl)
+	// registered first so the spans of the other resources closing are still exported
+	lc.Register("tracing", shutdownTracing)
 
-	dbInst, err := db.New(context.Background(), os.Getenv("DATABASE_URL"))
+	dbInst, err := db.New(context.Background(), cfg.DatabaseURL)
 	if err != nil {
 		logInst.Fatal().Err(err).Msg("failed to initialize database")
 	}
-	defer dbInst.Close()
+	lc.Register("database", func(context.Context) error {
+		dbInst.Close()
+		return nil
+	})
+
+	// Instances starting together wait on the migration lock, the first one applies the migrations
+	if cfg.AutoMigrate {
+		migrator, err := migrate.New(dbInst.Pool, migrations.FS)
+		if err != nil {
+			logInst.Fatal().Err(err).Msg("failed to load migrations")
+		}
+		steps, err := migrator.Up(context.Background(), 0)
+		for _, step := range steps {
+			logInst.Info().Stringer("migration", step).Msg("migration applied")
+		}
+		if err != nil {
+			logInst.Fatal().Err(err).Msg("failed to apply migrations")
+		}
+	}
+
+	m := metrics.New()
+	m.RegisterPool(dbInst.Pool)
 
-	app := fiber.New()
+	checks := health.NewRegistry(cfg.HealthCacheTTL, cfg.HealthCheckTimeout)
+	checks.Register("postgres", health.CheckerFunc(dbInst.Pool.Ping))
+	if cfg.S3.Enabled() {
+		storage := config.NewS3Storage(cfg.S3)
+		checks.RegisterOptional("s3", health.CheckerFunc(func(ctx context.Context) error {
+			return helpers.S3BucketReachable(ctx, storage, cfg.S3.Bucket)
+		}))
+	}
+
+	// Revoked tokens, login failures, idempotent responses and rate limits must be shared by every instance,
+	// the in-memory stores only suit local development
+	var revocations revocation.Store
+	var lockouts lockout.Store
+	var idempotencyStore idempotency.Store
+	var limits ratelimit.Store
+	if cfg.RedisURL != "" {
+		redisClient, err := db.NewRedisClient(cfg.RedisURL)
+		if err != nil {
+			logInst.Fatal().Err(err).Msg("failed to initialize redis")
+		}
+		lc.Register("redis", func(context.Context) error {
+			return redisClient.Close()
+		})
+		checks.Register("redis", health.CheckerFunc(func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		}))
+		revocations = revocation.NewRedisStore(redisClient)
+		lockouts = lockout.NewRedisStore(redisClient)
+		idempotencyStore = idempotency.NewRedisStore(redisClient)
+		limits = ratelimit.NewRedisStore(redisClient)
+	} else {
+		logInst.Warn().Msg("REDIS_URL not set, using in-memory token revocation, login lockout, idempotency and rate limit stores")
+		memRevocations := revocation.NewMemoryStore()
+		revocations = memRevocations
+		memLockouts := lockout.NewMemoryStore()
+		lockouts = memLockouts
+		memIdempotency := idempotency.NewMemoryStore()
+		idempotencyStore = memIdempotency
+		memLimits := ratelimit.NewMemoryStore()
+		limits = memLimits
+
+		// Redis expires its keys, the in-memory stores drop theirs once in a while
+		lc.Go("memory stores sweep", func(ctx context.Context) {
+			ticker := time.NewTicker(time.Minute)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					memRevocations.Sweep()
+					memLockouts.Sweep()
+					memIdempotency.Sweep()
+					memLimits.Sweep()
+				}
+			}
+		})
+	}
+	guard := lockout.NewGuard(lockouts, lockout.Policy{
+		MaxAttempts:      cfg.LoginMaxAttempts,
+		MaxAttemptsPerIP: cfg.LoginMaxAttemptsPerIP,
+		Window:           cfg.LoginAttemptWindow,
+		LockoutDuration:  cfg.LoginLockoutDuration,
+		BaseDelay:        cfg.LoginBaseDelay,
+	})
+
+	// Only local stand-ins exist for now, reset links end up in the log or in NOTIFIER_FILE
+	var notify notifier.Notifier = notifier.NewLogNotifier(loggerpkg.NewZerologAdapter(logInst))
+	if cfg.NotifierFile != "" {
+		notify = notifier.NewFileNotifier(cfg.NotifierFile)
+	}
+
+	// Keys generated in memory differ on every instance and restart, a shared JWT_KEYS_DIR is needed in production
+	if cfg.JWTKeysDir == "" {
+		logInst.Warn().Msg("JWT_KEYS_DIR not set, using in-memory signing keys")
+	}
+	keys, err := signing.NewKeySet(signing.Config{
+		Algorithm:        cfg.JWTAlgorithm,
+		Dir:              cfg.JWTKeysDir,
+		RotationInterval: cfg.JWTKeyRotation,
+		// a replaced key must verify every token it signed until they expire
+		Retention: max(cfg.TokenTTL, cfg.MFAChallengeTTL),
+	})
+	if err != nil {
+		logInst.Fatal().Err(err).Msg("failed to initialize signing keys")
+	}
+	lc.Go("signing keys", func(ctx context.Context) {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := keys.Maintain(); err != nil {
+					logInst.Error().Err(err).Msg("failed to maintain signing keys")
+				}
+			}
+		}
+	})
+
+	if cfg.IsProd && cfg.ProxyHeader == "" {
+		logInst.Warn().Msg("PROXY_HEADER not set, the per IP rate limits and login lockout see the address of any reverse proxy as the client's")
+	}
+	app := fiber.New(fiber.Config{
+		ErrorHandler: middlewares.ErrorHandler,
+		// c.IP() reads the client IP from ProxyHeader on requests of the trusted proxies only,
+		// and falls back to the proxy's IP when the header doesn't hold a valid one
+		ProxyHeader:        cfg.ProxyHeader,
+		TrustProxy:         cfg.ProxyHeader != "",
+		TrustProxyConfig:   fiber.TrustProxyConfig{Proxies: cfg.Proxies()},
+		EnableIPValidation: true,
+	})
 
 	// Disable cache control middleware in development and add dynamic route for style
-	if !config.IS_PROD {
+	if !cfg.IsProd {
 		app.Use(func(c fiber.Ctx) error {
 			c.Set("Cache-Control", "no-store")
 			return c.Next()
@@ -44,26 +202,56 @@ func main() {
 	}
 
 	// Setup middlewares (order matters):
-	// 1. Recover - catches panics
-	// 2. Request ID - generates/propagates ID before logger
-	// 3. Logger - includes request ID in logs via FieldRequestId
-	// 4. Other middlewares
+	// 1. Tracing - starts the request span, continuing the caller's trace
+	// 2. Metrics - observes every request, recovered panics and replayed responses included
+	// 3. Recover - catches panics
+	// 4. Request ID - generates/propagates ID before logger
+	// 5. Logger - includes request ID and trace ID in logs
+	// 6. Other middlewares, idempotency last so retried requests are replayed from the store
 	logAdapter := loggerpkg.NewZerologAdapter(logInst)
-	middlewares.SetupMiddlewareRecover(app, logAdapter)
+	app.Use(tracing.Middleware())
+	middlewares.SetupMetrics(app, m)
+	middlewares.SetupMiddlewareRecover(app, logAdapter, m)
 	middlewares.SetupMiddlewareRequestID(app)
 	middlewares.SetupLogger(app, logInst)
-	middlewares.SetupMiddlewares(app)
+	middlewares.SetupMiddlewares(app, idempotencyStore, cfg.RedisKeysTTL)
 
 	// Setup routes
-	routes.SetupRoutes(app, logInst, dbInst)
+	h := handlers.New(handlers.Deps{
+		Config:      cfg,
+		Log:         logInst,
+		DB:          dbInst,
+		Revocations: revocations,
+		Notifier:    notify,
+		Keys:        keys,
+		Guard:       guard,
+		Checks:      checks,
+		Metrics:     m,
+		Ready:       lc.Ready,
+	})
+	routes.SetupRoutes(app, h, limits)
 
-	if config.BASE_URL == "" {
-		config.BASE_URL = "localhost:3000"
+	// Keep the metrics off the public listener when an admin address is given
+	if cfg.MetricsAddr == "" {
+		app.Get("/metrics", m.Handler())
+	} else {
+		admin := fiber.New()
+		admin.Get("/metrics", m.Handler())
+		lc.Go("metrics server", func(ctx context.Context) {
+			err := admin.Listen(cfg.MetricsAddr, fiber.ListenConfig{
+				DisableStartupMessage: true,
+				GracefulContext:       ctx,
+			})
+			if err != nil {
+				logInst.Error().Err(err).Msg("metrics server failed")
+			}
+		})
 	}
-	fmt.Printf("baseurl:%s\n", config.BASE_URL)
-	//Start Server
-	err = app.Listen(config.BASE_URL)
+
+	fmt.Printf("baseurl:%s\n", cfg.BaseURL)
+	//Start Server, blocks until SIGINT or SIGTERM and the shutdown that follows
+	err = lc.Run(app, cfg.BaseURL)
 	if err != nil {
-		logInst.Fatal().Err(err).Msg("server failed to start")
+		logInst.Fatal().Err(err).Msg("server failed")
 	}
 }
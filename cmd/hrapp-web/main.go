@@ -5,9 +5,14 @@ import (
 	"os"
 	"web-boilerplate/assets"
 	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/internal/hr-web/middlewares"
 	"web-boilerplate/internal/hr-web/routes"
+	"web-boilerplate/shared/health"
+	"web-boilerplate/shared/lifecycle"
+	"web-boilerplate/shared/tracing"
 
 	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
 	"github.com/gofiber/fiber/v3/middleware/static"
 	"github.com/rs/zerolog"
 )
@@ -15,24 +20,33 @@ import (
 func main() {
 	app := fiber.New()
 
-	err := config.LoadEnvFile()
+	cfg, err := config.Load()
 	if err != nil {
 		panic(err)
 	}
-	err = config.LoadAllConfig()
+
+	// Setup logger
+	logLevel, err := zerolog.ParseLevel(cfg.LogLevel)
 	if err != nil {
 		panic(err)
 	}
+	logInst := zerolog.New(os.Stderr).Level(logLevel).With().Timestamp().Logger().Hook(tracing.LogHook{})
 
-	// Setup logger
-	logLevel := os.Getenv("LOG_LEVEL")
-	if logLevel == "" {
-		logLevel = "info"
+	shutdownTracing, err := tracing.Setup(tracing.Config{
+		ServiceName: "hr-web",
+		Exporter:    cfg.TracingExporter,
+		File:        cfg.TracingFile,
+	})
+	if err != nil {
+		panic(err)
 	}
-	logInst := zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+	app.Use(tracing.Middleware())
+	// the calls to hr-api carry the request id so both logs can be matched
+	app.Use(requestid.New(), middlewares.APIRequestID())
 
 	// Disable cache control middleware in development and add dynamic route for style
-	if !config.IS_PROD {
+	if !cfg.IsProd {
 		app.Use(func(c fiber.Ctx) error {
 			c.Set("Cache-Control", "no-store")
 			return c.Next()
@@ -67,11 +81,21 @@ func main() {
 		Browse: true,
 	}))
 
-	routes.SetupRoutes(app, &logInst)
+	lc := lifecycle.New(&logInst, lifecycle.Config{
+		ShutdownTimeout: cfg.ShutdownTimeout,
+		DrainDelay:      cfg.ShutdownDrainDelay,
+	})
+	lc.Register("tracing", shutdownTracing)
+
+	// hr-web can't do anything useful without the api
+	checks := health.NewRegistry(cfg.HealthCacheTTL, cfg.HealthCheckTimeout)
+	checks.Register("hr-api", health.HTTPChecker(cfg.APIURL+"/livez"))
+
+	routes.SetupRoutes(app, cfg, &logInst, checks, lc.Ready)
 
-	fmt.Printf("baseurl:%s\n", config.BASE_URL)
-	fmt.Printf("apiurl:%s\n", config.API_URL)
-	err = app.Listen(config.BASE_URL)
+	fmt.Printf("baseurl:%s\n", cfg.BaseURL)
+	fmt.Printf("apiurl:%s\n", cfg.APIURL)
+	err = lc.Run(app, cfg.BaseURL)
 	if err != nil {
 		panic(err)
 	}
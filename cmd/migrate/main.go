@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"web-boilerplate/internal/hr-api/config"
+	"web-boilerplate/internal/hr-api/db"
+	"web-boilerplate/internal/hr-api/pkg/migrate"
+	"web-boilerplate/internal/hr-api/repositories/migrations"
+
+	"github.com/rs/zerolog"
+)
+
+const usage = `Usage: migrate [-dry-run] <command>
+
+Commands:
+  up [N]        apply the next N pending migrations, all of them without N
+  down [N]      revert the last N applied migrations, 1 without N
+  goto V        apply or revert migrations until V is the current version, 0 reverts them all
+  status        list the migrations and whether they are applied
+  force V       set the version and clear the dirty flag without running anything
+
+Flags:
+`
+
+func main() {
+	log := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
+
+	dryRun := flag.Bool("dry-run", false, "print the migrations a command would run without running them")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
+
+	// interrupting stops waiting for the lock, a running migration is rolled back with its transaction
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	dbInst, err := db.New(ctx, cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect to db")
+	}
+	defer dbInst.Close()
+
+	migrator, err := migrate.New(dbInst.Pool, migrations.FS)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load migrations")
+	}
+	migrator.DryRun = *dryRun
+
+	command, args := flag.Arg(0), flag.Args()[1:]
+
+	var steps []migrate.Step
+	switch command {
+	case "up":
+		steps, err = migrator.Up(ctx, intArg(args, 0))
+	case "down":
+		steps, err = migrator.Down(ctx, intArg(args, 1))
+	case "goto":
+		steps, err = migrator.Goto(ctx, versionArg(args))
+	case "force":
+		version := versionArg(args)
+		err = migrator.Force(ctx, version)
+		if err == nil && !*dryRun {
+			log.Info().Uint64("version", version).Msg("version forced")
+		}
+	case "status":
+		err = printStatus(ctx, migrator)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	for _, step := range steps {
+		if *dryRun {
+			fmt.Printf("-- %s\n%s\n", step, step.SQL())
+			continue
+		}
+		log.Info().Stringer("migration", step).Msg("migration applied")
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("migrate " + command + " failed")
+	}
+	if len(steps) == 0 && command != "status" && command != "force" {
+		log.Info().Msg("no change")
+	}
+}
+
+func printStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, m := range status.Migrations {
+		state := "pending"
+		if m.Applied {
+			state = "applied"
+		}
+		if m.Version == status.Version && status.Dirty {
+			state = "dirty"
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\n", m.Version, m.Name, state)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nversion %d, %d pending\n", status.Version, status.Pending())
+	return nil
+}
+
+// intArg returns the optional count argument, def when it is missing
+func intArg(args []string, def int) int {
+	if len(args) == 0 {
+		return def
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		fmt.Fprintf(os.Stderr, "invalid count %q, expected a positive number\n", args[0])
+		os.Exit(2)
+	}
+	return n
+}
+
+// versionArg returns the required version argument
+func versionArg(args []string) uint64 {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "missing version")
+		os.Exit(2)
+	}
+
+	version, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid version %q\n", args[0])
+		os.Exit(2)
+	}
+	return version
+}
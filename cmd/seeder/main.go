@@ -2,33 +2,50 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"os"
 	"web-boilerplate/internal/hr-api/config"
 	"web-boilerplate/internal/hr-api/db"
+	"web-boilerplate/internal/hr-api/pkg/validation"
 	"web-boilerplate/internal/hr-api/repositories"
 	"web-boilerplate/shared/helpers"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog"
 )
 
+// adminRole is the role of the roles and permissions migration granted every permission
+const adminRole = "admin"
+
+// adminParams describes the admin account created when it doesn't exist yet
+type adminParams struct {
+	Username string `json:"username" validate:"required,max=50"`
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"ADMIN_PASSWORD" validate:"required,strong_password"`
+}
+
 func main() {
 	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
 
-	err := config.LoadEnvFile()
-	if err != nil {
-		log.Fatal().Err(err).Msg("failed to load env")
-	}
+	// Users are only created here, nothing else grants the admin role the first time
+	var admin adminParams
+	flag.StringVar(&admin.Username, "admin", "", "username of an admin to create, or of an existing user to promote, its password is read from ADMIN_PASSWORD")
+	flag.StringVar(&admin.Email, "admin-email", "", "email of the admin created with -admin")
+	fake := flag.Bool("fake", true, "seed 20 fake users and employees")
+	flag.Parse()
+	admin.Password = os.Getenv("ADMIN_PASSWORD")
 
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		log.Fatal().Msg("DATABASE_URL is not set")
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
 	}
 
 	ctx := context.Background()
-	dbInst, err := db.New(ctx, dbURL)
+	dbInst, err := db.New(ctx, cfg.DatabaseURL)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to connect to db")
 	}
@@ -36,6 +53,17 @@ func main() {
 
 	queries := repositories.New(dbInst.Pool)
 
+	if admin.Username != "" {
+		if err := bootstrapAdmin(ctx, queries, admin); err != nil {
+			log.Fatal().Err(err).Str("username", admin.Username).Msg("failed to bootstrap admin")
+		}
+		log.Info().Str("username", admin.Username).Msg("admin ready")
+	}
+
+	if !*fake {
+		return
+	}
+
 	// Seed Users
 	log.Info().Msg("Seeding 20 users...")
 	for i := range 20 {
@@ -81,3 +109,40 @@ func main() {
 
 	log.Info().Msg("Seeding completed successfully!")
 }
+
+// bootstrapAdmin grants the admin role to the user named admin.Username, creating it first
+// when it doesn't exist
+func bootstrapAdmin(ctx context.Context, queries *repositories.Queries, admin adminParams) error {
+	user, err := queries.GetUserByUsername(ctx, admin.Username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		user, err = createAdmin(ctx, queries, admin)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = queries.UpdateUserRole(ctx, repositories.UpdateUserRoleParams{
+		ID:   user.ID,
+		Role: adminRole,
+	})
+	return err
+}
+
+func createAdmin(ctx context.Context, queries *repositories.Queries, admin adminParams) (repositories.User, error) {
+	if err := validation.Struct(ctx, admin); err != nil {
+		return repositories.User{}, err
+	}
+
+	pass, err := helpers.HashPass(admin.Password)
+	if err != nil {
+		return repositories.User{}, err
+	}
+
+	return queries.CreateUser(ctx, repositories.CreateUserParams{
+		ID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Name:     admin.Username,
+		Email:    admin.Email,
+		Username: admin.Username,
+		Password: pass,
+	})
+}
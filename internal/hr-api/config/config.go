@@ -1,89 +1,175 @@
 package config
 
 import (
-	"fmt"
-	"os"
+	"net/netip"
+	"strings"
 	"time"
-
-	"github.com/joho/godotenv"
+	"web-boilerplate/internal/hr-api/pkg/signing"
+	sharedconfig "web-boilerplate/shared/config"
+	"web-boilerplate/shared/tracing"
 )
 
-func LoadAllConfig() error {
-	// Load env file
-	err := LoadEnvFile()
-	if err != nil {
-		fmt.Printf("Failed to load .env file, err: %v", err)
-		return err
-	}
+// Config holds every setting of hr-api, see Default for the values used when a setting is unset
+type Config struct {
+	sharedconfig.Common `yaml:",inline"`
 
-	LOG_LEVEL, err = determineLogLevel(os.Getenv("LOG_LEVEL"))
-	if err != nil {
-		fmt.Printf("Failed to determine log level, err: %v", err)
-		return err
-	}
+	TokenTTL         time.Duration `yaml:"token_ttl" env:"TOKEN_EXPIRE_TIME"`
+	RefreshTokenTTL  time.Duration `yaml:"refresh_token_ttl" env:"REFRESH_TOKEN_EXPIRE_TIME"`
+	PasswordResetTTL time.Duration `yaml:"password_reset_ttl" env:"PASSWORD_RESET_EXPIRE_TIME"`
+	PasswordResetURL string        `yaml:"password_reset_url" env:"PASSWORD_RESET_URL"`
+	NotifierFile     string        `yaml:"notifier_file" env:"NOTIFIER_FILE"`
+	TOTPIssuer       string        `yaml:"totp_issuer" env:"TOTP_ISSUER"`
+	MFAChallengeTTL  time.Duration `yaml:"mfa_challenge_ttl" env:"MFA_CHALLENGE_EXPIRE_TIME"`
 
-	BASE_URL = os.Getenv("BASE_URL")
-	IS_PROD = os.Getenv("IS_PROD") == "true"
+	JWTAlgorithm   string        `yaml:"jwt_algorithm" env:"JWT_ALGORITHM"`
+	JWTKeysDir     string        `yaml:"jwt_keys_dir" env:"JWT_KEYS_DIR"`
+	JWTKeyRotation time.Duration `yaml:"jwt_key_rotation" env:"JWT_KEY_ROTATION"`
 
-	SECRET_KEY = os.Getenv("SECRET_KEY")
+	LoginMaxAttempts      int64         `yaml:"login_max_attempts" env:"LOGIN_MAX_ATTEMPTS"`
+	LoginMaxAttemptsPerIP int64         `yaml:"login_max_attempts_per_ip" env:"LOGIN_MAX_ATTEMPTS_PER_IP"`
+	LoginAttemptWindow    time.Duration `yaml:"login_attempt_window" env:"LOGIN_ATTEMPT_WINDOW"`
+	LoginLockoutDuration  time.Duration `yaml:"login_lockout_duration" env:"LOGIN_LOCKOUT_DURATION"`
+	LoginBaseDelay        time.Duration `yaml:"login_base_delay" env:"LOGIN_BASE_DELAY"`
 
-	expireRaw := os.Getenv("TOKEN_EXPIRE_TIME")
-	if expireRaw != "" {
-		TOKEN_TTL, err = time.ParseDuration(expireRaw)
-		if err != nil {
-			return fmt.Errorf("error parsing token expire time duration, %w", err)
-		}
-	} else {
-		TOKEN_TTL = time.Hour * 5
-	}
+	// ProxyHeader is the header the reverse proxies in front of hr-api put the client IP in, e.g. X-Real-IP.
+	// It is only read on requests coming from TrustedProxies, which must overwrite it rather than append to it,
+	// so the per IP rate limits and login lockout apply to clients and not to the proxies.
+	ProxyHeader string `yaml:"proxy_header" env:"PROXY_HEADER"`
+	// TrustedProxies are the comma separated IPs and CIDR ranges of the reverse proxies
+	TrustedProxies string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+
+	// MetricsAddr serves /metrics on a separate admin listener when set, on the api listener otherwise
+	MetricsAddr string `yaml:"metrics_addr" env:"METRICS_ADDR"`
+
+	// AutoMigrate applies the pending migrations on startup, instances starting together wait for each other
+	AutoMigrate bool `yaml:"auto_migrate" env:"AUTO_MIGRATE"`
+
+	S3 S3Config `yaml:"s3"`
+}
 
-	ALLOWED_ORIGINS = os.Getenv("ALLOWED_ORIGINS")
-	// In production, require explicit ALLOWED_ORIGINS to be set
-	if IS_PROD && len(ALLOWED_ORIGINS) == 0 {
-		return fmt.Errorf("ALLOWED_ORIGINS must be set in production environment")
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint" env:"S3_ENDPOINT"`
+	Region    string `yaml:"region" env:"S3_REGION"`
+	AccessKey string `yaml:"access_key" env:"S3_ACCESS_KEY"`
+	SecretKey string `yaml:"secret_key" env:"S3_SECRET_KEY"`
+	Bucket    string `yaml:"bucket" env:"S3_BUCKET"`
+}
+
+// Enabled reports whether an S3 endpoint or region is configured
+func (c S3Config) Enabled() bool {
+	return c.Endpoint != "" || c.Region != ""
+}
+
+func Default() Config {
+	return Config{
+		Common: sharedconfig.Common{
+			LogLevel:           "info",
+			BaseURL:            "localhost:3000",
+			RedisKeysTTL:       time.Hour * 24 * 7, // 7 days
+			ShutdownTimeout:    time.Second * 30,   // 30 seconds
+			HealthCacheTTL:     time.Second * 5,    // 5 seconds
+			HealthCheckTimeout: time.Second * 2,    // 2 seconds
+			TracingExporter:    tracing.ExporterNone,
+		},
+		TokenTTL:              time.Minute * 15,   // 15 minutes
+		RefreshTokenTTL:       time.Hour * 24 * 7, // 7 days
+		PasswordResetTTL:      time.Hour,          // 1 hour
+		PasswordResetURL:      "http://localhost:4000/reset-password",
+		TOTPIssuer:            "HR App",
+		MFAChallengeTTL:       time.Minute * 5, // 5 minutes
+		JWTAlgorithm:          signing.AlgorithmEdDSA,
+		JWTKeyRotation:        time.Hour * 24 * 30, // 30 days
+		LoginMaxAttempts:      5,
+		LoginMaxAttemptsPerIP: 50,
+		LoginAttemptWindow:    time.Minute * 15, // 15 minutes
+		LoginLockoutDuration:  time.Minute * 15, // 15 minutes
+		LoginBaseDelay:        time.Second,      // 1 second
+		S3: S3Config{
+			Bucket: "testbucket",
+		},
 	}
-	if len(ALLOWED_ORIGINS) == 0 {
-		ALLOWED_ORIGINS = "*"
+}
+
+// Proxies returns the IPs and CIDR ranges of TrustedProxies
+func (c *Config) Proxies() []string {
+	if c.TrustedProxies == "" {
+		return nil
 	}
 
-	REDIS_KEYS_TTL, err = time.ParseDuration(os.Getenv("REDIS_KEYS_TTL"))
-	if err != nil {
-		REDIS_KEYS_TTL = time.Hour * 24 * 7
+	proxies := strings.Split(c.TrustedProxies, ",")
+	for i, proxy := range proxies {
+		proxies[i] = strings.TrimSpace(proxy)
 	}
+	return proxies
+}
 
-	return nil
+// Load reads the config from the environment, .env and the optional CONFIG_FILE over the defaults
+func Load() (*Config, error) {
+	cfg := Default()
+	if err := sharedconfig.Load(&cfg, ".env", "cmd/hrapp-api/.env"); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
 }
 
-func LoadEnvFile() error {
-	paths := []string{".env", "cmd/hrapp-api/.env"}
-	for _, path := range paths {
-		if _, err := os.Stat(path); !os.IsNotExist(err) {
-			err := godotenv.Load(path)
-			if err != nil {
-				return err
-			}
-			fmt.Printf("Loaded .env from: %s\n", path)
-			return nil
+func (c *Config) Validate() error {
+	errs := &sharedconfig.ValidationError{}
+	c.Common.Validate(errs)
+
+	if c.DatabaseURL == "" {
+		errs.Add("DATABASE_URL", "must be set")
+	}
+
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"TOKEN_EXPIRE_TIME", c.TokenTTL},
+		{"REFRESH_TOKEN_EXPIRE_TIME", c.RefreshTokenTTL},
+		{"PASSWORD_RESET_EXPIRE_TIME", c.PasswordResetTTL},
+		{"MFA_CHALLENGE_EXPIRE_TIME", c.MFAChallengeTTL},
+		{"JWT_KEY_ROTATION", c.JWTKeyRotation},
+		{"LOGIN_ATTEMPT_WINDOW", c.LoginAttemptWindow},
+		{"LOGIN_LOCKOUT_DURATION", c.LoginLockoutDuration},
+	}
+	for _, d := range durations {
+		if d.value <= 0 {
+			errs.Add(d.name, "must be a positive duration")
 		}
 	}
-	return nil
-}
+	if c.LoginBaseDelay < 0 {
+		errs.Add("LOGIN_BASE_DELAY", "must not be negative")
+	}
+	if c.LoginMaxAttempts <= 0 {
+		errs.Add("LOGIN_MAX_ATTEMPTS", "must be positive")
+	}
+	if c.LoginMaxAttemptsPerIP <= 0 {
+		errs.Add("LOGIN_MAX_ATTEMPTS_PER_IP", "must be positive")
+	}
+	for _, proxy := range c.Proxies() {
+		if _, err := netip.ParsePrefix(proxy); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(proxy); err != nil {
+			errs.Add("TRUSTED_PROXIES", "%q is not an IP or CIDR range", proxy)
+		}
+	}
+	if c.ProxyHeader != "" && c.TrustedProxies == "" {
+		errs.Add("TRUSTED_PROXIES", "must be set when PROXY_HEADER is, any client could set the header otherwise")
+	}
+	if c.JWTAlgorithm != signing.AlgorithmEdDSA && c.JWTAlgorithm != signing.AlgorithmRS256 {
+		errs.Add("JWT_ALGORITHM", "must be %s or %s", signing.AlgorithmEdDSA, signing.AlgorithmRS256)
+	}
 
-func determineLogLevel(logLevel string) (LOG_LEVEL_TYPE, error) {
-	switch logLevel {
-	case "":
-		return LOG_LEVEL_DEBUG, nil
-	case "info":
-		return LOG_LEVEL_INFO, nil
-	case "warn":
-		return LOG_LEVEL_WARN, nil
-	case "debug":
-		return LOG_LEVEL_DEBUG, nil
-	case "error":
-		return LOG_LEVEL_ERROR, nil
-	case "fatal":
-		return LOG_LEVEL_FATAL, nil
-	default:
-		return LOG_LEVEL_NOTFOUND, fmt.Errorf("invalid log level")
+	// Revoked tokens, login failures and signing keys must be shared by every instance
+	if c.IsProd {
+		if c.RedisURL == "" {
+			errs.Add("REDIS_URL", "must be set in production environment")
+		}
+		if c.JWTKeysDir == "" {
+			errs.Add("JWT_KEYS_DIR", "must be set in production environment")
+		}
 	}
+
+	return errs.Err()
 }
@@ -16,6 +16,7 @@ func New(ctx context.Context, connString string) (*Database, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse connection string: %w", err)
 	}
+	config.ConnConfig.Tracer = queryTracer{}
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
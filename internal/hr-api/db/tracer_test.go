@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const getUserSQL = "-- name: GetUser :one\nSELECT id, name FROM users WHERE id = $1"
+
+// traceQuery runs a query through queryTracer under a recording tracer provider and returns its span
+func traceQuery(t *testing.T, sql string, end pgx.TraceQueryEndData) sdktrace.ReadOnlySpan {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	var tracer queryTracer
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql})
+	tracer.TraceQueryEnd(ctx, nil, end)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	return spans[0]
+}
+
+func TestQueryTracer_NamesSpansAfterTheQuery(t *testing.T) {
+	span := traceQuery(t, getUserSQL, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	assert.Equal(t, "GetUser", span.Name())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	attrs := map[string]any{}
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	assert.Equal(t, "postgresql", attrs["db.system.name"])
+	assert.Equal(t, getUserSQL, attrs["db.query.text"])
+	assert.Equal(t, int64(1), attrs["db.response.affected_rows"])
+}
+
+func TestQueryTracer_Errors(t *testing.T) {
+	span := traceQuery(t, getUserSQL, pgx.TraceQueryEndData{Err: errors.New("connection reset")})
+
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "connection reset", span.Status().Description)
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, "exception", span.Events()[0].Name)
+}
+
+func TestQueryTracer_NoRowsIsNotAnError(t *testing.T) {
+	span := traceQuery(t, getUserSQL, pgx.TraceQueryEndData{Err: pgx.ErrNoRows})
+
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Empty(t, span.Events())
+}
+
+func TestQueryName(t *testing.T) {
+	tests := map[string]string{
+		getUserSQL:                   "GetUser",
+		"-- name: ListRoles :many":   "ListRoles",
+		"SELECT 1":                   "query",
+		"-- name: Unterminated":      "query",
+		"-- a comment\nSELECT now()": "query",
+	}
+
+	for sql, name := range tests {
+		assert.Equal(t, name, queryName(sql), sql)
+	}
+}
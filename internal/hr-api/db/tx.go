@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+	"web-boilerplate/internal/hr-api/repositories"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// maxTxAttempts bounds the runs of a transaction aborted by serialization failures or deadlocks
+	maxTxAttempts = 3
+	// txRetryDelay is the base wait before running an aborted transaction again, doubled on every attempt
+	txRetryDelay = 20 * time.Millisecond
+)
+
+// TxManager runs callbacks in transactions of the pool
+type TxManager struct {
+	pool    beginner
+	queries *repositories.Queries
+}
+
+// beginner starts the transactions of a TxManager, a *pgxpool.Pool outside of tests
+type beginner interface {
+	BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error)
+}
+
+func NewTxManager(pool *pgxpool.Pool) *TxManager {
+	return &TxManager{pool: pool, queries: repositories.New(pool)}
+}
+
+// InTx runs fn with a Querier bound to a new transaction, see interfaces.TxManager
+func (m *TxManager) InTx(ctx context.Context, opts pgx.TxOptions, fn func(q repositories.Querier) error) error {
+	for attempt := 1; ; attempt++ {
+		err := m.run(ctx, opts, fn)
+		if err == nil || !isRetryable(err) || attempt == maxTxAttempts {
+			return err
+		}
+
+		// jitter keeps the transactions that conflicted from colliding again
+		delay := txRetryDelay<<(attempt-1) + rand.N(txRetryDelay)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (m *TxManager) run(ctx context.Context, opts pgx.TxOptions, fn func(q repositories.Querier) error) error {
+	tx, err := m.pool.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	// no-op once committed
+	defer tx.Rollback(ctx)
+
+	if err := fn(m.queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// isRetryable reports whether Postgres aborted the transaction because of concurrent ones,
+// running it again is expected to succeed
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	// serialization_failure and deadlock_detected
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"web-boilerplate/internal/hr-api/repositories"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTx records how a transaction ended, commitErr fails its commit
+type fakeTx struct {
+	pgx.Tx
+	commitErr error
+	committed bool
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	if tx.commitErr != nil {
+		return tx.commitErr
+	}
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	return nil
+}
+
+// fakePool starts fakeTx transactions, the commits of the first ones fail with commitErrs
+type fakePool struct {
+	begun      []*fakeTx
+	commitErrs []error
+}
+
+func (p *fakePool) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+	tx := &fakeTx{}
+	if len(p.begun) < len(p.commitErrs) {
+		tx.commitErr = p.commitErrs[len(p.begun)]
+	}
+	p.begun = append(p.begun, tx)
+	return tx, nil
+}
+
+func newTestTxManager() (*TxManager, *fakePool) {
+	pool := &fakePool{}
+	return &TxManager{pool: pool, queries: repositories.New(nil)}, pool
+}
+
+var (
+	errSerialization = &pgconn.PgError{Code: "40001", Message: "could not serialize access due to concurrent update"}
+	errDeadlock      = &pgconn.PgError{Code: "40P01", Message: "deadlock detected"}
+)
+
+func TestTxManager_InTx_RetriesAbortedTransactions(t *testing.T) {
+	m, pool := newTestTxManager()
+
+	// the first attempts conflict with concurrent transactions
+	failures := []error{errSerialization, errDeadlock}
+	attempts := 0
+	err := m.InTx(context.Background(), pgx.TxOptions{}, func(q repositories.Querier) error {
+		attempts++
+		if attempts <= len(failures) {
+			return failures[attempts-1]
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, pool.begun, 3)
+	assert.False(t, pool.begun[0].committed)
+	assert.False(t, pool.begun[1].committed)
+	assert.True(t, pool.begun[2].committed)
+}
+
+func TestTxManager_InTx_RetriesSerializationFailureOnCommit(t *testing.T) {
+	m, pool := newTestTxManager()
+	// serializable transactions may only fail when they commit
+	pool.commitErrs = []error{errSerialization}
+
+	attempts := 0
+	err := m.InTx(context.Background(), pgx.TxOptions{IsoLevel: pgx.Serializable}, func(q repositories.Querier) error {
+		attempts++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.True(t, pool.begun[1].committed)
+}
+
+func TestTxManager_InTx_GivesUpAfterMaxAttempts(t *testing.T) {
+	m, pool := newTestTxManager()
+
+	attempts := 0
+	err := m.InTx(context.Background(), pgx.TxOptions{}, func(q repositories.Querier) error {
+		attempts++
+		return errSerialization
+	})
+
+	assert.ErrorIs(t, err, errSerialization)
+	assert.Equal(t, maxTxAttempts, attempts)
+	assert.Len(t, pool.begun, maxTxAttempts)
+}
+
+func TestTxManager_InTx_DoesNotRetryOtherErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"plain error", errors.New("employee not found")},
+		{"unique violation", &pgconn.PgError{Code: "23505"}},
+		{"wrapped by the callback", errors.Join(errors.New("failed to save"), &pgconn.PgError{Code: "23503"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, pool := newTestTxManager()
+
+			attempts := 0
+			err := m.InTx(context.Background(), pgx.TxOptions{}, func(q repositories.Querier) error {
+				attempts++
+				return tt.err
+			})
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, 1, attempts)
+			assert.False(t, pool.begun[0].committed)
+		})
+	}
+}
+
+func TestTxManager_InTx_StopsRetryingOnceContextIsDone(t *testing.T) {
+	m, pool := newTestTxManager()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := m.InTx(ctx, pgx.TxOptions{}, func(q repositories.Querier) error {
+		attempts++
+		// the request is gone while the transaction runs
+		cancel()
+		return errDeadlock
+	})
+
+	assert.ErrorIs(t, err, errDeadlock)
+	assert.Equal(t, 1, attempts)
+	assert.Len(t, pool.begun, 1)
+}
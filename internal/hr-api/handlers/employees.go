@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"web-boilerplate/internal/hr-api/pkg/pagination"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/repositories"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type EmployeeParams struct {
+	FirstName  string `json:"first_name" validate:"required,max=100"`
+	LastName   string `json:"last_name" validate:"required,max=100"`
+	Email      string `json:"email" validate:"required,email,max=254"`
+	Department string `json:"department" validate:"required,max=100,department"`
+}
+
+type EmployeeResponse struct {
+	ID         string `json:"id"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	Email      string `json:"email"`
+	Department string `json:"department"`
+}
+
+func newEmployeeResponse(e repositories.Employee) EmployeeResponse {
+	return EmployeeResponse{
+		ID:         uuid.UUID(e.ID.Bytes).String(),
+		FirstName:  e.FirstName,
+		LastName:   e.LastName,
+		Email:      e.Email,
+		Department: e.Department,
+	}
+}
+
+// normalize trims surrounding whitespace from every field and lowercases the email
+func (p *EmployeeParams) normalize() {
+	p.FirstName = strings.TrimSpace(p.FirstName)
+	p.LastName = strings.TrimSpace(p.LastName)
+	p.Email = strings.ToLower(strings.TrimSpace(p.Email))
+	p.Department = strings.TrimSpace(p.Department)
+}
+
+// employeeSortFields are the accepted values of the sort query param, the first being the default
+var employeeSortFields = []string{"name", "first_name", "last_name", "email", "department"}
+
+func (h *Handler) ListEmployees(c fiber.Ctx) error {
+	page, err := pagination.Parse(c, employeeSortFields...)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	department := optionalText(c.Query("department"))
+	emailDomain := optionalText(strings.TrimPrefix(c.Query("email_domain"), "@"))
+	search := optionalText(likeEscaper.Replace(c.Query("q")))
+
+	params := repositories.ListEmployeesByNameParams{
+		Department:  department,
+		EmailDomain: emailDomain,
+		Search:      search,
+		// fetch one extra row to know whether there is a next page
+		PageSize: int32(page.Limit + 1),
+	}
+	if page.Cursor != nil {
+		params.AfterKey = pgtype.Text{String: page.Cursor.Key, Valid: true}
+		params.AfterID = pgtype.UUID{Bytes: page.Cursor.ID, Valid: true}
+	}
+
+	employees, err := h.listEmployees(c.Context(), page, params)
+	if err != nil {
+		h.Log.Error(err, "failed to list employees")
+		return fiber.ErrInternalServerError
+	}
+
+	meta := pagination.Meta{Limit: page.Limit}
+	if len(employees) > page.Limit {
+		employees = employees[:page.Limit]
+		last := employees[len(employees)-1]
+		meta.NextCursor = page.Next(employeeSortKey(page.Sort, last), last.ID.Bytes)
+	}
+
+	meta.TotalEstimate, err = h.countEmployees(c, page, department, emailDomain, search)
+	if err != nil {
+		h.Log.Error(err, "failed to count employees")
+		return fiber.ErrInternalServerError
+	}
+
+	data := make([]EmployeeResponse, 0, len(employees))
+	for _, employee := range employees {
+		data = append(data, newEmployeeResponse(employee))
+	}
+
+	return c.JSON(fiber.Map{
+		"data": data,
+		"meta": meta,
+	})
+}
+
+// likeEscaper makes the q query param match literally inside a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// listEmployees runs the query of the page's sort, each one is backed by an index on (sort key, id)
+func (h *Handler) listEmployees(ctx context.Context, page pagination.Page, params repositories.ListEmployeesByNameParams) ([]repositories.Employee, error) {
+	switch page.Sort {
+	case "first_name":
+		if page.Desc {
+			return h.Repo.ListEmployeesByFirstNameDesc(ctx, repositories.ListEmployeesByFirstNameDescParams(params))
+		}
+		return h.Repo.ListEmployeesByFirstName(ctx, repositories.ListEmployeesByFirstNameParams(params))
+	case "last_name":
+		if page.Desc {
+			return h.Repo.ListEmployeesByLastNameDesc(ctx, repositories.ListEmployeesByLastNameDescParams(params))
+		}
+		return h.Repo.ListEmployeesByLastName(ctx, repositories.ListEmployeesByLastNameParams(params))
+	case "email":
+		if page.Desc {
+			return h.Repo.ListEmployeesByEmailDesc(ctx, repositories.ListEmployeesByEmailDescParams(params))
+		}
+		return h.Repo.ListEmployeesByEmail(ctx, repositories.ListEmployeesByEmailParams(params))
+	case "department":
+		if page.Desc {
+			return h.Repo.ListEmployeesByDepartmentDesc(ctx, repositories.ListEmployeesByDepartmentDescParams(params))
+		}
+		return h.Repo.ListEmployeesByDepartment(ctx, repositories.ListEmployeesByDepartmentParams(params))
+	default:
+		if page.Desc {
+			return h.Repo.ListEmployeesByNameDesc(ctx, repositories.ListEmployeesByNameDescParams(params))
+		}
+		return h.Repo.ListEmployeesByName(ctx, params)
+	}
+}
+
+// employeeSortKey is the value employees are ordered by for the given sort, as compared by the list queries
+func employeeSortKey(sort string, e repositories.Employee) string {
+	switch sort {
+	case "first_name":
+		return e.FirstName
+	case "last_name":
+		return e.LastName
+	case "email":
+		return e.Email
+	case "department":
+		return e.Department
+	default:
+		return e.LastName + " " + e.FirstName
+	}
+}
+
+// countEmployees uses the planner's row estimate for unfiltered listings, or an exact count
+// when the table was never analyzed. Filtered listings are only counted when the client asks
+// for the total, as the count goes through every matching row.
+func (h *Handler) countEmployees(c fiber.Ctx, page pagination.Page, department, emailDomain, search pgtype.Text) (*int64, error) {
+	if !department.Valid && !emailDomain.Valid && !search.Valid {
+		estimate, err := h.Repo.EstimateEmployeesCount(c.Context())
+		if err != nil || estimate >= 0 {
+			return &estimate, err
+		}
+	} else if !page.Total {
+		return nil, nil
+	}
+
+	count, err := h.Repo.CountEmployees(c.Context(), repositories.CountEmployeesParams{
+		Department:  department,
+		EmailDomain: emailDomain,
+		Search:      search,
+	})
+	return &count, err
+}
+
+// ListDepartments returns the departments employees belong to, to offer them as filters
+func (h *Handler) ListDepartments(c fiber.Ctx) error {
+	departments, err := h.Repo.ListDepartments(c.Context())
+	if err != nil {
+		h.Log.Error(err, "failed to list departments")
+		return fiber.ErrInternalServerError
+	}
+	if departments == nil {
+		departments = []string{}
+	}
+
+	return c.JSON(fiber.Map{"data": departments})
+}
+
+func (h *Handler) GetEmployee(c fiber.Ctx) error {
+	id, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	employee, err := h.Repo.GetEmployee(c.Context(), id)
+	if err != nil {
+		h.Log.Error(err, "failed to get employee")
+		return problem.From(err)
+	}
+
+	return c.JSON(newEmployeeResponse(employee))
+}
+
+func (h *Handler) CreateEmployee(c fiber.Ctx) error {
+	var params EmployeeParams
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	employee, err := h.Repo.CreateEmployee(c.Context(), repositories.CreateEmployeeParams{
+		ID:         pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		FirstName:  params.FirstName,
+		LastName:   params.LastName,
+		Email:      params.Email,
+		Department: params.Department,
+	})
+	if err != nil {
+		h.Log.Error(err, "failed to create employee")
+		return problem.From(err)
+	}
+
+	resp := newEmployeeResponse(employee)
+	h.Log.Info("employee created", "id", resp.ID)
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+func (h *Handler) UpdateEmployee(c fiber.Ctx) error {
+	id, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var params EmployeeParams
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	employee, err := h.Repo.UpdateEmployee(c.Context(), repositories.UpdateEmployeeParams{
+		ID:         id,
+		FirstName:  params.FirstName,
+		LastName:   params.LastName,
+		Email:      params.Email,
+		Department: params.Department,
+	})
+	if err != nil {
+		h.Log.Error(err, "failed to update employee")
+		return problem.From(err)
+	}
+
+	resp := newEmployeeResponse(employee)
+	h.Log.Info("employee updated", "id", resp.ID)
+
+	return c.JSON(resp)
+}
+
+func (h *Handler) DeleteEmployee(c fiber.Ctx) error {
+	id, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rows, err := h.Repo.DeleteEmployee(c.Context(), id)
+	if err != nil {
+		h.Log.Error(err, "failed to delete employee")
+		return problem.From(err)
+	}
+	if rows == 0 {
+		return fiber.ErrNotFound
+	}
+
+	h.Log.Info("employee deleted", "id", uuid.UUID(id.Bytes).String())
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
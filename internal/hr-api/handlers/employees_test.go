@@ -0,0 +1,555 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/internal/hr-api/middlewares"
+	"web-boilerplate/internal/hr-api/pkg/pagination"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/repositories"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testEmployeeID = uuid.UUID{1, 2, 3, 4}
+
+func testEmployee() repositories.Employee {
+	return repositories.Employee{
+		ID:         pgtype.UUID{Bytes: testEmployeeID, Valid: true},
+		FirstName:  "Jane",
+		LastName:   "Doe",
+		Email:      "jane.doe@example.com",
+		Department: "Engineering",
+	}
+}
+
+func setupEmployeesApp(h *Handler) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: middlewares.ErrorHandler})
+	app.Get("/employees", h.ListEmployees)
+	app.Get("/departments", h.ListDepartments)
+	app.Post("/employees", h.CreateEmployee)
+	app.Get("/employees/:id", h.GetEmployee)
+	app.Put("/employees/:id", h.UpdateEmployee)
+	app.Delete("/employees/:id", h.DeleteEmployee)
+	return app
+}
+
+func jsonBody(payload any) *bytes.Reader {
+	body, _ := json.Marshal(payload)
+	return bytes.NewReader(body)
+}
+
+func TestListEmployees_Success(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().ListEmployeesByName(context.Background(), repositories.ListEmployeesByNameParams{
+		PageSize: 21,
+	}).Return([]repositories.Employee{testEmployee()}, nil)
+	mockRepo.EXPECT().EstimateEmployeesCount(context.Background()).Return(1, nil)
+
+	h := &Handler{
+		Log:  interfaces.NewMockLogger(t),
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	req := httptest.NewRequest("GET", "/employees", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var respBody struct {
+		Data []EmployeeResponse `json:"data"`
+		Meta pagination.Meta    `json:"meta"`
+	}
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Len(t, respBody.Data, 1)
+	assert.Equal(t, "01020304-0000-0000-0000-000000000000", respBody.Data[0].ID)
+	assert.Equal(t, "jane.doe@example.com", respBody.Data[0].Email)
+	assert.Equal(t, 20, respBody.Meta.Limit)
+	assert.Empty(t, respBody.Meta.NextCursor)
+	if assert.NotNil(t, respBody.Meta.TotalEstimate) {
+		assert.Equal(t, int64(1), *respBody.Meta.TotalEstimate)
+	}
+}
+
+func TestListEmployees_FilteredNextPage(t *testing.T) {
+	secondID := uuid.UUID{5, 6, 7, 8}
+	second := testEmployee()
+	second.ID = pgtype.UUID{Bytes: secondID, Valid: true}
+
+	cursor := pagination.Cursor{Sort: "email", Desc: true, Key: "x@example.com", ID: uuid.UUID{9}}
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().ListEmployeesByEmailDesc(context.Background(), repositories.ListEmployeesByEmailDescParams{
+		Department:  pgtype.Text{String: "Engineering", Valid: true},
+		EmailDomain: pgtype.Text{String: "example.com", Valid: true},
+		AfterKey:    pgtype.Text{String: "x@example.com", Valid: true},
+		AfterID:     pgtype.UUID{Bytes: uuid.UUID{9}, Valid: true},
+		PageSize:    2,
+	}).Return([]repositories.Employee{testEmployee(), second}, nil)
+	mockRepo.EXPECT().CountEmployees(context.Background(), repositories.CountEmployeesParams{
+		Department:  pgtype.Text{String: "Engineering", Valid: true},
+		EmailDomain: pgtype.Text{String: "example.com", Valid: true},
+	}).Return(7, nil)
+
+	h := &Handler{
+		Log:  interfaces.NewMockLogger(t),
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	req := httptest.NewRequest("GET", "/employees?limit=1&sort=email&order=desc&department=Engineering&email_domain=@example.com&include_total=true&cursor="+cursor.Encode(), nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var respBody struct {
+		Data []EmployeeResponse `json:"data"`
+		Meta pagination.Meta    `json:"meta"`
+	}
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Len(t, respBody.Data, 1)
+	if assert.NotNil(t, respBody.Meta.TotalEstimate) {
+		assert.Equal(t, int64(7), *respBody.Meta.TotalEstimate)
+	}
+
+	next, err := pagination.Decode(respBody.Meta.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, pagination.Cursor{Sort: "email", Desc: true, Key: "jane.doe@example.com", ID: testEmployeeID}, next)
+}
+
+func TestListEmployees_SearchEscapesWildcards(t *testing.T) {
+	search := pgtype.Text{String: `50\% o\_neil\\`, Valid: true}
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().ListEmployeesByName(context.Background(), repositories.ListEmployeesByNameParams{
+		Search:   search,
+		PageSize: 21,
+	}).Return([]repositories.Employee{}, nil)
+
+	h := &Handler{
+		Log:  interfaces.NewMockLogger(t),
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	req := httptest.NewRequest("GET", "/employees?q="+url.QueryEscape(` 50% o_neil\ `), nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var respBody struct {
+		Data []EmployeeResponse `json:"data"`
+		Meta pagination.Meta    `json:"meta"`
+	}
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NotNil(t, respBody.Data)
+	assert.Empty(t, respBody.Data)
+	// filtered listings are only counted on request
+	assert.Nil(t, respBody.Meta.TotalEstimate)
+}
+
+func TestListEmployees_QueryOfEachSort(t *testing.T) {
+	tests := []struct {
+		query  string
+		method string
+		key    string
+	}{
+		{"", "ListEmployeesByName", "Doe Jane"},
+		{"order=desc", "ListEmployeesByNameDesc", "Doe Jane"},
+		{"sort=first_name", "ListEmployeesByFirstName", "Jane"},
+		{"sort=first_name&order=desc", "ListEmployeesByFirstNameDesc", "Jane"},
+		{"sort=last_name", "ListEmployeesByLastName", "Doe"},
+		{"sort=last_name&order=desc", "ListEmployeesByLastNameDesc", "Doe"},
+		{"sort=email", "ListEmployeesByEmail", "jane.doe@example.com"},
+		{"sort=email&order=desc", "ListEmployeesByEmailDesc", "jane.doe@example.com"},
+		{"sort=department", "ListEmployeesByDepartment", "Engineering"},
+		{"sort=department&order=desc", "ListEmployeesByDepartmentDesc", "Engineering"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			mockRepo := repositories.NewMockQuerier(t)
+			mockRepo.On(tt.method, mock.Anything, mock.Anything).
+				Return([]repositories.Employee{testEmployee(), testEmployee()}, nil)
+			mockRepo.EXPECT().EstimateEmployeesCount(mock.Anything).Return(2, nil)
+
+			h := &Handler{
+				Log:  interfaces.NewMockLogger(t),
+				Repo: mockRepo,
+			}
+			app := setupEmployeesApp(h)
+
+			req := httptest.NewRequest("GET", "/employees?limit=1&"+tt.query, nil)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			if err != nil {
+				t.FailNow()
+			}
+
+			assert.Equal(t, 200, resp.StatusCode)
+
+			var respBody struct {
+				Meta pagination.Meta `json:"meta"`
+			}
+			json.NewDecoder(resp.Body).Decode(&respBody)
+			next, err := pagination.Decode(respBody.Meta.NextCursor)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.key, next.Key)
+			assert.Equal(t, testEmployeeID, next.ID)
+		})
+	}
+}
+
+func TestListDepartments_Success(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().ListDepartments(context.Background()).Return([]string{"Engineering", "Sales"}, nil)
+
+	h := &Handler{
+		Log:  interfaces.NewMockLogger(t),
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	req := httptest.NewRequest("GET", "/departments", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var respBody struct {
+		Data []string `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, []string{"Engineering", "Sales"}, respBody.Data)
+}
+
+func TestListEmployees_InvalidQuery(t *testing.T) {
+	h := &Handler{
+		Log: interfaces.NewMockLogger(t),
+	}
+	app := setupEmployeesApp(h)
+
+	mismatched := pagination.Cursor{Sort: "email", Key: "a", ID: testEmployeeID}.Encode()
+	for _, query := range []string{"limit=0", "limit=abc", "sort=password", "order=up", "cursor=garbage!", "cursor=" + mismatched} {
+		req := httptest.NewRequest("GET", "/employees?"+query, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		if err != nil {
+			t.FailNow()
+		}
+
+		assert.Equal(t, 400, resp.StatusCode, query)
+	}
+}
+
+func TestGetEmployee_Success(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetEmployee(context.Background(), pgtype.UUID{Bytes: testEmployeeID, Valid: true}).Return(testEmployee(), nil)
+
+	h := &Handler{
+		Log:  interfaces.NewMockLogger(t),
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	req := httptest.NewRequest("GET", "/employees/"+testEmployeeID.String(), nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var respBody EmployeeResponse
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, "Jane", respBody.FirstName)
+	assert.Equal(t, "Engineering", respBody.Department)
+}
+
+func TestGetEmployee_InvalidID(t *testing.T) {
+	h := &Handler{
+		Log: interfaces.NewMockLogger(t),
+	}
+	app := setupEmployeesApp(h)
+
+	req := httptest.NewRequest("GET", "/employees/not-a-uuid", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestGetEmployee_NotFound(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetEmployee(context.Background(), pgtype.UUID{Bytes: testEmployeeID, Valid: true}).Return(repositories.Employee{}, pgx.ErrNoRows)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Error(pgx.ErrNoRows, "failed to get employee")
+
+	h := &Handler{
+		Log:  mockLogger,
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	req := httptest.NewRequest("GET", "/employees/"+testEmployeeID.String(), nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestCreateEmployee_Success(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().CreateEmployee(context.Background(), mock.MatchedBy(func(arg repositories.CreateEmployeeParams) bool {
+		return arg.ID.Valid && arg.Email == "jane.doe@example.com" && arg.FirstName == "Jane"
+	})).Return(testEmployee(), nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("employee created", []any{"id", testEmployeeID.String()})
+
+	h := &Handler{
+		Log:  mockLogger,
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	payload := map[string]string{
+		"first_name": " Jane ",
+		"last_name":  "Doe",
+		"email":      "Jane.Doe@example.com",
+		"department": "Engineering",
+	}
+	req := httptest.NewRequest("POST", "/employees", jsonBody(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 201, resp.StatusCode)
+
+	var respBody EmployeeResponse
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, testEmployeeID.String(), respBody.ID)
+}
+
+func TestCreateEmployee_ValidationFailed(t *testing.T) {
+	h := &Handler{
+		Log: interfaces.NewMockLogger(t),
+	}
+	app := setupEmployeesApp(h)
+
+	payload := map[string]string{
+		"first_name": "Jane",
+		"email":      "not-an-email",
+	}
+	req := httptest.NewRequest("POST", "/employees", jsonBody(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, problem.ContentType, resp.Header.Get("Content-Type"))
+
+	var respBody problem.Problem
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, problem.CodeValidationFailed, respBody.Code)
+
+	fields := map[string]string{}
+	for _, field := range respBody.Errors {
+		fields[field.Field] = field.Message
+	}
+	assert.Contains(t, fields, "last_name")
+	assert.Equal(t, "email must be a valid email address", fields["email"])
+	assert.Contains(t, fields, "department")
+	assert.NotContains(t, fields, "first_name")
+}
+
+func TestCreateEmployee_NormalizedBeforeValidation(t *testing.T) {
+	h := &Handler{
+		Log: interfaces.NewMockLogger(t),
+	}
+	app := setupEmployeesApp(h)
+
+	payload := map[string]string{
+		"first_name": "   ",
+		"last_name":  "Doe",
+		"email":      " Jane.Doe@Example.com ",
+		"department": "Engineering; DROP TABLE",
+	}
+	req := httptest.NewRequest("POST", "/employees", jsonBody(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	assert.Equal(t, 400, resp.StatusCode)
+
+	var respBody problem.Problem
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, []problem.FieldError{
+		{Field: "department", Message: "department must only contain letters, digits, spaces and & , . - / ( )"},
+		{Field: "first_name", Message: "first_name is required"},
+	}, respBody.Errors)
+}
+
+func TestCreateEmployee_DuplicateEmail(t *testing.T) {
+	pgErr := &pgconn.PgError{Code: "23505", TableName: "employees", ConstraintName: "employees_email_key"}
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().CreateEmployee(context.Background(), mock.AnythingOfType("repositories.CreateEmployeeParams")).Return(repositories.Employee{}, pgErr)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Error(pgErr, "failed to create employee")
+
+	h := &Handler{
+		Log:  mockLogger,
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	payload := map[string]string{
+		"first_name": "Jane",
+		"last_name":  "Doe",
+		"email":      "jane.doe@example.com",
+		"department": "Engineering",
+	}
+	req := httptest.NewRequest("POST", "/employees", jsonBody(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 409, resp.StatusCode)
+
+	var respBody problem.Problem
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, problem.CodeAlreadyExists, respBody.Code)
+	assert.Equal(t, "a record with the same email already exists", respBody.Detail)
+}
+
+func TestUpdateEmployee_NotFound(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().UpdateEmployee(context.Background(), repositories.UpdateEmployeeParams{
+		ID:         pgtype.UUID{Bytes: testEmployeeID, Valid: true},
+		FirstName:  "Jane",
+		LastName:   "Doe",
+		Email:      "jane.doe@example.com",
+		Department: "Engineering",
+	}).Return(repositories.Employee{}, pgx.ErrNoRows)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Error(pgx.ErrNoRows, "failed to update employee")
+
+	h := &Handler{
+		Log:  mockLogger,
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	payload := map[string]string{
+		"first_name": "Jane",
+		"last_name":  "Doe",
+		"email":      "jane.doe@example.com",
+		"department": "Engineering",
+	}
+	req := httptest.NewRequest("PUT", "/employees/"+testEmployeeID.String(), jsonBody(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestDeleteEmployee_Success(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().DeleteEmployee(context.Background(), pgtype.UUID{Bytes: testEmployeeID, Valid: true}).Return(1, nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("employee deleted", []any{"id", testEmployeeID.String()})
+
+	h := &Handler{
+		Log:  mockLogger,
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	req := httptest.NewRequest("DELETE", "/employees/"+testEmployeeID.String(), nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 204, resp.StatusCode)
+}
+
+func TestDeleteEmployee_NotFound(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().DeleteEmployee(context.Background(), pgtype.UUID{Bytes: testEmployeeID, Valid: true}).Return(0, nil)
+
+	h := &Handler{
+		Log:  interfaces.NewMockLogger(t),
+		Repo: mockRepo,
+	}
+	app := setupEmployeesApp(h)
+
+	req := httptest.NewRequest("DELETE", "/employees/"+testEmployeeID.String(), nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 404, resp.StatusCode)
+}
@@ -1,24 +1,65 @@
 package handlers
 
 import (
+	"web-boilerplate/internal/hr-api/config"
 	"web-boilerplate/internal/hr-api/db"
 	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/internal/hr-api/pkg/lockout"
 	"web-boilerplate/internal/hr-api/pkg/logger"
+	"web-boilerplate/internal/hr-api/pkg/metrics"
+	"web-boilerplate/internal/hr-api/pkg/notifier"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
+	"web-boilerplate/internal/hr-api/pkg/signing"
 	"web-boilerplate/internal/hr-api/repositories"
+	"web-boilerplate/shared/health"
 
 	"github.com/rs/zerolog"
 )
 
 type Handler struct {
-	Log  interfaces.Logger
-	Repo repositories.Querier
-	Pool interfaces.DBPool
+	Config      *config.Config
+	Log         interfaces.Logger
+	Repo        repositories.Querier
+	Pool        interfaces.DBPool
+	Tx          interfaces.TxManager
+	Revocations revocation.Store
+	Notifier    notifier.Notifier
+	Keys        *signing.KeySet
+	Guard       *lockout.Guard
+	Checks      *health.Registry
+	Metrics     *metrics.Metrics
+	// Ready reports false once the app is shutting down
+	Ready func() bool
 }
 
-func New(log *zerolog.Logger, dbInst *db.Database) *Handler {
+// Deps are the services shared by the handlers, main builds them once
+type Deps struct {
+	Config      *config.Config
+	Log         *zerolog.Logger
+	DB          *db.Database
+	Revocations revocation.Store
+	Notifier    notifier.Notifier
+	Keys        *signing.KeySet
+	Guard       *lockout.Guard
+	Checks      *health.Registry
+	Metrics     *metrics.Metrics
+	// Ready reports false once the app is shutting down
+	Ready func() bool
+}
+
+func New(deps Deps) *Handler {
 	return &Handler{
-		Log:  logger.NewZerologAdapter(log),
-		Repo: repositories.New(dbInst.Pool),
-		Pool: dbInst.Pool,
+		Config:      deps.Config,
+		Log:         logger.NewZerologAdapter(deps.Log),
+		Repo:        repositories.New(deps.DB.Pool),
+		Pool:        deps.DB.Pool,
+		Tx:          db.NewTxManager(deps.DB.Pool),
+		Revocations: deps.Revocations,
+		Notifier:    deps.Notifier,
+		Keys:        deps.Keys,
+		Guard:       deps.Guard,
+		Checks:      deps.Checks,
+		Metrics:     deps.Metrics,
+		Ready:       deps.Ready,
 	}
 }
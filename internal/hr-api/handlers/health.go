@@ -1,20 +1,47 @@
 package handlers
 
 import (
+	"fmt"
+	"web-boilerplate/shared/health"
+
 	"github.com/gofiber/fiber/v3"
 )
 
-func (h *Handler) Health(ctx fiber.Ctx) error {
-	// check for db connection
-	// check for db connection
-	if err := h.Pool.Ping(ctx.Context()); err != nil {
-		h.Log.Error(err, "database ping failed")
-		return ctx.Status(fiber.StatusInternalServerError).SendString("Database Unreachable")
+// Livez answers as long as the process serves requests, it never checks dependencies
+func (h *Handler) Livez(ctx fiber.Ctx) error {
+	return ctx.JSON(fiber.Map{"status": health.StatusUp})
+}
+
+// Readyz fails while shutting down or while a critical dependency is down
+func (h *Handler) Readyz(ctx fiber.Ctx) error {
+	// fail first on shutdown so load balancers stop sending requests
+	if h.Ready != nil && !h.Ready() {
+		return ctx.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status": health.StatusDown,
+			"reason": "shutting down",
+		})
 	}
 
-	// TODO: check for redis connection
-	// TODO: check for other services
+	report := h.Checks.Check(ctx.Context())
+	if !report.Healthy() {
+		return ctx.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": report.Status})
+	}
 
-	h.Log.Info("health check passed")
-	return ctx.Status(fiber.StatusOK).SendString("OK")
+	return ctx.JSON(fiber.Map{"status": report.Status})
+}
+
+// Health reports the status and latency of every dependency
+func (h *Handler) Health(ctx fiber.Ctx) error {
+	report := h.Checks.Check(ctx.Context())
+	for name, result := range report.Checks {
+		if result.Err != nil {
+			h.Log.Error(fmt.Errorf("%s: %w", name, result.Err), "health check failed")
+		}
+	}
+
+	status := fiber.StatusOK
+	if !report.Healthy() {
+		status = fiber.StatusServiceUnavailable
+	}
+	return ctx.Status(status).JSON(report)
 }
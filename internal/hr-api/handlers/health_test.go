@@ -2,54 +2,158 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/shared/health"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+func newHealthApp(h *Handler) *fiber.App {
+	app := fiber.New()
+	app.Get("/livez", h.Livez)
+	app.Get("/readyz", h.Readyz)
+	app.Get("/health", h.Health)
+	return app
+}
+
+func newTestChecks(pool interfaces.DBPool) *health.Registry {
+	checks := health.NewRegistry(time.Minute, time.Second)
+	checks.Register("postgres", health.CheckerFunc(pool.Ping))
+	return checks
+}
+
+func getHealth(t *testing.T, app *fiber.App, path string) (int, map[string]any) {
+	resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	var body map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
 func TestHealth_Success(t *testing.T) {
 	mockPool := interfaces.NewMockDBPool(t)
-	mockPool.EXPECT().Ping(context.Background()).Return(nil)
+	mockPool.EXPECT().Ping(mock.Anything).Return(nil)
+
+	h := &Handler{
+		Log:    interfaces.NewMockLogger(t),
+		Checks: newTestChecks(mockPool),
+	}
+
+	status, body := getHealth(t, newHealthApp(h), "/health")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "up", body["status"])
+
+	postgres := body["checks"].(map[string]any)["postgres"].(map[string]any)
+	assert.Equal(t, "up", postgres["status"])
+	assert.Contains(t, postgres, "latency_ms")
+}
+
+func TestHealth_DBFailure(t *testing.T) {
+	mockPool := interfaces.NewMockDBPool(t)
+	mockPool.EXPECT().Ping(mock.Anything).Return(assert.AnError)
 
 	mockLogger := interfaces.NewMockLogger(t)
-	mockLogger.EXPECT().Info("health check passed", mock.Anything)
+	mockLogger.EXPECT().Error(mock.Anything, "health check failed")
 
-	h := Handler{
-		Pool: mockPool,
-		Log:  mockLogger,
+	h := &Handler{
+		Log:    mockLogger,
+		Checks: newTestChecks(mockPool),
 	}
 
-	app := fiber.New()
-	app.Get("/health", h.Health)
-
-	req := httptest.NewRequest("GET", "/health", nil)
-	resp, _ := app.Test(req)
+	status, body := getHealth(t, newHealthApp(h), "/health")
+	assert.Equal(t, 503, status)
+	assert.Equal(t, "down", body["status"])
 
-	assert.Equal(t, 200, resp.StatusCode)
+	postgres := body["checks"].(map[string]any)["postgres"].(map[string]any)
+	assert.Equal(t, assert.AnError.Error(), postgres["error"])
 }
 
-func TestHealth_DBFailure(t *testing.T) {
+func TestHealth_OptionalFailureDegrades(t *testing.T) {
 	mockPool := interfaces.NewMockDBPool(t)
-	mockPool.EXPECT().Ping(context.Background()).Return(assert.AnError)
+	mockPool.EXPECT().Ping(mock.Anything).Return(nil)
+
+	checks := newTestChecks(mockPool)
+	checks.RegisterOptional("s3", health.CheckerFunc(func(ctx context.Context) error { return assert.AnError }))
 
 	mockLogger := interfaces.NewMockLogger(t)
-	mockLogger.EXPECT().Error(assert.AnError, "database ping failed")
+	mockLogger.EXPECT().Error(mock.Anything, "health check failed")
 
-	h := Handler{
-		Pool: mockPool,
-		Log:  mockLogger,
+	h := &Handler{
+		Log:    mockLogger,
+		Checks: checks,
 	}
 
-	app := fiber.New()
-	app.Get("/health", h.Health)
+	status, body := getHealth(t, newHealthApp(h), "/health")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "degraded", body["status"])
+
+	// degraded is still ready
+	status, _ = getHealth(t, newHealthApp(h), "/readyz")
+	assert.Equal(t, 200, status)
+}
+
+func TestHealth_CachesResults(t *testing.T) {
+	mockPool := interfaces.NewMockDBPool(t)
+	mockPool.EXPECT().Ping(mock.Anything).Return(nil).Once()
+
+	h := &Handler{
+		Log:    interfaces.NewMockLogger(t),
+		Checks: newTestChecks(mockPool),
+	}
+
+	app := newHealthApp(h)
+	for range 3 {
+		status, _ := getHealth(t, app, "/readyz")
+		assert.Equal(t, 200, status)
+	}
+}
 
-	req := httptest.NewRequest("GET", "/health", nil)
-	resp, _ := app.Test(req)
+func TestLivez_IgnoresDependencies(t *testing.T) {
+	// the pool isn't pinged
+	h := &Handler{
+		Log:    interfaces.NewMockLogger(t),
+		Checks: newTestChecks(interfaces.NewMockDBPool(t)),
+	}
+
+	status, body := getHealth(t, newHealthApp(h), "/livez")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "up", body["status"])
+}
+
+func TestReadyz_DBFailure(t *testing.T) {
+	mockPool := interfaces.NewMockDBPool(t)
+	mockPool.EXPECT().Ping(mock.Anything).Return(assert.AnError)
+
+	h := &Handler{
+		Log:    interfaces.NewMockLogger(t),
+		Checks: newTestChecks(mockPool),
+	}
+
+	status, body := getHealth(t, newHealthApp(h), "/readyz")
+	assert.Equal(t, 503, status)
+	assert.Equal(t, "down", body["status"])
+}
+
+func TestReadyz_ShuttingDown(t *testing.T) {
+	// the pool isn't pinged once shutting down
+	h := &Handler{
+		Log:    interfaces.NewMockLogger(t),
+		Checks: newTestChecks(interfaces.NewMockDBPool(t)),
+		Ready:  func() bool { return false },
+	}
 
-	assert.Equal(t, 500, resp.StatusCode)
+	status, body := getHealth(t, newHealthApp(h), "/readyz")
+	assert.Equal(t, 503, status)
+	assert.Equal(t, "shutting down", body["reason"])
 }
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/pkg/validation"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// bindBody binds the request body into params, normalizes it when params has a normalize method
+// and validates it against its validate tags, invalid fields are answered with a validation problem
+func (h *Handler) bindBody(c fiber.Ctx, params any) error {
+	if err := c.Bind().Body(params); err != nil {
+		h.Log.Error(err, "failed to bind body")
+		return fiber.ErrBadRequest
+	}
+
+	if n, ok := params.(interface{ normalize() }); ok {
+		n.normalize()
+	}
+
+	err := validation.Struct(c.Context(), params)
+	var errs validation.Errors
+	if errors.As(err, &errs) {
+		return problem.Validation(errs)
+	}
+	if err != nil {
+		h.Log.Error(err, "failed to validate body")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// parseUUIDParam parses the named route param as a UUID
+// and returns a 400 error when it is missing or malformed
+func parseUUIDParam(c fiber.Ctx, name string) (pgtype.UUID, error) {
+	id, err := uuid.Parse(c.Params(name))
+	if err != nil {
+		return pgtype.UUID{}, fiber.NewError(fiber.StatusBadRequest, "invalid "+name)
+	}
+
+	return pgtype.UUID{Bytes: id, Valid: true}, nil
+}
+
+// userClaims returns the access token claims stored by middlewares.Protected
+func userClaims(c fiber.Ctx) (jwt.MapClaims, bool) {
+	switch claims := c.Locals("user").(type) {
+	case jwt.MapClaims:
+		return claims, true
+	case map[string]any:
+		return jwt.MapClaims(claims), true
+	default:
+		return nil, false
+	}
+}
+
+// optionalText converts an optional string param to a nullable query argument
+func optionalText(s string) pgtype.Text {
+	s = strings.TrimSpace(s)
+	return pgtype.Text{String: s, Valid: s != ""}
+}
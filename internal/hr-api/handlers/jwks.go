@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v3"
+)
+
+// JWKS publishes the public keys access tokens are verified with,
+// keys added by a rotation show up here before they sign anything
+func (h *Handler) JWKS(c fiber.Ctx) error {
+	set, err := h.Keys.JWKS()
+	if err != nil {
+		h.Log.Error(err, "failed to build jwks")
+		return fiber.ErrInternalServerError
+	}
+
+	// verifiers refetch on unknown kids, a short cache is enough
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+	return c.JSON(set)
+}
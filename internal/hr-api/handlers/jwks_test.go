@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/shared/jwks"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKS_VerifiesIssuedTokens(t *testing.T) {
+	h := &Handler{
+		Config: &testConfig,
+		Log:    interfaces.NewMockLogger(t),
+		Keys:   testKeys,
+	}
+
+	app := fiber.New()
+	app.Get("/.well-known/jwks.json", h.JWKS)
+
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+	resp, err := app.Test(req, fiber.TestConfig{
+		Timeout: 20 * time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var set jwks.Set
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&set))
+	assert.NotEmpty(t, set.Keys)
+	for _, key := range set.Keys {
+		// only public material is published
+		assert.Empty(t, key.N+key.E)
+		assert.Equal(t, "OKP", key.Kty)
+		assert.Equal(t, "sig", key.Use)
+	}
+
+	tokenString, err := h.newAccessToken(testUser, 0, []string{})
+	assert.NoError(t, err)
+
+	// a verifier only holding the published keys accepts the token
+	_, err = jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		for _, key := range set.Keys {
+			if key.Kid == token.Header["kid"] {
+				return key.PublicKey()
+			}
+		}
+		return nil, jwt.ErrTokenUnverifiable
+	}, jwt.WithValidMethods([]string{"EdDSA"}))
+	assert.NoError(t, err)
+}
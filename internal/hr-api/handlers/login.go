@@ -1,34 +1,48 @@
 package handlers
 
 import (
-	"context"
 	"errors"
+	"math"
+	"strconv"
 	"time"
-	"web-boilerplate/internal/hr-api/config"
+	"web-boilerplate/internal/hr-api/pkg/metrics"
+	"web-boilerplate/internal/hr-api/pkg/problem"
 	"web-boilerplate/shared/helpers"
 
 	"github.com/gofiber/fiber/v3"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type LoginParams struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `json:"username" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,max=72"`
 }
 
 func (h *Handler) Login(c fiber.Ctx) error {
 	var params LoginParams
-	err := c.Bind().Body(&params)
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	// refuse attempts on locked accounts and IPs before looking at the password
+	wait, err := h.Guard.Check(c.Context(), params.Username, c.IP())
 	if err != nil {
-		h.Log.Error(err, "failed to bind body")
-		return fiber.ErrBadRequest
+		h.Log.Error(err, "failed to check login lockout")
+		return fiber.ErrInternalServerError
+	}
+	if wait > 0 {
+		h.Log.Info("login attempt while locked out", "username", params.Username)
+		return h.tooManyAttempts(c, wait)
 	}
 
-	user, err := h.Repo.GetUserByUsername(context.Background(), params.Username)
+	user, err := h.Repo.GetUserByUsername(c.Context(), params.Username)
 	if err != nil {
 		h.Log.Error(err, "user not found or db error")
+		// unknown usernames count too, so guessing them is throttled the same way
+		h.loginFailed(c, params.Username)
 		return fiber.ErrUnauthorized
 	}
 
@@ -36,32 +50,64 @@ func (h *Handler) Login(c fiber.Ctx) error {
 	if err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
 			h.Log.Info("invalid password attempt")
+			h.loginFailed(c, params.Username)
 			return fiber.ErrUnauthorized
 		}
 		h.Log.Error(err, "failed to compare password")
 		return fiber.ErrInternalServerError
 	}
 
-	// 4. Generate JWT Token
-	// Convert pgtype.UUID to google/uuid for easier string handling if needed,
-	// but we can just use the bytes directly for formatting or use a string helper.
-	userID := uuid.UUID(user.ID.Bytes).String()
+	// users with 2FA enabled get a challenge to exchange with a code on /login/2fa
+	stored, err := h.Repo.GetUserTOTP(c.Context(), user.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		h.Log.Error(err, "failed to get totp secret")
+		return fiber.ErrInternalServerError
+	}
+	if err == nil && stored.EnabledAt.Valid {
+		challenge, err := h.mfaChallenge(c.Context(), user)
+		if err != nil {
+			h.Log.Error(err, "failed to issue mfa challenge")
+			return fiber.ErrInternalServerError
+		}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"id":  userID,
-		"exp": time.Now().Add(config.TOKEN_TTL).Unix(),
-	})
+		h.Metrics.LoginAttempt(metrics.LoginMFARequired)
+		h.Log.Info("login requires second factor", "username", params.Username)
+
+		return c.JSON(challenge)
+	}
 
-	tokenString, err := token.SignedString([]byte(config.SECRET_KEY))
+	// 4. Generate the access token and start a new refresh token family
+	resp, _, err := h.issueTokens(c.Context(), user, pgtype.UUID{Bytes: uuid.New(), Valid: true})
 	if err != nil {
-		h.Log.Error(err, "failed to sign token")
+		h.Log.Error(err, "failed to issue tokens")
 		return fiber.ErrInternalServerError
 	}
 
-	h.Log.Info("login successful", "username", params.Username, "id", userID)
+	if err := h.Guard.Succeeded(c.Context(), params.Username); err != nil {
+		h.Log.Error(err, "failed to reset login failures")
+	}
+
+	h.Metrics.LoginAttempt(metrics.LoginSuccess)
+	h.Log.Info("login successful", "username", params.Username, "id", resp.ID)
+
+	return c.JSON(resp)
+}
+
+// loginFailed records a failed login attempt, a failure to record it doesn't change the answer
+func (h *Handler) loginFailed(c fiber.Ctx, username string) {
+	h.Metrics.LoginAttempt(metrics.LoginFailure)
+	if err := h.Guard.Failed(c.Context(), username, c.IP()); err != nil {
+		h.Log.Error(err, "failed to record login failure")
+	}
+}
+
+// tooManyAttempts answers 429 with the number of seconds to wait in Retry-After
+func (h *Handler) tooManyAttempts(c fiber.Ctx, wait time.Duration) error {
+	h.Metrics.LoginAttempt(metrics.LoginLocked)
+
+	seconds := int64(math.Ceil(wait.Seconds()))
+	c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(seconds, 10))
 
-	return c.JSON(fiber.Map{
-		"token": tokenString,
-		"id":    userID,
-	})
+	return problem.New(fiber.StatusTooManyRequests, problem.CodeLoginLocked, "too many failed login attempts").
+		With("retry_after", seconds)
 }
@@ -4,15 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/internal/hr-api/middlewares"
+	"web-boilerplate/internal/hr-api/pkg/lockout"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
 	"web-boilerplate/internal/hr-api/repositories"
 	"web-boilerplate/shared/helpers"
 
 	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -25,14 +32,24 @@ func TestLogin_Success(t *testing.T) {
 		ID:       pgtype.UUID{Bytes: [16]byte{1, 2, 3, 4}, Valid: true},
 		Username: "testuser",
 		Password: hashedPassword,
+		Role:     "employee",
 	}, nil)
+	mockRepo.EXPECT().GetUserTOTP(context.Background(), pgtype.UUID{Bytes: [16]byte{1, 2, 3, 4}, Valid: true}).Return(repositories.UserTotp{}, pgx.ErrNoRows)
+	mockRepo.EXPECT().ListRolePermissions(context.Background(), "employee").Return([]string{"employees:read"}, nil)
+	mockRepo.EXPECT().CreateRefreshToken(context.Background(), mock.MatchedBy(func(arg repositories.CreateRefreshTokenParams) bool {
+		return arg.UserID.Bytes == [16]byte{1, 2, 3, 4} && arg.FamilyID.Valid && arg.TokenHash != ""
+	})).Return(repositories.RefreshToken{}, nil)
 
 	mockLogger := interfaces.NewMockLogger(t)
 	mockLogger.EXPECT().Info("login successful", []any{"username", "testuser", "id", uuid.UUID{1, 2, 3, 4}.String()})
 
 	h := &Handler{
-		Log:  mockLogger,
-		Repo: mockRepo,
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Repo:        mockRepo,
+		Revocations: revocation.NewMemoryStore(),
+		Keys:        testKeys,
+		Guard:       newTestGuard(),
 	}
 
 	// 3. Setup Fiber app
@@ -63,7 +80,17 @@ func TestLogin_Success(t *testing.T) {
 	var respBody map[string]any
 	json.NewDecoder(resp.Body).Decode(&respBody)
 	assert.NotEmpty(t, respBody["token"])
+	assert.NotEmpty(t, respBody["refresh_token"])
 	assert.Equal(t, "01020304-0000-0000-0000-000000000000", respBody["id"])
+
+	// the role and its permissions are embedded in the access token
+	tokenString, _ := respBody["token"].(string)
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokenString, claims, testKeys.Keyfunc)
+	assert.NoError(t, err)
+	assert.Equal(t, "employee", claims["role"])
+	assert.Equal(t, []any{"employees:read"}, claims["permissions"])
+	assert.Equal(t, "access", claims["typ"])
 }
 
 func TestLogin_InvalidBody(t *testing.T) {
@@ -71,7 +98,8 @@ func TestLogin_InvalidBody(t *testing.T) {
 	mockLogger.EXPECT().Error(mock.AnythingOfType("*json.SyntaxError"), "failed to bind body")
 
 	h := &Handler{
-		Log: mockLogger,
+		Config: &testConfig,
+		Log:    mockLogger,
 	}
 	app := fiber.New()
 	app.Post("/login", h.Login)
@@ -90,6 +118,34 @@ func TestLogin_InvalidBody(t *testing.T) {
 	assert.Equal(t, 400, resp.StatusCode)
 }
 
+func TestLogin_MissingFields(t *testing.T) {
+	// neither the lockout store nor the database are reached
+	h := &Handler{
+		Config: &testConfig,
+		Log:    interfaces.NewMockLogger(t),
+	}
+	app := fiber.New(fiber.Config{ErrorHandler: middlewares.ErrorHandler})
+	app.Post("/login", h.Login)
+
+	req := httptest.NewRequest("POST", "/login", jsonBody(map[string]string{"username": "", "password": ""}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	assert.Equal(t, 400, resp.StatusCode)
+
+	var respBody problem.Problem
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, problem.CodeValidationFailed, respBody.Code)
+	assert.Equal(t, []problem.FieldError{
+		{Field: "password", Message: "password is required"},
+		{Field: "username", Message: "username is required"},
+	}, respBody.Errors)
+}
+
 func TestLogin_UserNotFound(t *testing.T) {
 	mockRepo := repositories.NewMockQuerier(t)
 	mockRepo.EXPECT().GetUserByUsername(context.Background(), "unknown").Return(repositories.User{}, assert.AnError)
@@ -98,8 +154,10 @@ func TestLogin_UserNotFound(t *testing.T) {
 	mockLogger.EXPECT().Error(assert.AnError, "user not found or db error")
 
 	h := &Handler{
-		Log:  mockLogger,
-		Repo: mockRepo,
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+		Guard:  newTestGuard(),
 	}
 	app := fiber.New()
 	app.Post("/login", h.Login)
@@ -136,8 +194,10 @@ func TestLogin_InvalidPassword(t *testing.T) {
 	mockLogger.EXPECT().Info("invalid password attempt")
 
 	h := &Handler{
-		Log:  mockLogger,
-		Repo: mockRepo,
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+		Guard:  newTestGuard(),
 	}
 	app := fiber.New()
 	app.Post("/login", h.Login)
@@ -169,8 +229,10 @@ func TestLogin_DBError(t *testing.T) {
 	mockLogger.EXPECT().Error(assert.AnError, "user not found or db error")
 
 	h := &Handler{
-		Log:  mockLogger,
-		Repo: mockRepo,
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+		Guard:  newTestGuard(),
 	}
 	app := fiber.New()
 	app.Post("/login", h.Login)
@@ -193,3 +255,90 @@ func TestLogin_DBError(t *testing.T) {
 
 	assert.Equal(t, 401, resp.StatusCode)
 }
+
+// newTestGuard locks accounts after 3 failures without delaying the attempts before
+func newTestGuard() *lockout.Guard {
+	return lockout.NewGuard(lockout.NewMemoryStore(), lockout.Policy{
+		MaxAttempts:      3,
+		MaxAttemptsPerIP: 10,
+		Window:           time.Minute,
+		LockoutDuration:  time.Minute,
+	})
+}
+
+func postLogin(t *testing.T, app *fiber.App, username, password string) *http.Response {
+	body, _ := json.Marshal(map[string]string{
+		"username": username,
+		"password": password,
+	})
+	req := httptest.NewRequest("POST", "/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, fiber.TestConfig{
+		Timeout: 20 * time.Second,
+	})
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	return resp
+}
+
+func TestLogin_LocksAccountAfterMaxAttempts(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUserByUsername(context.Background(), "unknown").Return(repositories.User{}, pgx.ErrNoRows).Times(3)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Error(pgx.ErrNoRows, "user not found or db error").Times(3)
+	mockLogger.EXPECT().Info("login attempt while locked out", []any{"username", "Unknown"})
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+		Guard:  newTestGuard(),
+	}
+	app := fiber.New()
+	app.Post("/login", h.Login)
+
+	for range 3 {
+		assert.Equal(t, 401, postLogin(t, app, "unknown", "password").StatusCode)
+	}
+
+	// the lockout doesn't depend on the username's case and the repository isn't queried anymore
+	resp := postLogin(t, app, "Unknown", "password")
+	assert.Equal(t, 429, resp.StatusCode)
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+}
+
+func TestLogin_ProgressiveDelay(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUserByUsername(context.Background(), "testuser").Return(repositories.User{}, pgx.ErrNoRows).Once()
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Error(pgx.ErrNoRows, "user not found or db error")
+	mockLogger.EXPECT().Info("login attempt while locked out", []any{"username", "testuser"})
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+		Guard: lockout.NewGuard(lockout.NewMemoryStore(), lockout.Policy{
+			MaxAttempts:      5,
+			MaxAttemptsPerIP: 10,
+			Window:           time.Hour,
+			LockoutDuration:  time.Hour,
+			BaseDelay:        30 * time.Second,
+		}),
+	}
+	app := fiber.New()
+	app.Post("/login", h.Login)
+
+	assert.Equal(t, 401, postLogin(t, app, "testuser", "password").StatusCode)
+
+	// retrying right away has to wait for the delay of the first failure
+	resp := postLogin(t, app, "testuser", "password")
+	assert.Equal(t, 429, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+}
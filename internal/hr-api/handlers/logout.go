@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+	"web-boilerplate/shared/helpers"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type LogoutParams struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout revokes the access token used for the request and,
+// when one is given, the refresh token family it was issued with
+func (h *Handler) Logout(c fiber.Ctx) error {
+	claims, ok := userClaims(c)
+	if !ok {
+		h.Log.Error(nil, "failed to get user claims from context")
+		return fiber.ErrUnauthorized
+	}
+
+	var params LogoutParams
+	if len(c.Body()) > 0 {
+		if err := h.bindBody(c, &params); err != nil {
+			return err
+		}
+	}
+
+	if jti, ok := claims["jti"].(string); ok && jti != "" {
+		expiresAt := time.Now().Add(h.Config.TokenTTL)
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+			expiresAt = exp.Time
+		}
+
+		if err := h.Revocations.Revoke(c.Context(), jti, expiresAt); err != nil {
+			h.Log.Error(err, "failed to revoke access token")
+			return fiber.ErrInternalServerError
+		}
+	}
+
+	if params.RefreshToken != "" {
+		if err := h.revokeRefreshToken(c, claims, params.RefreshToken); err != nil {
+			return err
+		}
+	}
+
+	h.Log.Info("logout successful", "id", claims["id"])
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// LogoutAll revokes every access and refresh token issued to the current user
+func (h *Handler) LogoutAll(c fiber.Ctx) error {
+	claims, ok := userClaims(c)
+	if !ok {
+		h.Log.Error(nil, "failed to get user claims from context")
+		return fiber.ErrUnauthorized
+	}
+
+	userIDStr, _ := claims["id"].(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.Log.Error(err, "invalid user id format")
+		return fiber.ErrUnauthorized
+	}
+
+	if _, err := h.Revocations.BumpTokenVersion(c.Context(), userIDStr); err != nil {
+		h.Log.Error(err, "failed to bump token version")
+		return fiber.ErrInternalServerError
+	}
+
+	err = h.Repo.RevokeUserRefreshTokens(c.Context(), pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		h.Log.Error(err, "failed to revoke refresh tokens")
+		return fiber.ErrInternalServerError
+	}
+
+	h.Log.Info("logout everywhere successful", "id", userIDStr)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// revokeRefreshToken revokes the family of the given refresh token, tokens that are unknown
+// or belong to another user are ignored so logout can't be used to probe for tokens
+func (h *Handler) revokeRefreshToken(c fiber.Ctx, claims jwt.MapClaims, token string) error {
+	stored, err := h.Repo.GetRefreshTokenByHash(c.Context(), helpers.HashToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		h.Log.Error(err, "failed to get refresh token")
+		return fiber.ErrInternalServerError
+	}
+
+	if uuid.UUID(stored.UserID.Bytes).String() != claims["id"] {
+		return nil
+	}
+
+	if err := h.Repo.RevokeRefreshTokenFamily(c.Context(), stored.FamilyID); err != nil {
+		h.Log.Error(err, "failed to revoke refresh token family")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
+	"web-boilerplate/internal/hr-api/repositories"
+	"web-boilerplate/shared/helpers"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newLogoutApp(h *Handler, claims jwt.MapClaims) *fiber.App {
+	app := fiber.New()
+
+	// Middleware that sets up the user claims (simulating the auth middleware)
+	app.Use(func(c fiber.Ctx) error {
+		c.Locals("user", claims)
+		return c.Next()
+	})
+
+	app.Post("/logout", h.Logout)
+	app.Post("/logout/all", h.LogoutAll)
+
+	return app
+}
+
+func TestLogout_RevokesAccessToken(t *testing.T) {
+	exp := time.Now().Add(time.Hour)
+	store := revocation.NewMemoryStore()
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("logout successful", mock.Anything)
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Revocations: store,
+	}
+
+	app := newLogoutApp(h, jwt.MapClaims{
+		"id":  uuid.UUID(testUserID.Bytes).String(),
+		"jti": "access-jti",
+		"exp": float64(exp.Unix()),
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/logout", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+
+	revoked, err := store.IsRevoked(context.Background(), "access-jti")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestLogout_RevokesRefreshTokenFamily(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetRefreshTokenByHash(mock.Anything, helpers.HashToken("refresh-token")).Return(testRefreshToken("refresh-token"), nil)
+	mockRepo.EXPECT().RevokeRefreshTokenFamily(mock.Anything, testFamilyID).Return(nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("logout successful", mock.Anything)
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Repo:        mockRepo,
+		Revocations: revocation.NewMemoryStore(),
+	}
+
+	app := newLogoutApp(h, jwt.MapClaims{
+		"id":  uuid.UUID(testUserID.Bytes).String(),
+		"jti": "access-jti",
+		"exp": float64(time.Now().Add(time.Hour).Unix()),
+	})
+
+	req := httptest.NewRequest("POST", "/logout", jsonBody(map[string]string{"refresh_token": "refresh-token"}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+}
+
+func TestLogout_IgnoresForeignRefreshToken(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetRefreshTokenByHash(mock.Anything, helpers.HashToken("unknown-token")).Return(repositories.RefreshToken{}, pgx.ErrNoRows)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("logout successful", mock.Anything)
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Repo:        mockRepo,
+		Revocations: revocation.NewMemoryStore(),
+	}
+
+	app := newLogoutApp(h, jwt.MapClaims{
+		"id":  uuid.UUID(testUserID.Bytes).String(),
+		"jti": "access-jti",
+	})
+
+	req := httptest.NewRequest("POST", "/logout", jsonBody(map[string]string{"refresh_token": "unknown-token"}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+}
+
+func TestLogoutAll_BumpsTokenVersion(t *testing.T) {
+	userID := uuid.UUID(testUserID.Bytes).String()
+	store := revocation.NewMemoryStore()
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().RevokeUserRefreshTokens(mock.Anything, testUserID).Return(nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("logout everywhere successful", []any{"id", userID})
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Repo:        mockRepo,
+		Revocations: store,
+	}
+
+	app := newLogoutApp(h, jwt.MapClaims{"id": userID})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/logout/all", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+
+	version, err := store.TokenVersion(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+}
+
+func TestLogoutAll_StoreFailure(t *testing.T) {
+	userID := uuid.UUID(testUserID.Bytes).String()
+
+	store := revocation.NewMockStore(t)
+	store.EXPECT().BumpTokenVersion(mock.Anything, userID).Return(0, assert.AnError)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Error(assert.AnError, "failed to bump token version")
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Revocations: store,
+	}
+
+	app := newLogoutApp(h, jwt.MapClaims{"id": userID})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/logout/all", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+}
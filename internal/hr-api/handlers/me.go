@@ -49,5 +49,6 @@ func (h *Handler) GetMe(c fiber.Ctx) error {
 		"name":     user.Name,
 		"email":    user.Email,
 		"username": user.Username,
+		"role":     user.Role,
 	})
 }
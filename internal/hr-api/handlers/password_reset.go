@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"web-boilerplate/internal/hr-api/pkg/notifier"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/repositories"
+	"web-boilerplate/shared/helpers"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// resetTokenBytes is the amount of entropy in a password reset token
+const resetTokenBytes = 32
+
+type PasswordResetRequestParams struct {
+	Email string `json:"email" validate:"required,email,max=254"`
+}
+
+func (p *PasswordResetRequestParams) normalize() {
+	p.Email = strings.ToLower(strings.TrimSpace(p.Email))
+}
+
+type PasswordResetConfirmParams struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,strong_password"`
+}
+
+// RequestPasswordReset sends a single use reset link to the user owning the email.
+// It always answers 202 so it can't be used to find out which emails are registered.
+func (h *Handler) RequestPasswordReset(c fiber.Ctx) error {
+	var params PasswordResetRequestParams
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	user, err := h.Repo.GetUserByEmail(c.Context(), params.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			h.Log.Info("password reset requested for unknown email")
+			return c.SendStatus(fiber.StatusAccepted)
+		}
+		h.Log.Error(err, "failed to get user by email")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := h.sendPasswordReset(c.Context(), user); err != nil {
+		h.Log.Error(err, "failed to send password reset")
+		return fiber.ErrInternalServerError
+	}
+
+	h.Log.Info("password reset requested", "id", uuid.UUID(user.ID.Bytes).String())
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+// sendPasswordReset replaces any pending reset token of the user with a new one and notifies them
+func (h *Handler) sendPasswordReset(ctx context.Context, user repositories.User) error {
+	token, err := helpers.GenerateToken(resetTokenBytes)
+	if err != nil {
+		return err
+	}
+
+	// two concurrent requests must not leave two valid tokens
+	expiresAt := time.Now().Add(h.Config.PasswordResetTTL)
+	err = h.Tx.InTx(ctx, pgx.TxOptions{}, func(q repositories.Querier) error {
+		if err := q.InvalidateUserPasswordResetTokens(ctx, user.ID); err != nil {
+			return err
+		}
+
+		_, err := q.CreatePasswordResetToken(ctx, repositories.CreatePasswordResetTokenParams{
+			ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			UserID:    user.ID,
+			TokenHash: helpers.HashToken(token),
+			ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	link := h.Config.PasswordResetURL + "?token=" + url.QueryEscape(token)
+	return h.Notifier.Send(ctx, notifier.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password, it expires at %s.\n\n%s\n\nIf you didn't ask for a password reset you can ignore this message.",
+			user.Name, expiresAt.Format(time.RFC1123), link),
+	})
+}
+
+// ConfirmPasswordReset sets a new password using a reset token and signs the user out everywhere
+func (h *Handler) ConfirmPasswordReset(c fiber.Ctx) error {
+	var params PasswordResetConfirmParams
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	invalidToken := problem.New(fiber.StatusBadRequest, problem.CodeInvalidResetToken, "invalid or expired reset token")
+
+	stored, err := h.Repo.GetPasswordResetTokenByHash(c.Context(), helpers.HashToken(params.Token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return invalidToken
+		}
+		h.Log.Error(err, "failed to get password reset token")
+		return fiber.ErrInternalServerError
+	}
+
+	// hashed before the transaction so the slow bcrypt doesn't hold it open
+	hashed, err := helpers.HashPass(params.Password)
+	if err != nil {
+		h.Log.Error(err, "failed to hash password")
+		return fiber.ErrInternalServerError
+	}
+
+	// the token is only used up if the password really changed and the sessions were revoked
+	err = h.Tx.InTx(c.Context(), pgx.TxOptions{}, func(q repositories.Querier) error {
+		// marking the token used is the single use guard, it fails for used or expired tokens
+		rows, err := q.UsePasswordResetToken(c.Context(), stored.ID)
+		if err != nil {
+			return fmt.Errorf("failed to use password reset token: %w", err)
+		}
+		if rows == 0 {
+			return invalidToken
+		}
+
+		err = q.UpdateUserPassword(c.Context(), repositories.UpdateUserPasswordParams{
+			ID:       stored.UserID,
+			Password: hashed,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update password: %w", err)
+		}
+
+		if err := q.RevokeUserRefreshTokens(c.Context(), stored.UserID); err != nil {
+			return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, invalidToken) {
+		return invalidToken
+	}
+	if err != nil {
+		h.Log.Error(err, "failed to reset password")
+		return fiber.ErrInternalServerError
+	}
+
+	// whoever knew the old password must lose access, the refresh tokens were revoked with the change
+	userID := uuid.UUID(stored.UserID.Bytes).String()
+	if _, err := h.Revocations.BumpTokenVersion(c.Context(), userID); err != nil {
+		h.Log.Error(err, "failed to bump token version")
+		return fiber.ErrInternalServerError
+	}
+
+	h.Log.Info("password reset", "id", userID)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
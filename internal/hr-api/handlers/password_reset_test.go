@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/internal/hr-api/pkg/notifier"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
+	"web-boilerplate/internal/hr-api/repositories"
+	"web-boilerplate/shared/helpers"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestTx runs the transactions of the handler straight on repo
+func newTestTx(t *testing.T, repo repositories.Querier) *interfaces.MockTxManager {
+	tx := interfaces.NewMockTxManager(t)
+	tx.EXPECT().InTx(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ pgx.TxOptions, fn func(q repositories.Querier) error) error {
+			return fn(repo)
+		}).Maybe()
+	return tx
+}
+
+func newPasswordResetApp(h *Handler) *fiber.App {
+	app := fiber.New()
+	app.Post("/password/reset", h.RequestPasswordReset)
+	app.Post("/password/reset/confirm", h.ConfirmPasswordReset)
+	return app
+}
+
+func postJSON(t *testing.T, app *fiber.App, path string, payload any) int {
+	req := httptest.NewRequest("POST", path, jsonBody(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, fiber.TestConfig{
+		Timeout: 20 * time.Second,
+	})
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	return resp.StatusCode
+}
+
+func TestRequestPasswordReset_SendsLink(t *testing.T) {
+	user := repositories.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}
+
+	var storedHash string
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUserByEmail(mock.Anything, "test@example.com").Return(user, nil)
+	mockRepo.EXPECT().InvalidateUserPasswordResetTokens(mock.Anything, testUserID).Return(nil)
+	mockRepo.EXPECT().CreatePasswordResetToken(mock.Anything, mock.MatchedBy(func(arg repositories.CreatePasswordResetTokenParams) bool {
+		storedHash = arg.TokenHash
+		return arg.UserID == testUserID && arg.ExpiresAt.Time.After(time.Now())
+	})).Return(repositories.PasswordResetToken{}, nil)
+
+	mockNotifier := notifier.NewMockNotifier(t)
+	mockNotifier.EXPECT().Send(mock.Anything, mock.MatchedBy(func(msg notifier.Message) bool {
+		// only the hash is stored, the plain token travels in the link
+		_, link, ok := strings.Cut(msg.Body, "?token=")
+		if !ok {
+			return false
+		}
+		token, err := url.QueryUnescape(strings.Fields(link)[0])
+		return err == nil && msg.To == "test@example.com" && helpers.HashToken(token) == storedHash
+	})).Return(nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("password reset requested", []any{"id", uuid.UUID(testUserID.Bytes).String()})
+
+	h := &Handler{
+		Config:   &testConfig,
+		Log:      mockLogger,
+		Repo:     mockRepo,
+		Tx:       newTestTx(t, mockRepo),
+		Notifier: mockNotifier,
+	}
+
+	status := postJSON(t, newPasswordResetApp(h), "/password/reset", map[string]string{"email": " Test@Example.com "})
+	assert.Equal(t, 202, status)
+}
+
+func TestRequestPasswordReset_UnknownEmail(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUserByEmail(mock.Anything, "nobody@example.com").Return(repositories.User{}, pgx.ErrNoRows)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("password reset requested for unknown email")
+
+	h := &Handler{
+		Config:   &testConfig,
+		Log:      mockLogger,
+		Repo:     mockRepo,
+		Notifier: notifier.NewMockNotifier(t),
+	}
+
+	// same answer as for a registered email
+	status := postJSON(t, newPasswordResetApp(h), "/password/reset", map[string]string{"email": "nobody@example.com"})
+	assert.Equal(t, 202, status)
+}
+
+func TestConfirmPasswordReset_Success(t *testing.T) {
+	resetID := pgtype.UUID{Bytes: uuid.UUID{7}, Valid: true}
+	store := revocation.NewMemoryStore()
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetPasswordResetTokenByHash(mock.Anything, helpers.HashToken("reset-token")).Return(repositories.PasswordResetToken{
+		ID:        resetID,
+		UserID:    testUserID,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}, nil)
+	mockRepo.EXPECT().UsePasswordResetToken(mock.Anything, resetID).Return(1, nil)
+	mockRepo.EXPECT().UpdateUserPassword(mock.Anything, mock.MatchedBy(func(arg repositories.UpdateUserPasswordParams) bool {
+		// comparing with bcrypt's work factor is too slow for the matcher, checking it is a bcrypt hash is enough
+		return arg.ID == testUserID && strings.HasPrefix(arg.Password, "$2a$")
+	})).Return(nil)
+	mockRepo.EXPECT().RevokeUserRefreshTokens(mock.Anything, testUserID).Return(nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("password reset", []any{"id", uuid.UUID(testUserID.Bytes).String()})
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Repo:        mockRepo,
+		Tx:          newTestTx(t, mockRepo),
+		Revocations: store,
+	}
+
+	status := postJSON(t, newPasswordResetApp(h), "/password/reset/confirm", map[string]string{
+		"token":    "reset-token",
+		"password": "new-password",
+	})
+	assert.Equal(t, 204, status)
+
+	// existing sessions are revoked
+	version, err := store.TokenVersion(context.Background(), uuid.UUID(testUserID.Bytes).String())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+}
+
+func TestConfirmPasswordReset_UsedToken(t *testing.T) {
+	resetID := pgtype.UUID{Bytes: uuid.UUID{7}, Valid: true}
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetPasswordResetTokenByHash(mock.Anything, helpers.HashToken("used-token")).Return(repositories.PasswordResetToken{
+		ID:     resetID,
+		UserID: testUserID,
+	}, nil)
+	mockRepo.EXPECT().UsePasswordResetToken(mock.Anything, resetID).Return(0, nil)
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    interfaces.NewMockLogger(t),
+		Repo:   mockRepo,
+		Tx:     newTestTx(t, mockRepo),
+	}
+
+	status := postJSON(t, newPasswordResetApp(h), "/password/reset/confirm", map[string]string{
+		"token":    "used-token",
+		"password": "new-password",
+	})
+	assert.Equal(t, 400, status)
+}
+
+func TestConfirmPasswordReset_TransactionFails(t *testing.T) {
+	resetID := pgtype.UUID{Bytes: uuid.UUID{7}, Valid: true}
+	dbErr := errors.New("connection reset")
+	store := revocation.NewMemoryStore()
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetPasswordResetTokenByHash(mock.Anything, helpers.HashToken("reset-token")).Return(repositories.PasswordResetToken{
+		ID:     resetID,
+		UserID: testUserID,
+	}, nil)
+	mockRepo.EXPECT().UsePasswordResetToken(mock.Anything, resetID).Return(1, nil)
+	mockRepo.EXPECT().UpdateUserPassword(mock.Anything, mock.Anything).Return(nil)
+	mockRepo.EXPECT().RevokeUserRefreshTokens(mock.Anything, testUserID).Return(dbErr)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Error(mock.MatchedBy(func(err error) bool {
+		return errors.Is(err, dbErr)
+	}), "failed to reset password")
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Repo:        mockRepo,
+		Tx:          newTestTx(t, mockRepo),
+		Revocations: store,
+	}
+
+	status := postJSON(t, newPasswordResetApp(h), "/password/reset/confirm", map[string]string{
+		"token":    "reset-token",
+		"password": "new-password",
+	})
+	assert.Equal(t, 500, status)
+
+	// the sessions are left alone when the password didn't change
+	version, err := store.TokenVersion(context.Background(), uuid.UUID(testUserID.Bytes).String())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+}
+
+func TestConfirmPasswordReset_UnknownToken(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetPasswordResetTokenByHash(mock.Anything, helpers.HashToken("unknown-token")).Return(repositories.PasswordResetToken{}, pgx.ErrNoRows)
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    interfaces.NewMockLogger(t),
+		Repo:   mockRepo,
+	}
+
+	status := postJSON(t, newPasswordResetApp(h), "/password/reset/confirm", map[string]string{
+		"token":    "unknown-token",
+		"password": "new-password",
+	})
+	assert.Equal(t, 400, status)
+}
+
+func TestConfirmPasswordReset_ShortPassword(t *testing.T) {
+	h := &Handler{
+		Config: &testConfig,
+		Log:    interfaces.NewMockLogger(t),
+	}
+
+	status := postJSON(t, newPasswordResetApp(h), "/password/reset/confirm", map[string]string{
+		"token":    "reset-token",
+		"password": "short",
+	})
+	assert.Equal(t, 400, status)
+}
+
+func TestConfirmPasswordReset_WeakPassword(t *testing.T) {
+	h := &Handler{
+		Config: &testConfig,
+		Log:    interfaces.NewMockLogger(t),
+	}
+
+	// long enough but a single class of characters
+	for _, password := range []string{"password", "12345678901234"} {
+		status := postJSON(t, newPasswordResetApp(h), "/password/reset/confirm", map[string]string{
+			"token":    "reset-token",
+			"password": password,
+		})
+		assert.Equal(t, 400, status, password)
+	}
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"time"
+	"web-boilerplate/internal/hr-api/repositories"
+	"web-boilerplate/shared/helpers"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// refreshTokenBytes is the amount of entropy in a refresh token
+	refreshTokenBytes = 32
+	// accessTokenType is the "typ" claim of access tokens, Protected refuses tokens of other types
+	accessTokenType = "access"
+)
+
+type RefreshTokenParams struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type TokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	ID           string `json:"id"`
+}
+
+// newAccessToken signs a short lived JWT for the given user, version is the user's
+// token version at issue time so the token stops working once it is bumped.
+// The role and its permissions are embedded so routes can be authorized without a db lookup.
+func (h *Handler) newAccessToken(user repositories.User, version int64, permissions []string) (string, error) {
+	now := time.Now()
+	return h.Keys.Sign(jwt.MapClaims{
+		"id":          uuid.UUID(user.ID.Bytes).String(),
+		"jti":         uuid.NewString(),
+		"ver":         version,
+		"typ":         accessTokenType,
+		"role":        user.Role,
+		"permissions": permissions,
+		"iat":         now.Unix(),
+		"exp":         now.Add(h.Config.TokenTTL).Unix(),
+	})
+}
+
+// newRefreshToken stores a new refresh token in the given family and returns its plain value,
+// only the hash of the token is persisted
+func (h *Handler) newRefreshToken(ctx context.Context, userID, familyID pgtype.UUID) (string, repositories.RefreshToken, error) {
+	token, err := helpers.GenerateToken(refreshTokenBytes)
+	if err != nil {
+		return "", repositories.RefreshToken{}, err
+	}
+
+	stored, err := h.Repo.CreateRefreshToken(ctx, repositories.CreateRefreshTokenParams{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: helpers.HashToken(token),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(h.Config.RefreshTokenTTL), Valid: true},
+	})
+	if err != nil {
+		return "", repositories.RefreshToken{}, err
+	}
+
+	return token, stored, nil
+}
+
+// issueTokens creates an access token and a refresh token belonging to familyID
+func (h *Handler) issueTokens(ctx context.Context, user repositories.User, familyID pgtype.UUID) (TokenResponse, repositories.RefreshToken, error) {
+	id := uuid.UUID(user.ID.Bytes).String()
+
+	version, err := h.Revocations.TokenVersion(ctx, id)
+	if err != nil {
+		return TokenResponse{}, repositories.RefreshToken{}, err
+	}
+
+	permissions, err := h.Repo.ListRolePermissions(ctx, user.Role)
+	if err != nil {
+		return TokenResponse{}, repositories.RefreshToken{}, err
+	}
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	accessToken, err := h.newAccessToken(user, version, permissions)
+	if err != nil {
+		return TokenResponse{}, repositories.RefreshToken{}, err
+	}
+
+	refreshToken, stored, err := h.newRefreshToken(ctx, user.ID, familyID)
+	if err != nil {
+		return TokenResponse{}, repositories.RefreshToken{}, err
+	}
+
+	return TokenResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(h.Config.TokenTTL.Seconds()),
+		ID:           id,
+	}, stored, nil
+}
+
+// RefreshToken exchanges a valid refresh token for a new access and refresh token pair.
+// Every refresh token can be used once, presenting an already rotated token revokes
+// the whole family since it means the token was leaked.
+func (h *Handler) RefreshToken(c fiber.Ctx) error {
+	var params RefreshTokenParams
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	stored, err := h.Repo.GetRefreshTokenByHash(c.Context(), helpers.HashToken(params.RefreshToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			h.Log.Info("unknown refresh token")
+			return fiber.ErrUnauthorized
+		}
+		h.Log.Error(err, "failed to get refresh token")
+		return fiber.ErrInternalServerError
+	}
+
+	if stored.RevokedAt.Valid {
+		return h.revokeRefreshTokenFamily(c, stored)
+	}
+
+	if time.Now().After(stored.ExpiresAt.Time) {
+		h.Log.Info("expired refresh token", "family_id", uuid.UUID(stored.FamilyID.Bytes).String())
+		return fiber.ErrUnauthorized
+	}
+
+	// the role may have changed since the family was started, always issue fresh claims
+	user, err := h.Repo.GetUser(c.Context(), stored.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.ErrUnauthorized
+		}
+		h.Log.Error(err, "failed to get user")
+		return fiber.ErrInternalServerError
+	}
+
+	resp, next, err := h.issueTokens(c.Context(), user, stored.FamilyID)
+	if err != nil {
+		h.Log.Error(err, "failed to issue tokens")
+		return fiber.ErrInternalServerError
+	}
+
+	rows, err := h.Repo.RotateRefreshToken(c.Context(), repositories.RotateRefreshTokenParams{
+		ID:         stored.ID,
+		ReplacedBy: next.ID,
+	})
+	if err != nil {
+		h.Log.Error(err, "failed to rotate refresh token")
+		return fiber.ErrInternalServerError
+	}
+	if rows == 0 {
+		// the token was rotated by a concurrent request between the lookup and now
+		return h.revokeRefreshTokenFamily(c, stored)
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *Handler) revokeRefreshTokenFamily(c fiber.Ctx, stored repositories.RefreshToken) error {
+	h.Log.Info("refresh token reuse detected, revoking token family",
+		"user_id", uuid.UUID(stored.UserID.Bytes).String(),
+		"family_id", uuid.UUID(stored.FamilyID.Bytes).String())
+
+	if err := h.Repo.RevokeRefreshTokenFamily(c.Context(), stored.FamilyID); err != nil {
+		h.Log.Error(err, "failed to revoke refresh token family")
+		return fiber.ErrInternalServerError
+	}
+
+	return fiber.ErrUnauthorized
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-api/config"
+	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
+	"web-boilerplate/internal/hr-api/pkg/signing"
+	"web-boilerplate/internal/hr-api/repositories"
+	"web-boilerplate/shared/helpers"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testUserID   = pgtype.UUID{Bytes: uuid.UUID{1, 2, 3, 4}, Valid: true}
+	testFamilyID = pgtype.UUID{Bytes: uuid.UUID{5, 6, 7, 8}, Valid: true}
+	testTokenID  = pgtype.UUID{Bytes: uuid.UUID{9}, Valid: true}
+)
+
+var (
+	testConfig = config.Default()
+	testKeys   = newTestKeys()
+)
+
+func newTestKeys() *signing.KeySet {
+	keys, err := signing.NewKeySet(signing.Config{Algorithm: signing.AlgorithmEdDSA})
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
+
+var testUser = repositories.User{
+	ID:       testUserID,
+	Username: "testuser",
+	Role:     "hr_manager",
+}
+
+func testRefreshToken(token string) repositories.RefreshToken {
+	return repositories.RefreshToken{
+		ID:        testTokenID,
+		UserID:    testUserID,
+		FamilyID:  testFamilyID,
+		TokenHash: helpers.HashToken(token),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+}
+
+func postRefresh(t *testing.T, h *Handler, token string) int {
+	app := fiber.New()
+	app.Post("/token/refresh", h.RefreshToken)
+
+	req := httptest.NewRequest("POST", "/token/refresh", jsonBody(map[string]string{"refresh_token": token}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	if resp.StatusCode == 200 {
+		var respBody TokenResponse
+		json.NewDecoder(resp.Body).Decode(&respBody)
+		assert.NotEmpty(t, respBody.Token)
+		assert.NotEmpty(t, respBody.RefreshToken)
+		assert.NotEqual(t, token, respBody.RefreshToken)
+		assert.Equal(t, uuid.UUID(testUserID.Bytes).String(), respBody.ID)
+	}
+
+	return resp.StatusCode
+}
+
+func TestRefreshToken_Rotates(t *testing.T) {
+	nextID := pgtype.UUID{Bytes: uuid.UUID{10}, Valid: true}
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetRefreshTokenByHash(context.Background(), helpers.HashToken("old-token")).Return(testRefreshToken("old-token"), nil)
+	mockRepo.EXPECT().GetUser(context.Background(), testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().ListRolePermissions(context.Background(), "hr_manager").Return([]string{"employees:read", "employees:write"}, nil)
+	mockRepo.EXPECT().CreateRefreshToken(context.Background(), mock.MatchedBy(func(arg repositories.CreateRefreshTokenParams) bool {
+		return arg.UserID == testUserID && arg.FamilyID == testFamilyID && arg.TokenHash != helpers.HashToken("old-token")
+	})).Return(repositories.RefreshToken{ID: nextID}, nil)
+	mockRepo.EXPECT().RotateRefreshToken(context.Background(), repositories.RotateRefreshTokenParams{
+		ID:         testTokenID,
+		ReplacedBy: nextID,
+	}).Return(1, nil)
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         interfaces.NewMockLogger(t),
+		Repo:        mockRepo,
+		Revocations: revocation.NewMemoryStore(),
+		Keys:        testKeys,
+	}
+
+	assert.Equal(t, 200, postRefresh(t, h, "old-token"))
+}
+
+func TestRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	used := testRefreshToken("used-token")
+	used.RevokedAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetRefreshTokenByHash(context.Background(), helpers.HashToken("used-token")).Return(used, nil)
+	mockRepo.EXPECT().RevokeRefreshTokenFamily(context.Background(), testFamilyID).Return(nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("refresh token reuse detected, revoking token family", mock.Anything)
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+	}
+
+	assert.Equal(t, 401, postRefresh(t, h, "used-token"))
+}
+
+func TestRefreshToken_ConcurrentRotationRevokesFamily(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetRefreshTokenByHash(context.Background(), helpers.HashToken("raced-token")).Return(testRefreshToken("raced-token"), nil)
+	mockRepo.EXPECT().GetUser(context.Background(), testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().ListRolePermissions(context.Background(), "hr_manager").Return([]string{"employees:read", "employees:write"}, nil)
+	mockRepo.EXPECT().CreateRefreshToken(context.Background(), mock.AnythingOfType("repositories.CreateRefreshTokenParams")).Return(repositories.RefreshToken{}, nil)
+	mockRepo.EXPECT().RotateRefreshToken(context.Background(), mock.AnythingOfType("repositories.RotateRefreshTokenParams")).Return(0, nil)
+	mockRepo.EXPECT().RevokeRefreshTokenFamily(context.Background(), testFamilyID).Return(nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("refresh token reuse detected, revoking token family", mock.Anything)
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Repo:        mockRepo,
+		Revocations: revocation.NewMemoryStore(),
+		Keys:        testKeys,
+	}
+
+	assert.Equal(t, 401, postRefresh(t, h, "raced-token"))
+}
+
+func TestRefreshToken_Expired(t *testing.T) {
+	expired := testRefreshToken("expired-token")
+	expired.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetRefreshTokenByHash(context.Background(), helpers.HashToken("expired-token")).Return(expired, nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("expired refresh token", mock.Anything)
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+	}
+
+	assert.Equal(t, 401, postRefresh(t, h, "expired-token"))
+}
+
+func TestRefreshToken_Unknown(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetRefreshTokenByHash(context.Background(), helpers.HashToken("unknown-token")).Return(repositories.RefreshToken{}, pgx.ErrNoRows)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("unknown refresh token")
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+	}
+
+	assert.Equal(t, 401, postRefresh(t, h, "unknown-token"))
+}
+
+func TestRefreshToken_MissingToken(t *testing.T) {
+	h := &Handler{
+		Config: &testConfig,
+		Log:    interfaces.NewMockLogger(t),
+	}
+
+	assert.Equal(t, 400, postRefresh(t, h, ""))
+}
@@ -0,0 +1,475 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"web-boilerplate/internal/hr-api/pkg/metrics"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/pkg/totp"
+	"web-boilerplate/internal/hr-api/repositories"
+	"web-boilerplate/shared/helpers"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// mfaChallengeType is the "typ" claim of the tokens returned by the password step of a 2FA login
+	mfaChallengeType = "mfa_challenge"
+	// recoveryCodeCount is the amount of recovery codes handed out when 2FA is activated
+	recoveryCodeCount = 10
+	// recoveryCodeLength is the length of a recovery code without its separator
+	recoveryCodeLength = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type TOTPCodeParams struct {
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+type LoginTOTPParams struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required,max=32"`
+}
+
+type TOTPEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TOTPActivateResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MFAChallengeResponse struct {
+	MFARequired    bool   `json:"mfa_required"`
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int64  `json:"expires_in"`
+}
+
+// EnrollTOTP creates a new TOTP secret for the current user and returns it with its provisioning URI.
+// The secret is only used for logins once a first code was verified with ActivateTOTP.
+func (h *Handler) EnrollTOTP(c fiber.Ctx) error {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return fiber.ErrUnauthorized
+	}
+
+	user, err := h.Repo.GetUser(c.Context(), userID)
+	if err != nil {
+		h.Log.Error(err, "failed to get user")
+		return problem.From(err)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		h.Log.Error(err, "failed to generate totp secret")
+		return fiber.ErrInternalServerError
+	}
+
+	// the upsert leaves an activated secret untouched and returns no row
+	_, err = h.Repo.UpsertUserTOTP(c.Context(), repositories.UpsertUserTOTPParams{
+		UserID: userID,
+		Secret: secret,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.New(fiber.StatusConflict, problem.CodeTOTPAlreadyEnabled, "two-factor authentication is already enabled")
+		}
+		h.Log.Error(err, "failed to store totp secret")
+		return fiber.ErrInternalServerError
+	}
+
+	h.Log.Info("totp enrollment started", "id", uuid.UUID(userID.Bytes).String())
+
+	return c.JSON(TOTPEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(h.Config.TOTPIssuer, user.Username, secret),
+	})
+}
+
+// ActivateTOTP enables 2FA for the current user once they prove their authenticator works,
+// the recovery codes are only returned here and stored hashed
+func (h *Handler) ActivateTOTP(c fiber.Ctx) error {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return fiber.ErrUnauthorized
+	}
+
+	var params TOTPCodeParams
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	user, err := h.Repo.GetUser(c.Context(), userID)
+	if err != nil {
+		h.Log.Error(err, "failed to get user")
+		return problem.From(err)
+	}
+	if err := h.checkCodeAttempts(c, user.Username); err != nil {
+		return err
+	}
+
+	stored, err := h.Repo.GetUserTOTP(c.Context(), userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.New(fiber.StatusBadRequest, problem.CodeTOTPNotEnrolled, "two-factor authentication is not enrolled")
+		}
+		h.Log.Error(err, "failed to get totp secret")
+		return fiber.ErrInternalServerError
+	}
+	if stored.EnabledAt.Valid {
+		return problem.New(fiber.StatusConflict, problem.CodeTOTPAlreadyEnabled, "two-factor authentication is already enabled")
+	}
+
+	step, ok := totp.Validate(stored.Secret, params.Code, time.Now())
+	if !ok {
+		h.codeFailed(c, user.Username)
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidCode, "invalid code")
+	}
+
+	alreadyEnabled := problem.New(fiber.StatusConflict, problem.CodeTOTPAlreadyEnabled, "two-factor authentication is already enabled")
+
+	// 2FA is never enabled without the recovery codes the user is about to be shown
+	var codes []string
+	err = h.Tx.InTx(c.Context(), pgx.TxOptions{}, func(q repositories.Querier) error {
+		rows, err := q.EnableUserTOTP(c.Context(), repositories.EnableUserTOTPParams{
+			UserID:       userID,
+			LastUsedStep: step,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to enable totp: %w", err)
+		}
+		if rows == 0 {
+			return alreadyEnabled
+		}
+
+		codes, err = newRecoveryCodes(c.Context(), q, userID)
+		if err != nil {
+			return fmt.Errorf("failed to create recovery codes: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, alreadyEnabled) {
+		return alreadyEnabled
+	}
+	if err != nil {
+		h.Log.Error(err, "failed to activate totp")
+		return fiber.ErrInternalServerError
+	}
+
+	h.Log.Info("totp enabled", "id", uuid.UUID(userID.Bytes).String())
+
+	return c.JSON(TOTPActivateResponse{RecoveryCodes: codes})
+}
+
+// DisableTOTP turns 2FA off for the current user, a valid code or recovery code is required
+// so a stolen access token alone isn't enough
+func (h *Handler) DisableTOTP(c fiber.Ctx) error {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return fiber.ErrUnauthorized
+	}
+
+	var params TOTPCodeParams
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	user, err := h.Repo.GetUser(c.Context(), userID)
+	if err != nil {
+		h.Log.Error(err, "failed to get user")
+		return problem.From(err)
+	}
+	if err := h.checkCodeAttempts(c, user.Username); err != nil {
+		return err
+	}
+
+	stored, err := h.Repo.GetUserTOTP(c.Context(), userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		h.Log.Error(err, "failed to get totp secret")
+		return fiber.ErrInternalServerError
+	}
+	if err != nil || !stored.EnabledAt.Valid {
+		return problem.New(fiber.StatusBadRequest, problem.CodeTOTPNotEnabled, "two-factor authentication is not enabled")
+	}
+
+	valid, err := h.verifySecondFactor(c.Context(), stored, params.Code)
+	if err != nil {
+		h.Log.Error(err, "failed to verify second factor")
+		return fiber.ErrInternalServerError
+	}
+	if !valid {
+		h.codeFailed(c, user.Username)
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidCode, "invalid code")
+	}
+
+	err = h.Tx.InTx(c.Context(), pgx.TxOptions{}, func(q repositories.Querier) error {
+		if err := q.DeleteUserTOTP(c.Context(), userID); err != nil {
+			return fmt.Errorf("failed to delete totp secret: %w", err)
+		}
+		if err := q.DeleteUserRecoveryCodes(c.Context(), userID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		h.Log.Error(err, "failed to disable totp")
+		return fiber.ErrInternalServerError
+	}
+
+	h.Log.Info("totp disabled", "id", uuid.UUID(userID.Bytes).String())
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// LoginTOTP is the second step of a 2FA login, it exchanges the challenge token returned by Login
+// and a TOTP or recovery code for an access and refresh token pair
+func (h *Handler) LoginTOTP(c fiber.Ctx) error {
+	var params LoginTOTPParams
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	invalidChallenge := problem.New(fiber.StatusUnauthorized, problem.CodeInvalidChallenge, "invalid or expired challenge")
+
+	claims, err := h.parseMFAChallenge(params.ChallengeToken)
+	if err != nil {
+		return invalidChallenge
+	}
+
+	jti, _ := claims["jti"].(string)
+	userIDStr, _ := claims["id"].(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil || jti == "" {
+		return invalidChallenge
+	}
+	userID := pgtype.UUID{Bytes: userUUID, Valid: true}
+
+	revoked, err := h.Revocations.IsRevoked(c.Context(), jti)
+	if err != nil {
+		h.Log.Error(err, "failed to check challenge revocation")
+		return fiber.ErrInternalServerError
+	}
+	if revoked {
+		return invalidChallenge
+	}
+
+	// a password reset or a logout everywhere since the password step voids the challenge
+	version, err := h.Revocations.TokenVersion(c.Context(), userIDStr)
+	if err != nil {
+		h.Log.Error(err, "failed to get token version")
+		return fiber.ErrInternalServerError
+	}
+	if ver, _ := claims["ver"].(float64); int64(ver) < version {
+		return invalidChallenge
+	}
+
+	user, err := h.Repo.GetUser(c.Context(), userID)
+	if err != nil {
+		h.Log.Error(err, "failed to get user")
+		return fiber.ErrUnauthorized
+	}
+
+	if err := h.checkCodeAttempts(c, user.Username); err != nil {
+		return err
+	}
+
+	stored, err := h.Repo.GetUserTOTP(c.Context(), userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		h.Log.Error(err, "failed to get totp secret")
+		return fiber.ErrInternalServerError
+	}
+	if err != nil || !stored.EnabledAt.Valid {
+		return invalidChallenge
+	}
+
+	valid, err := h.verifySecondFactor(c.Context(), stored, params.Code)
+	if err != nil {
+		h.Log.Error(err, "failed to verify second factor")
+		return fiber.ErrInternalServerError
+	}
+	if !valid {
+		h.Log.Info("invalid second factor attempt", "id", userIDStr)
+		h.loginFailed(c, user.Username)
+		return problem.New(fiber.StatusUnauthorized, problem.CodeInvalidCode, "invalid code")
+	}
+
+	// the challenge is single use
+	expiresAt := time.Now().Add(h.Config.MFAChallengeTTL)
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		expiresAt = exp.Time
+	}
+	if err := h.Revocations.Revoke(c.Context(), jti, expiresAt); err != nil {
+		h.Log.Error(err, "failed to revoke challenge")
+		return fiber.ErrInternalServerError
+	}
+
+	resp, _, err := h.issueTokens(c.Context(), user, pgtype.UUID{Bytes: uuid.New(), Valid: true})
+	if err != nil {
+		h.Log.Error(err, "failed to issue tokens")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := h.Guard.Succeeded(c.Context(), user.Username); err != nil {
+		h.Log.Error(err, "failed to reset login failures")
+	}
+
+	h.Metrics.LoginAttempt(metrics.LoginSuccess)
+	h.Log.Info("login successful", "username", user.Username, "id", resp.ID)
+
+	return c.JSON(resp)
+}
+
+// mfaChallenge returns the challenge response of the password step for users with 2FA enabled
+func (h *Handler) mfaChallenge(ctx context.Context, user repositories.User) (MFAChallengeResponse, error) {
+	id := uuid.UUID(user.ID.Bytes).String()
+
+	version, err := h.Revocations.TokenVersion(ctx, id)
+	if err != nil {
+		return MFAChallengeResponse{}, err
+	}
+
+	now := time.Now()
+	challenge, err := h.Keys.Sign(jwt.MapClaims{
+		"id":  id,
+		"jti": uuid.NewString(),
+		"ver": version,
+		"typ": mfaChallengeType,
+		"iat": now.Unix(),
+		"exp": now.Add(h.Config.MFAChallengeTTL).Unix(),
+	})
+	if err != nil {
+		return MFAChallengeResponse{}, err
+	}
+
+	return MFAChallengeResponse{
+		MFARequired:    true,
+		ChallengeToken: challenge,
+		ExpiresIn:      int64(h.Config.MFAChallengeTTL.Seconds()),
+	}, nil
+}
+
+// parseMFAChallenge validates a challenge token and returns its claims
+func (h *Handler) parseMFAChallenge(challenge string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(challenge, claims, h.Keys.Keyfunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims["typ"] != mfaChallengeType {
+		return nil, errors.New("not a challenge token")
+	}
+
+	return claims, nil
+}
+
+// checkCodeAttempts refuses to check a code of a locked out account or IP, codes are throttled
+// with the password attempts of the account so they can't be guessed from any endpoint
+func (h *Handler) checkCodeAttempts(c fiber.Ctx, username string) error {
+	wait, err := h.Guard.Check(c.Context(), username, c.IP())
+	if err != nil {
+		h.Log.Error(err, "failed to check login lockout")
+		return fiber.ErrInternalServerError
+	}
+	if wait > 0 {
+		h.Log.Info("login attempt while locked out", "username", username)
+		return h.tooManyAttempts(c, wait)
+	}
+	return nil
+}
+
+// codeFailed records a wrong code of a signed in user, checking the codes of their own account
+// isn't a login so it only counts towards the lockout
+func (h *Handler) codeFailed(c fiber.Ctx, username string) {
+	if err := h.Guard.Failed(c.Context(), username, c.IP()); err != nil {
+		h.Log.Error(err, "failed to record code failure")
+	}
+}
+
+// verifySecondFactor checks a TOTP code, refusing codes of an already used time step,
+// or consumes a recovery code
+func (h *Handler) verifySecondFactor(ctx context.Context, stored repositories.UserTotp, code string) (bool, error) {
+	if step, ok := totp.Validate(stored.Secret, code, time.Now()); ok {
+		rows, err := h.Repo.UseTOTPStep(ctx, repositories.UseTOTPStepParams{
+			UserID:       stored.UserID,
+			LastUsedStep: step,
+		})
+		return rows > 0, err
+	}
+
+	code = normalizeRecoveryCode(code)
+	if len(code) != recoveryCodeLength {
+		return false, nil
+	}
+
+	rows, err := h.Repo.UseRecoveryCode(ctx, repositories.UseRecoveryCodeParams{
+		UserID:   stored.UserID,
+		CodeHash: helpers.HashToken(code),
+	})
+	return rows > 0, err
+}
+
+// newRecoveryCodes replaces the recovery codes of the user and returns the plain codes
+func newRecoveryCodes(ctx context.Context, q repositories.Querier, userID pgtype.UUID) ([]string, error) {
+	if err := q.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		buf := make([]byte, recoveryCodeLength*5/8)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("error generating recovery code err: %v", err)
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))
+
+		err := q.CreateRecoveryCode(ctx, repositories.CreateRecoveryCodeParams{
+			ID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			UserID:   userID,
+			CodeHash: helpers.HashToken(code),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// split in two halves to make it easier to copy by hand
+		codes = append(codes, code[:recoveryCodeLength/2]+"-"+code[recoveryCodeLength/2:])
+	}
+
+	return codes, nil
+}
+
+// normalizeRecoveryCode strips what users may add or change when typing a recovery code
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// currentUserID returns the id of the user authenticated by Protected
+func (h *Handler) currentUserID(c fiber.Ctx) (pgtype.UUID, bool) {
+	claims, ok := userClaims(c)
+	if !ok {
+		h.Log.Error(nil, "failed to get user claims from context")
+		return pgtype.UUID{}, false
+	}
+
+	userIDStr, _ := claims["id"].(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.Log.Error(err, "invalid user id format")
+		return pgtype.UUID{}, false
+	}
+
+	return pgtype.UUID{Bytes: userID, Valid: true}, true
+}
@@ -0,0 +1,393 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
+	"web-boilerplate/internal/hr-api/pkg/totp"
+	"web-boilerplate/internal/hr-api/repositories"
+	"web-boilerplate/shared/helpers"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+var enabledTOTP = repositories.UserTotp{
+	UserID:    testUserID,
+	Secret:    testTOTPSecret,
+	EnabledAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+}
+
+func newTOTPApp(h *Handler) *fiber.App {
+	app := fiber.New()
+
+	// Middleware that sets up the user claims (simulating the auth middleware)
+	app.Use(func(c fiber.Ctx) error {
+		c.Locals("user", jwt.MapClaims{"id": uuid.UUID(testUserID.Bytes).String()})
+		return c.Next()
+	})
+
+	app.Post("/login", h.Login)
+	app.Post("/login/2fa", h.LoginTOTP)
+	app.Post("/2fa/enroll", h.EnrollTOTP)
+	app.Post("/2fa/activate", h.ActivateTOTP)
+	app.Post("/2fa/disable", h.DisableTOTP)
+
+	return app
+}
+
+func postTOTP(t *testing.T, app *fiber.App, path string, payload any) (int, map[string]any) {
+	req := httptest.NewRequest("POST", path, jsonBody(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, fiber.TestConfig{
+		Timeout: 20 * time.Second,
+	})
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	var respBody map[string]any
+	json.NewDecoder(resp.Body).Decode(&respBody)
+
+	return resp.StatusCode, respBody
+}
+
+func currentCode(t *testing.T) string {
+	code, err := totp.GenerateCode(testTOTPSecret, time.Now())
+	assert.NoError(t, err)
+	return code
+}
+
+func TestEnrollTOTP_ReturnsProvisioningURI(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().UpsertUserTOTP(mock.Anything, mock.MatchedBy(func(arg repositories.UpsertUserTOTPParams) bool {
+		return arg.UserID == testUserID && arg.Secret != ""
+	})).Return(repositories.UserTotp{}, nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("totp enrollment started", []any{"id", uuid.UUID(testUserID.Bytes).String()})
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+	}
+
+	status, body := postTOTP(t, newTOTPApp(h), "/2fa/enroll", nil)
+	assert.Equal(t, 200, status)
+
+	secret, _ := body["secret"].(string)
+	assert.NotEmpty(t, secret)
+	assert.Contains(t, body["provisioning_uri"], "otpauth://totp/")
+	assert.Contains(t, body["provisioning_uri"], "secret="+secret)
+}
+
+func TestEnrollTOTP_AlreadyEnabled(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().UpsertUserTOTP(mock.Anything, mock.Anything).Return(repositories.UserTotp{}, pgx.ErrNoRows)
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    interfaces.NewMockLogger(t),
+		Repo:   mockRepo,
+	}
+
+	status, _ := postTOTP(t, newTOTPApp(h), "/2fa/enroll", nil)
+	assert.Equal(t, 409, status)
+}
+
+func TestActivateTOTP_ReturnsRecoveryCodes(t *testing.T) {
+	var storedHashes []string
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().GetUserTOTP(mock.Anything, testUserID).Return(repositories.UserTotp{
+		UserID: testUserID,
+		Secret: testTOTPSecret,
+	}, nil)
+	mockRepo.EXPECT().EnableUserTOTP(mock.Anything, mock.MatchedBy(func(arg repositories.EnableUserTOTPParams) bool {
+		return arg.UserID == testUserID && arg.LastUsedStep > 0
+	})).Return(1, nil)
+	mockRepo.EXPECT().DeleteUserRecoveryCodes(mock.Anything, testUserID).Return(nil)
+	mockRepo.EXPECT().CreateRecoveryCode(mock.Anything, mock.MatchedBy(func(arg repositories.CreateRecoveryCodeParams) bool {
+		storedHashes = append(storedHashes, arg.CodeHash)
+		return arg.UserID == testUserID
+	})).Return(nil).Times(recoveryCodeCount)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("totp enabled", []any{"id", uuid.UUID(testUserID.Bytes).String()})
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+		Tx:     newTestTx(t, mockRepo),
+		Guard:  newTestGuard(),
+	}
+
+	status, body := postTOTP(t, newTOTPApp(h), "/2fa/activate", map[string]string{"code": currentCode(t)})
+	assert.Equal(t, 200, status)
+
+	codes, _ := body["recovery_codes"].([]any)
+	assert.Len(t, codes, recoveryCodeCount)
+
+	// only hashes are stored
+	for _, code := range codes {
+		assert.Contains(t, storedHashes, helpers.HashToken(normalizeRecoveryCode(code.(string))))
+	}
+}
+
+func TestActivateTOTP_InvalidCode(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().GetUserTOTP(mock.Anything, testUserID).Return(repositories.UserTotp{
+		UserID: testUserID,
+		Secret: testTOTPSecret,
+	}, nil)
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    interfaces.NewMockLogger(t),
+		Repo:   mockRepo,
+		Guard:  newTestGuard(),
+	}
+
+	status, _ := postTOTP(t, newTOTPApp(h), "/2fa/activate", map[string]string{"code": "000000x"})
+	assert.Equal(t, 400, status)
+}
+
+func TestActivateTOTP_LocksOutGuessing(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	// the secret is no longer read once locked out
+	mockRepo.EXPECT().GetUserTOTP(mock.Anything, testUserID).Return(repositories.UserTotp{
+		UserID: testUserID,
+		Secret: testTOTPSecret,
+	}, nil).Times(3)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("login attempt while locked out", []any{"username", testUser.Username})
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+		Guard:  newTestGuard(),
+	}
+	app := newTOTPApp(h)
+
+	for range 3 {
+		status, _ := postTOTP(t, app, "/2fa/activate", map[string]string{"code": "000000x"})
+		assert.Equal(t, 400, status)
+	}
+
+	// even the right code is refused
+	status, _ := postTOTP(t, app, "/2fa/activate", map[string]string{"code": currentCode(t)})
+	assert.Equal(t, 429, status)
+}
+
+func TestDisableTOTP_Success(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().GetUserTOTP(mock.Anything, testUserID).Return(enabledTOTP, nil)
+	mockRepo.EXPECT().UseTOTPStep(mock.Anything, mock.Anything).Return(1, nil)
+	mockRepo.EXPECT().DeleteUserTOTP(mock.Anything, testUserID).Return(nil)
+	mockRepo.EXPECT().DeleteUserRecoveryCodes(mock.Anything, testUserID).Return(nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("totp disabled", []any{"id", uuid.UUID(testUserID.Bytes).String()})
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+		Tx:     newTestTx(t, mockRepo),
+		Guard:  newTestGuard(),
+	}
+
+	status, _ := postTOTP(t, newTOTPApp(h), "/2fa/disable", map[string]string{"code": currentCode(t)})
+	assert.Equal(t, 204, status)
+}
+
+func TestDisableTOTP_LocksOutGuessing(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().GetUserTOTP(mock.Anything, testUserID).Return(enabledTOTP, nil).Times(3)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("login attempt while locked out", []any{"username", testUser.Username})
+
+	h := &Handler{
+		Config: &testConfig,
+		Log:    mockLogger,
+		Repo:   mockRepo,
+		Guard:  newTestGuard(),
+	}
+	app := newTOTPApp(h)
+
+	// neither a TOTP code nor the length of a recovery code, nothing is looked up
+	for range 3 {
+		status, _ := postTOTP(t, app, "/2fa/disable", map[string]string{"code": "0000"})
+		assert.Equal(t, 400, status)
+	}
+
+	status, _ := postTOTP(t, app, "/2fa/disable", map[string]string{"code": currentCode(t)})
+	assert.Equal(t, 429, status)
+}
+
+func TestLogin_RequiresSecondFactor(t *testing.T) {
+	hashedPassword, _ := helpers.HashPass("password123")
+	user := testUser
+	user.Password = hashedPassword
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUserByUsername(mock.Anything, "testuser").Return(user, nil)
+	mockRepo.EXPECT().GetUserTOTP(mock.Anything, testUserID).Return(enabledTOTP, nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("login requires second factor", []any{"username", "testuser"})
+
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         mockLogger,
+		Repo:        mockRepo,
+		Revocations: revocation.NewMemoryStore(),
+		Keys:        testKeys,
+		Guard:       newTestGuard(),
+	}
+
+	status, body := postTOTP(t, newTOTPApp(h), "/login", map[string]string{
+		"username": "testuser",
+		"password": "password123",
+	})
+	assert.Equal(t, 200, status)
+	assert.Equal(t, true, body["mfa_required"])
+	assert.NotEmpty(t, body["challenge_token"])
+	// no credentials before the second factor
+	assert.Nil(t, body["token"])
+	assert.Nil(t, body["refresh_token"])
+}
+
+func TestLoginTOTP_Success(t *testing.T) {
+	store := revocation.NewMemoryStore()
+	h := &Handler{Config: &testConfig, Revocations: store, Keys: testKeys, Guard: newTestGuard()}
+	challenge, err := h.mfaChallenge(context.Background(), testUser)
+	assert.NoError(t, err)
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUserTOTP(mock.Anything, testUserID).Return(enabledTOTP, nil)
+	mockRepo.EXPECT().UseTOTPStep(mock.Anything, mock.MatchedBy(func(arg repositories.UseTOTPStepParams) bool {
+		return arg.UserID == testUserID && arg.LastUsedStep >= totp.Step(time.Now())-totp.Skew
+	})).Return(1, nil).Once()
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().ListRolePermissions(mock.Anything, "hr_manager").Return([]string{"employees:read"}, nil)
+	mockRepo.EXPECT().CreateRefreshToken(mock.Anything, mock.Anything).Return(repositories.RefreshToken{}, nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("login successful", []any{"username", "testuser", "id", uuid.UUID(testUserID.Bytes).String()})
+
+	h.Log = mockLogger
+	h.Repo = mockRepo
+	app := newTOTPApp(h)
+
+	payload := map[string]string{
+		"challenge_token": challenge.ChallengeToken,
+		"code":            currentCode(t),
+	}
+
+	status, body := postTOTP(t, app, "/login/2fa", payload)
+	assert.Equal(t, 200, status)
+	assert.NotEmpty(t, body["token"])
+	assert.NotEmpty(t, body["refresh_token"])
+
+	// the challenge is single use
+	status, _ = postTOTP(t, app, "/login/2fa", payload)
+	assert.Equal(t, 401, status)
+}
+
+func TestLoginTOTP_RecoveryCode(t *testing.T) {
+	store := revocation.NewMemoryStore()
+	h := &Handler{Config: &testConfig, Revocations: store, Keys: testKeys, Guard: newTestGuard()}
+	challenge, err := h.mfaChallenge(context.Background(), testUser)
+	assert.NoError(t, err)
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUserTOTP(mock.Anything, testUserID).Return(enabledTOTP, nil)
+	mockRepo.EXPECT().UseRecoveryCode(mock.Anything, repositories.UseRecoveryCodeParams{
+		UserID:   testUserID,
+		CodeHash: helpers.HashToken("abcdefghij"),
+	}).Return(1, nil)
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().ListRolePermissions(mock.Anything, "hr_manager").Return([]string{}, nil)
+	mockRepo.EXPECT().CreateRefreshToken(mock.Anything, mock.Anything).Return(repositories.RefreshToken{}, nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("login successful", mock.Anything)
+
+	h.Log = mockLogger
+	h.Repo = mockRepo
+
+	status, _ := postTOTP(t, newTOTPApp(h), "/login/2fa", map[string]string{
+		"challenge_token": challenge.ChallengeToken,
+		"code":            " ABCDE-FGHIJ ",
+	})
+	assert.Equal(t, 200, status)
+}
+
+func TestLoginTOTP_ReplayedCode(t *testing.T) {
+	store := revocation.NewMemoryStore()
+	h := &Handler{Config: &testConfig, Revocations: store, Keys: testKeys, Guard: newTestGuard()}
+	challenge, err := h.mfaChallenge(context.Background(), testUser)
+	assert.NoError(t, err)
+
+	// the step of the code was already used
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUserTOTP(mock.Anything, testUserID).Return(enabledTOTP, nil)
+	mockRepo.EXPECT().GetUser(mock.Anything, testUserID).Return(testUser, nil)
+	mockRepo.EXPECT().UseTOTPStep(mock.Anything, mock.Anything).Return(0, nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("invalid second factor attempt", []any{"id", uuid.UUID(testUserID.Bytes).String()})
+
+	h.Log = mockLogger
+	h.Repo = mockRepo
+
+	status, _ := postTOTP(t, newTOTPApp(h), "/login/2fa", map[string]string{
+		"challenge_token": challenge.ChallengeToken,
+		"code":            currentCode(t),
+	})
+	assert.Equal(t, 401, status)
+}
+
+func TestLoginTOTP_RejectsAccessToken(t *testing.T) {
+	h := &Handler{
+		Config:      &testConfig,
+		Log:         interfaces.NewMockLogger(t),
+		Repo:        repositories.NewMockQuerier(t),
+		Revocations: revocation.NewMemoryStore(),
+		Keys:        testKeys,
+		Guard:       newTestGuard(),
+	}
+	accessToken, err := h.newAccessToken(testUser, 0, []string{})
+	assert.NoError(t, err)
+
+	status, _ := postTOTP(t, newTOTPApp(h), "/login/2fa", map[string]string{
+		"challenge_token": accessToken,
+		"code":            currentCode(t),
+	})
+	assert.Equal(t, 401, status)
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"web-boilerplate/internal/hr-api/pkg/pagination"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/repositories"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type UserResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+type UserRoleParams struct {
+	Role string `json:"role" validate:"required,max=50"`
+}
+
+func (p *UserRoleParams) normalize() {
+	p.Role = strings.TrimSpace(p.Role)
+}
+
+func newUserResponse(u repositories.User) UserResponse {
+	return UserResponse{
+		ID:       uuid.UUID(u.ID.Bytes).String(),
+		Name:     u.Name,
+		Email:    u.Email,
+		Username: u.Username,
+		Role:     u.Role,
+	}
+}
+
+// userSortFields are the accepted values of the sort query param, the first being the default
+var userSortFields = []string{"name", "email", "username"}
+
+func (h *Handler) ListUsers(c fiber.Ctx) error {
+	page, err := pagination.Parse(c, userSortFields...)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	emailDomain := optionalText(strings.TrimPrefix(c.Query("email_domain"), "@"))
+
+	params := repositories.ListUsersByNameParams{
+		EmailDomain: emailDomain,
+		// fetch one extra row to know whether there is a next page
+		PageSize: int32(page.Limit + 1),
+	}
+	if page.Cursor != nil {
+		params.AfterKey = pgtype.Text{String: page.Cursor.Key, Valid: true}
+		params.AfterID = pgtype.UUID{Bytes: page.Cursor.ID, Valid: true}
+	}
+
+	users, err := h.listUsers(c.Context(), page, params)
+	if err != nil {
+		h.Log.Error(err, "failed to list users")
+		return fiber.ErrInternalServerError
+	}
+
+	meta := pagination.Meta{Limit: page.Limit}
+	if len(users) > page.Limit {
+		users = users[:page.Limit]
+		last := users[len(users)-1]
+		meta.NextCursor = page.Next(userSortKey(page.Sort, last), last.ID.Bytes)
+	}
+
+	meta.TotalEstimate, err = h.countUsers(c, page, emailDomain)
+	if err != nil {
+		h.Log.Error(err, "failed to count users")
+		return fiber.ErrInternalServerError
+	}
+
+	data := make([]UserResponse, 0, len(users))
+	for _, user := range users {
+		data = append(data, newUserResponse(user))
+	}
+
+	return c.JSON(fiber.Map{
+		"data": data,
+		"meta": meta,
+	})
+}
+
+// listUsers runs the query of the page's sort, each one is backed by an index on (sort key, id)
+func (h *Handler) listUsers(ctx context.Context, page pagination.Page, params repositories.ListUsersByNameParams) ([]repositories.User, error) {
+	switch page.Sort {
+	case "email":
+		if page.Desc {
+			return h.Repo.ListUsersByEmailDesc(ctx, repositories.ListUsersByEmailDescParams(params))
+		}
+		return h.Repo.ListUsersByEmail(ctx, repositories.ListUsersByEmailParams(params))
+	case "username":
+		if page.Desc {
+			return h.Repo.ListUsersByUsernameDesc(ctx, repositories.ListUsersByUsernameDescParams(params))
+		}
+		return h.Repo.ListUsersByUsername(ctx, repositories.ListUsersByUsernameParams(params))
+	default:
+		if page.Desc {
+			return h.Repo.ListUsersByNameDesc(ctx, repositories.ListUsersByNameDescParams(params))
+		}
+		return h.Repo.ListUsersByName(ctx, params)
+	}
+}
+
+// userSortKey is the value users are ordered by for the given sort
+func userSortKey(sort string, u repositories.User) string {
+	switch sort {
+	case "email":
+		return u.Email
+	case "username":
+		return u.Username
+	default:
+		return u.Name
+	}
+}
+
+// countUsers uses the planner's row estimate for unfiltered listings, or an exact count when
+// the table was never analyzed. Filtered listings are only counted when the client asks for the total.
+func (h *Handler) countUsers(c fiber.Ctx, page pagination.Page, emailDomain pgtype.Text) (*int64, error) {
+	if !emailDomain.Valid {
+		estimate, err := h.Repo.EstimateUsersCount(c.Context())
+		if err != nil || estimate >= 0 {
+			return &estimate, err
+		}
+	} else if !page.Total {
+		return nil, nil
+	}
+
+	count, err := h.Repo.CountUsers(c.Context(), emailDomain)
+	return &count, err
+}
+
+// UpdateUserRole assigns a role to the user, tokens issued with the previous role are revoked
+// so the new permissions apply on the next refresh
+func (h *Handler) UpdateUserRole(c fiber.Ctx) error {
+	id, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var params UserRoleParams
+	if err := h.bindBody(c, &params); err != nil {
+		return err
+	}
+
+	// roles are rows of their own, refuse unknown ones before the foreign key does
+	roles, err := h.Repo.ListRoles(c.Context())
+	if err != nil {
+		h.Log.Error(err, "failed to list roles")
+		return fiber.ErrInternalServerError
+	}
+	if !slices.Contains(roles, params.Role) {
+		return problem.Validation(map[string]string{
+			"role": "role must be one of " + strings.Join(roles, ", "),
+		})
+	}
+
+	user, err := h.Repo.UpdateUserRole(c.Context(), repositories.UpdateUserRoleParams{
+		ID:   id,
+		Role: params.Role,
+	})
+	if err != nil {
+		h.Log.Error(err, "failed to update user role")
+		return problem.From(err)
+	}
+
+	resp := newUserResponse(user)
+	if _, err := h.Revocations.BumpTokenVersion(c.Context(), resp.ID); err != nil {
+		h.Log.Error(err, "failed to bump token version")
+		return fiber.ErrInternalServerError
+	}
+
+	h.Log.Info("user role updated", "id", resp.ID, "role", resp.Role)
+
+	return c.JSON(resp)
+}
+
+// UnlockUser lifts the login lockout of a user and clears their failed attempts
+func (h *Handler) UnlockUser(c fiber.Ctx) error {
+	id, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	user, err := h.Repo.GetUser(c.Context(), id)
+	if err != nil {
+		h.Log.Error(err, "failed to get user")
+		return problem.From(err)
+	}
+
+	if err := h.Guard.Unlock(c.Context(), user.Username); err != nil {
+		h.Log.Error(err, "failed to unlock user")
+		return fiber.ErrInternalServerError
+	}
+
+	h.Log.Info("user unlocked", "id", uuid.UUID(user.ID.Bytes).String())
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/internal/hr-api/middlewares"
+	"web-boilerplate/internal/hr-api/pkg/pagination"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
+	"web-boilerplate/internal/hr-api/repositories"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRoles are the roles of the roles and permissions migration
+var testRoles = []string{"admin", "employee", "hr_manager"}
+
+func TestListUsers_Success(t *testing.T) {
+	userID := uuid.UUID{1, 2, 3, 4}
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().ListUsersByUsername(context.Background(), repositories.ListUsersByUsernameParams{
+		EmailDomain: pgtype.Text{String: "example.com", Valid: true},
+		PageSize:    21,
+	}).Return([]repositories.User{{
+		ID:       pgtype.UUID{Bytes: userID, Valid: true},
+		Name:     "Test User",
+		Email:    "test@example.com",
+		Username: "testuser",
+		Password: "hashed-password",
+	}}, nil)
+	mockRepo.EXPECT().CountUsers(context.Background(), pgtype.Text{String: "example.com", Valid: true}).Return(1, nil)
+
+	h := &Handler{
+		Log:  interfaces.NewMockLogger(t),
+		Repo: mockRepo,
+	}
+
+	app := fiber.New()
+	app.Get("/users", h.ListUsers)
+
+	req := httptest.NewRequest("GET", "/users?sort=username&email_domain=example.com&include_total=true", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var respBody struct {
+		Data []map[string]any `json:"data"`
+		Meta pagination.Meta  `json:"meta"`
+	}
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Len(t, respBody.Data, 1)
+	assert.Equal(t, "testuser", respBody.Data[0]["username"])
+	assert.Nil(t, respBody.Data[0]["password"])
+	if assert.NotNil(t, respBody.Meta.TotalEstimate) {
+		assert.Equal(t, int64(1), *respBody.Meta.TotalEstimate)
+	}
+}
+
+func TestListUsers_DBError(t *testing.T) {
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().ListUsersByName(context.Background(), repositories.ListUsersByNameParams{
+		PageSize: 21,
+	}).Return(nil, assert.AnError)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Error(assert.AnError, "failed to list users")
+
+	h := &Handler{
+		Log:  mockLogger,
+		Repo: mockRepo,
+	}
+
+	app := fiber.New()
+	app.Get("/users", h.ListUsers)
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 500, resp.StatusCode)
+}
+
+func TestUpdateUserRole_Success(t *testing.T) {
+	userID := uuid.UUID{1, 2, 3, 4}
+	store := revocation.NewMemoryStore()
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().ListRoles(context.Background()).Return(testRoles, nil)
+	mockRepo.EXPECT().UpdateUserRole(context.Background(), repositories.UpdateUserRoleParams{
+		ID:   pgtype.UUID{Bytes: userID, Valid: true},
+		Role: "hr_manager",
+	}).Return(repositories.User{
+		ID:       pgtype.UUID{Bytes: userID, Valid: true},
+		Username: "testuser",
+		Role:     "hr_manager",
+	}, nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("user role updated", []any{"id", userID.String(), "role", "hr_manager"})
+
+	h := &Handler{
+		Log:         mockLogger,
+		Repo:        mockRepo,
+		Revocations: store,
+	}
+
+	app := fiber.New()
+	app.Put("/users/:id/role", h.UpdateUserRole)
+
+	req := httptest.NewRequest("PUT", "/users/"+userID.String()+"/role", jsonBody(map[string]string{"role": "hr_manager"}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var respBody UserResponse
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, "hr_manager", respBody.Role)
+
+	// tokens carrying the previous role no longer verify
+	version, err := store.TokenVersion(context.Background(), userID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+}
+
+func TestUpdateUserRole_UnknownRole(t *testing.T) {
+	// refused before the update, the foreign key would only tell the role doesn't exist
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().ListRoles(context.Background()).Return(testRoles, nil)
+
+	h := &Handler{
+		Log:  interfaces.NewMockLogger(t),
+		Repo: mockRepo,
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: middlewares.ErrorHandler})
+	app.Put("/users/:id/role", h.UpdateUserRole)
+
+	req := httptest.NewRequest("PUT", "/users/"+uuid.UUID{1, 2, 3, 4}.String()+"/role", jsonBody(map[string]string{"role": "superuser"}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 400, resp.StatusCode)
+
+	var respBody problem.Problem
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, problem.CodeValidationFailed, respBody.Code)
+	assert.Equal(t, []problem.FieldError{
+		{Field: "role", Message: "role must be one of admin, employee, hr_manager"},
+	}, respBody.Errors)
+}
+
+func TestUpdateUserRole_RoleDeletedMeanwhile(t *testing.T) {
+	userID := uuid.UUID{1, 2, 3, 4}
+	fkErr := &pgconn.PgError{Code: "23503", TableName: "users", ConstraintName: "users_role_fkey"}
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().ListRoles(context.Background()).Return(testRoles, nil)
+	mockRepo.EXPECT().UpdateUserRole(context.Background(), repositories.UpdateUserRoleParams{
+		ID:   pgtype.UUID{Bytes: userID, Valid: true},
+		Role: "hr_manager",
+	}).Return(repositories.User{}, fkErr)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Error(fkErr, "failed to update user role")
+
+	h := &Handler{
+		Log:  mockLogger,
+		Repo: mockRepo,
+	}
+
+	app := fiber.New()
+	app.Put("/users/:id/role", h.UpdateUserRole)
+
+	req := httptest.NewRequest("PUT", "/users/"+userID.String()+"/role", jsonBody(map[string]string{"role": "hr_manager"}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestUpdateUserRole_MissingRole(t *testing.T) {
+	h := &Handler{
+		Log: interfaces.NewMockLogger(t),
+	}
+
+	app := fiber.New()
+	app.Put("/users/:id/role", h.UpdateUserRole)
+
+	req := httptest.NewRequest("PUT", "/users/"+uuid.UUID{1}.String()+"/role", jsonBody(map[string]string{"role": " "}))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestUnlockUser_Success(t *testing.T) {
+	guard := newTestGuard()
+	for range 3 {
+		assert.NoError(t, guard.Failed(context.Background(), "testuser", "203.0.113.1"))
+	}
+	wait, err := guard.Check(context.Background(), "testuser", "198.51.100.1")
+	assert.NoError(t, err)
+	assert.NotZero(t, wait)
+
+	mockRepo := repositories.NewMockQuerier(t)
+	mockRepo.EXPECT().GetUser(context.Background(), testUserID).Return(testUser, nil)
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("user unlocked", []any{"id", uuid.UUID(testUserID.Bytes).String()})
+
+	h := &Handler{
+		Log:   mockLogger,
+		Repo:  mockRepo,
+		Guard: guard,
+	}
+
+	app := fiber.New()
+	app.Post("/users/:id/unlock", h.UnlockUser)
+
+	req := httptest.NewRequest("POST", "/users/"+uuid.UUID(testUserID.Bytes).String()+"/unlock", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+
+	wait, err = guard.Check(context.Background(), "testuser", "198.51.100.1")
+	assert.NoError(t, err)
+	assert.Zero(t, wait)
+}
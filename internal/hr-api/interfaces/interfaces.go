@@ -1,6 +1,11 @@
 package interfaces
 
-import "context"
+import (
+	"context"
+	"web-boilerplate/internal/hr-api/repositories"
+
+	"github.com/jackc/pgx/v5"
+)
 
 type Logger interface {
 	Info(msg string, keys ...any)
@@ -14,3 +19,11 @@ type RedisDB interface {
 type DBPool interface {
 	Ping(ctx context.Context) error
 }
+
+// TxManager runs multi-step operations atomically
+type TxManager interface {
+	// InTx runs fn with a Querier bound to a new transaction, committed when fn returns nil and
+	// rolled back otherwise. fn runs again when Postgres aborts the transaction on a serialization
+	// failure or a deadlock, so it must not have side effects outside of q.
+	InTx(ctx context.Context, opts pgx.TxOptions, fn func(q repositories.Querier) error) error
+}
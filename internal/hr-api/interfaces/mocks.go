@@ -6,7 +6,9 @@ package interfaces
 
 import (
 	"context"
+	"web-boilerplate/internal/hr-api/repositories"
 
+	"github.com/jackc/pgx/v5"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -292,3 +294,93 @@ func (_c *MockDBPool_Ping_Call) RunAndReturn(run func(ctx context.Context) error
 	_c.Call.Return(run)
 	return _c
 }
+
+// NewMockTxManager creates a new instance of MockTxManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTxManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTxManager {
+	mock := &MockTxManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTxManager is an autogenerated mock type for the TxManager type
+type MockTxManager struct {
+	mock.Mock
+}
+
+type MockTxManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTxManager) EXPECT() *MockTxManager_Expecter {
+	return &MockTxManager_Expecter{mock: &_m.Mock}
+}
+
+// InTx provides a mock function for the type MockTxManager
+func (_mock *MockTxManager) InTx(ctx context.Context, opts pgx.TxOptions, fn func(q repositories.Querier) error) error {
+	ret := _mock.Called(ctx, opts, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InTx")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgx.TxOptions, func(q repositories.Querier) error) error); ok {
+		r0 = returnFunc(ctx, opts, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTxManager_InTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InTx'
+type MockTxManager_InTx_Call struct {
+	*mock.Call
+}
+
+// InTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts pgx.TxOptions
+//   - fn func(q repositories.Querier) error
+func (_e *MockTxManager_Expecter) InTx(ctx any, opts any, fn any) *MockTxManager_InTx_Call {
+	return &MockTxManager_InTx_Call{Call: _e.mock.On("InTx", ctx, opts, fn)}
+}
+
+func (_c *MockTxManager_InTx_Call) Run(run func(ctx context.Context, opts pgx.TxOptions, fn func(q repositories.Querier) error)) *MockTxManager_InTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgx.TxOptions
+		if args[1] != nil {
+			arg1 = args[1].(pgx.TxOptions)
+		}
+		var arg2 func(q repositories.Querier) error
+		if args[2] != nil {
+			arg2 = args[2].(func(q repositories.Querier) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTxManager_InTx_Call) Return(err error) *MockTxManager_InTx_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTxManager_InTx_Call) RunAndReturn(run func(ctx context.Context, opts pgx.TxOptions, fn func(q repositories.Querier) error) error) *MockTxManager_InTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
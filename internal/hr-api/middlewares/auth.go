@@ -1,48 +1,97 @@
 package middlewares
 
 import (
-	"fmt"
+	"strings"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
+	"web-boilerplate/internal/hr-api/pkg/signing"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/golang-jwt/jwt/v5"
-	"web-boilerplate/internal/hr-api/config"
 )
 
-func Protected(c fiber.Ctx) error {
-	auth := c.Get("Authorization")
-	if auth == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Unauthorized",
-		})
-	}
+var (
+	errMissingToken  = problem.New(fiber.StatusUnauthorized, problem.CodeUnauthorized, "missing access token")
+	errInvalidToken  = problem.New(fiber.StatusUnauthorized, problem.CodeInvalidToken, "invalid or expired token")
+	errInvalidClaims = problem.New(fiber.StatusUnauthorized, problem.CodeInvalidToken, "invalid token claims")
+	errInvalidType   = problem.New(fiber.StatusUnauthorized, problem.CodeInvalidToken, "invalid token type")
+	errTokenRevoked  = problem.New(fiber.StatusUnauthorized, problem.CodeTokenRevoked, "token has been revoked")
+)
 
-	// Parse the token
-	token, err := jwt.Parse(auth, func(token *jwt.Token) (any, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+// Protected returns a middleware accepting only requests with an access token signed
+// by one of the given keys that was not revoked in the given store
+func Protected(store revocation.Store, keys *signing.KeySet) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		auth := bearerToken(c.Get(fiber.HeaderAuthorization))
+		if auth == "" {
+			return errMissingToken
 		}
 
-		// Provide the secret key used for signing
-		return []byte(config.SECRET_KEY), nil
-	})
+		// Parse the token, the key set refuses unknown kids and algorithms other than the key's
+		token, err := jwt.Parse(auth, keys.Keyfunc)
 
-	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Invalid or expired token",
-			"error":   err.Error(),
-		})
-	}
+		if err != nil {
+			// the parser's reason is only logged, it tells an attacker which check failed
+			return errInvalidToken.Wrap(err)
+		}
+
+		// Extract claims from the token
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok || !token.Valid {
+			return errInvalidClaims
+		}
+
+		// other tokens signed with the same key, like 2FA challenges, can't be used as access tokens
+		if typ, _ := claims["typ"].(string); typ != "access" {
+			return errInvalidType
+		}
+
+		revoked, err := isRevoked(c, store, claims)
+		if err != nil {
+			return fiber.ErrInternalServerError
+		}
+		if revoked {
+			return errTokenRevoked
+		}
 
-	// Extract claims from the token
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		// Store claims in context for later use
 		c.Locals("user", claims)
-	} else {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"message": "Invalid token claims",
-		})
+
+		return c.Next()
+	}
+}
+
+// bearerToken returns the token of an Authorization header, sent with the Bearer scheme
+// or bare as the first clients of hr-api did
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return header
+}
+
+// isRevoked checks the token's jti against the revoked tokens
+// and its "ver" claim against the user's current token version
+func isRevoked(c fiber.Ctx, store revocation.Store, claims jwt.MapClaims) (bool, error) {
+	if jti, ok := claims["jti"].(string); ok && jti != "" {
+		revoked, err := store.IsRevoked(c.Context(), jti)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	userID, ok := claims["id"].(string)
+	if !ok {
+		return false, nil
+	}
+
+	current, err := store.TokenVersion(c.Context(), userID)
+	if err != nil {
+		return false, err
 	}
 
-	return c.Next()
+	// tokens issued before versions existed have no "ver" claim and count as version 0
+	version, _ := claims["ver"].(float64)
+	return int64(version) < current, nil
 }
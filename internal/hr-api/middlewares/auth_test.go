@@ -1,29 +1,41 @@
 package middlewares
 
 import (
+	"context"
 	"encoding/json"
 	"net/http/httptest"
 	"testing"
 	"time"
-	"web-boilerplate/internal/hr-api/config"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/pkg/revocation"
+	"web-boilerplate/internal/hr-api/pkg/signing"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+var testKeys = newTestKeys()
+
+func newTestKeys() *signing.KeySet {
+	keys, err := signing.NewKeySet(signing.Config{Algorithm: signing.AlgorithmEdDSA})
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
+
 func TestProtected_Success(t *testing.T) {
-	// Create a valid token using the same config.SECRET_KEY
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	tokenString, err := testKeys.Sign(jwt.MapClaims{
 		"id":  "test-user-id",
+		"typ": "access",
 		"exp": time.Now().Add(time.Hour).Unix(),
 	})
-
-	tokenString, err := token.SignedString([]byte(config.SECRET_KEY))
 	assert.NoError(t, err)
 
-	app := fiber.New()
-	app.Get("/protected", Protected, func(c fiber.Ctx) error {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(revocation.NewMemoryStore(), testKeys), func(c fiber.Ctx) error {
 		claims := c.Locals("user")
 		return c.JSON(fiber.Map{"user": claims})
 	})
@@ -42,9 +54,48 @@ func TestProtected_Success(t *testing.T) {
 	assert.NotNil(t, respBody["user"])
 }
 
+func TestProtected_BearerScheme(t *testing.T) {
+	tokenString, err := testKeys.Sign(jwt.MapClaims{
+		"id":  "test-user-id",
+		"typ": "access",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{"bearer", "Bearer " + tokenString, 200},
+		{"scheme is case insensitive", "bearer " + tokenString, 200},
+		{"bare token of older clients", tokenString, 200},
+		{"other scheme", "Basic " + tokenString, 401},
+		{"bearer without token", "Bearer ", 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			app.Get("/protected", Protected(revocation.NewMemoryStore(), testKeys), func(c fiber.Ctx) error {
+				return c.JSON(fiber.Map{"message": "success"})
+			})
+
+			req := httptest.NewRequest("GET", "/protected", nil)
+			req.Header.Set("Authorization", tt.header)
+
+			resp, err := app.Test(req, fiber.TestConfig{
+				Timeout: 20 * time.Second,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
 func TestProtected_MissingToken(t *testing.T) {
-	app := fiber.New()
-	app.Get("/protected", Protected, func(c fiber.Ctx) error {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(revocation.NewMemoryStore(), testKeys), func(c fiber.Ctx) error {
 		return c.JSON(fiber.Map{"message": "success"})
 	})
 
@@ -58,12 +109,12 @@ func TestProtected_MissingToken(t *testing.T) {
 
 	var respBody map[string]any
 	json.NewDecoder(resp.Body).Decode(&respBody)
-	assert.Equal(t, "Unauthorized", respBody["message"])
+	assert.Equal(t, problem.CodeUnauthorized, respBody["code"])
 }
 
 func TestProtected_InvalidToken(t *testing.T) {
-	app := fiber.New()
-	app.Get("/protected", Protected, func(c fiber.Ctx) error {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(revocation.NewMemoryStore(), testKeys), func(c fiber.Ctx) error {
 		return c.JSON(fiber.Map{"message": "success"})
 	})
 
@@ -78,22 +129,22 @@ func TestProtected_InvalidToken(t *testing.T) {
 
 	var respBody map[string]any
 	json.NewDecoder(resp.Body).Decode(&respBody)
-	assert.Equal(t, "Invalid or expired token", respBody["message"])
+	assert.Equal(t, problem.CodeInvalidToken, respBody["code"])
+	// the parser's reason isn't sent
+	assert.Equal(t, "invalid or expired token", respBody["detail"])
 }
 
-func TestProtected_WrongSecret(t *testing.T) {
-	// Create a token with a different secret
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func TestProtected_UnknownKey(t *testing.T) {
+	// Sign with a key the middleware doesn't know
+	tokenString, err := newTestKeys().Sign(jwt.MapClaims{
 		"id":  "test-user-id",
+		"typ": "access",
 		"exp": time.Now().Add(time.Hour).Unix(),
 	})
-
-	// Sign with a different secret than config.SECRET_KEY
-	tokenString, err := token.SignedString([]byte("wrong-secret"))
 	assert.NoError(t, err)
 
-	app := fiber.New()
-	app.Get("/protected", Protected, func(c fiber.Ctx) error {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(revocation.NewMemoryStore(), testKeys), func(c fiber.Ctx) error {
 		return c.JSON(fiber.Map{"message": "success"})
 	})
 
@@ -108,21 +159,121 @@ func TestProtected_WrongSecret(t *testing.T) {
 
 	var respBody map[string]any
 	json.NewDecoder(resp.Body).Decode(&respBody)
-	assert.Equal(t, "Invalid or expired token", respBody["message"])
+	assert.Equal(t, problem.CodeInvalidToken, respBody["code"])
+	// the parser's reason isn't sent
+	assert.Equal(t, "invalid or expired token", respBody["detail"])
+}
+
+func TestProtected_HMACTokenRefused(t *testing.T) {
+	set, err := testKeys.JWKS()
+	assert.NoError(t, err)
+
+	// an HS256 token naming a known kid, signed with the public key as the secret
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":  "test-user-id",
+		"typ": "access",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = set.Keys[0].Kid
+	tokenString, err := token.SignedString([]byte(set.Keys[0].X))
+	assert.NoError(t, err)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(revocation.NewMemoryStore(), testKeys), func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"message": "success"})
+	})
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", tokenString)
+
+	resp, err := app.Test(req, fiber.TestConfig{
+		Timeout: 20 * time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestProtected_RotatedKey(t *testing.T) {
+	keys := newTestKeys()
+
+	oldToken, err := keys.Sign(jwt.MapClaims{
+		"id":  "test-user-id",
+		"typ": "access",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, keys.Rotate())
+
+	newToken, err := keys.Sign(jwt.MapClaims{
+		"id":  "test-user-id",
+		"typ": "access",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	assert.NoError(t, err)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(revocation.NewMemoryStore(), keys), func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"message": "success"})
+	})
+
+	// tokens signed before the rotation keep working until they expire
+	for _, tokenString := range []string{oldToken, newToken} {
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", tokenString)
+
+		resp, err := app.Test(req, fiber.TestConfig{
+			Timeout: 20 * time.Second,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+	}
 }
 
 func TestProtected_ExpiredToken(t *testing.T) {
 	// Create an expired token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	tokenString, err := testKeys.Sign(jwt.MapClaims{
 		"id":  "test-user-id",
+		"typ": "access",
 		"exp": time.Now().Add(-time.Hour).Unix(), // Expired 1 hour ago
 	})
+	assert.NoError(t, err)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(revocation.NewMemoryStore(), testKeys), func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"message": "success"})
+	})
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", tokenString)
+
+	resp, err := app.Test(req, fiber.TestConfig{
+		Timeout: 20 * time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+
+	var respBody map[string]any
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, problem.CodeInvalidToken, respBody["code"])
+	// the parser's reason isn't sent
+	assert.Equal(t, "invalid or expired token", respBody["detail"])
+}
 
-	tokenString, err := token.SignedString([]byte(config.SECRET_KEY))
+func TestProtected_RevokedToken(t *testing.T) {
+	tokenString, err := testKeys.Sign(jwt.MapClaims{
+		"id":  "test-user-id",
+		"typ": "access",
+		"jti": "revoked-jti",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
 	assert.NoError(t, err)
 
-	app := fiber.New()
-	app.Get("/protected", Protected, func(c fiber.Ctx) error {
+	store := revocation.NewMemoryStore()
+	assert.NoError(t, store.Revoke(context.Background(), "revoked-jti", time.Now().Add(time.Hour)))
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(store, testKeys), func(c fiber.Ctx) error {
 		return c.JSON(fiber.Map{"message": "success"})
 	})
 
@@ -137,5 +288,113 @@ func TestProtected_ExpiredToken(t *testing.T) {
 
 	var respBody map[string]any
 	json.NewDecoder(resp.Body).Decode(&respBody)
-	assert.Equal(t, "Invalid or expired token", respBody["message"])
+	assert.Equal(t, problem.CodeTokenRevoked, respBody["code"])
+}
+
+func TestProtected_OtherTokenTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  any
+	}{
+		{"2fa challenge", "mfa_challenge"},
+		{"untyped", nil},
+		{"not a string", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := jwt.MapClaims{
+				"id":  "test-user-id",
+				"jti": "test-jti",
+				"exp": time.Now().Add(time.Hour).Unix(),
+			}
+			if tt.typ != nil {
+				claims["typ"] = tt.typ
+			}
+			tokenString, err := testKeys.Sign(claims)
+			assert.NoError(t, err)
+
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			app.Get("/protected", Protected(revocation.NewMemoryStore(), testKeys), func(c fiber.Ctx) error {
+				return c.JSON(fiber.Map{"message": "success"})
+			})
+
+			req := httptest.NewRequest("GET", "/protected", nil)
+			req.Header.Set("Authorization", tokenString)
+
+			resp, err := app.Test(req, fiber.TestConfig{
+				Timeout: 20 * time.Second,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 401, resp.StatusCode)
+
+			var respBody map[string]any
+			json.NewDecoder(resp.Body).Decode(&respBody)
+			assert.Equal(t, problem.CodeInvalidToken, respBody["code"])
+			assert.Equal(t, "invalid token type", respBody["detail"])
+		})
+	}
+}
+
+func TestProtected_OutdatedTokenVersion(t *testing.T) {
+	store := revocation.NewMemoryStore()
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(store, testKeys), func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"message": "success"})
+	})
+
+	sign := func(version int64) string {
+		tokenString, err := testKeys.Sign(jwt.MapClaims{
+			"id":  "test-user-id",
+			"typ": "access",
+			"ver": version,
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+		assert.NoError(t, err)
+		return tokenString
+	}
+	oldToken := sign(0)
+
+	// logout everywhere
+	version, err := store.BumpTokenVersion(context.Background(), "test-user-id")
+	assert.NoError(t, err)
+
+	for tokenString, status := range map[string]int{oldToken: 401, sign(version): 200} {
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", tokenString)
+
+		resp, err := app.Test(req, fiber.TestConfig{
+			Timeout: 20 * time.Second,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, status, resp.StatusCode)
+	}
+}
+
+func TestProtected_StoreFailure(t *testing.T) {
+	tokenString, err := testKeys.Sign(jwt.MapClaims{
+		"id":  "test-user-id",
+		"typ": "access",
+		"jti": "some-jti",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	assert.NoError(t, err)
+
+	store := revocation.NewMockStore(t)
+	store.EXPECT().IsRevoked(mock.Anything, "some-jti").Return(false, assert.AnError)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/protected", Protected(store, testKeys), func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"message": "success"})
+	})
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", tokenString)
+
+	resp, err := app.Test(req, fiber.TestConfig{
+		Timeout: 20 * time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
 }
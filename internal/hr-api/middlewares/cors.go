@@ -1,16 +1,11 @@
 package middlewares
 
-import (
-	"strings"
-	"web-boilerplate/internal/hr-api/config"
+import "github.com/gofiber/fiber/v3/middleware/cors"
 
-	"github.com/gofiber/fiber/v3/middleware/cors"
-)
-
-func SetupCorsConfig() cors.Config {
+func SetupCorsConfig(origins []string) cors.Config {
 	return cors.Config{
 		AllowCredentials: true,
-		AllowOrigins:     strings.Split(config.ALLOWED_ORIGINS, ","),
+		AllowOrigins:     origins,
 		AllowHeaders:     []string{"Origin", " Content-Type", " Accept", " Accept-Language", " Content-Length"},
 	}
 }
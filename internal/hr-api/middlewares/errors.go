@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"web-boilerplate/internal/hr-api/pkg/problem"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+)
+
+// ErrorHandler answers the errors returned by handlers and middlewares with problem+json documents,
+// see problem.From for how errors are mapped. Internal errors never carry their cause.
+//
+// Usage:
+//
+//	app := fiber.New(fiber.Config{ErrorHandler: middlewares.ErrorHandler})
+func ErrorHandler(c fiber.Ctx, err error) error {
+	doc := problem.From(err).Problem()
+	doc.Instance = c.Path()
+	doc.RequestID = requestid.FromContext(c)
+
+	return c.Status(doc.Status).JSON(doc, problem.ContentType)
+}
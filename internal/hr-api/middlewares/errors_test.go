@@ -0,0 +1,124 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// sendError answers a request with the error returned by the handler and decodes the problem document
+func sendError(t *testing.T, handlerErr error) (int, map[string]any) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(requestid.New())
+	app.Get("/test", func(c fiber.Ctx) error {
+		return handlerErr
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "test-request-id")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	assert.Equal(t, problem.ContentType, resp.Header.Get(fiber.HeaderContentType))
+
+	var body map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestErrorHandler_FiberError(t *testing.T) {
+	status, body := sendError(t, fiber.ErrNotFound)
+	assert.Equal(t, 404, status)
+	assert.Equal(t, "urn:hr-api:problem:not_found", body["type"])
+	assert.Equal(t, "Not Found", body["title"])
+	assert.Equal(t, float64(404), body["status"])
+	assert.Equal(t, problem.CodeNotFound, body["code"])
+	assert.Equal(t, "/test", body["instance"])
+	assert.Equal(t, "test-request-id", body["request_id"])
+	assert.NotContains(t, body, "detail")
+}
+
+func TestErrorHandler_FiberErrorMessage(t *testing.T) {
+	status, body := sendError(t, fiber.NewError(fiber.StatusBadRequest, "invalid id"))
+	assert.Equal(t, 400, status)
+	assert.Equal(t, problem.CodeBadRequest, body["code"])
+	assert.Equal(t, "invalid id", body["detail"])
+}
+
+func TestErrorHandler_ApplicationError(t *testing.T) {
+	appErr := problem.New(fiber.StatusTooManyRequests, problem.CodeLoginLocked, "too many failed login attempts").
+		With("retry_after", 30)
+
+	status, body := sendError(t, appErr)
+	assert.Equal(t, 429, status)
+	assert.Equal(t, problem.CodeLoginLocked, body["code"])
+	assert.Equal(t, "too many failed login attempts", body["detail"])
+	assert.Equal(t, float64(30), body["retry_after"])
+}
+
+func TestErrorHandler_Validation(t *testing.T) {
+	status, body := sendError(t, problem.Validation(map[string]string{
+		"last_name": "last_name is required",
+		"email":     "email is required",
+	}))
+	assert.Equal(t, 400, status)
+	assert.Equal(t, problem.CodeValidationFailed, body["code"])
+	assert.Equal(t, []any{
+		map[string]any{"field": "email", "message": "email is required"},
+		map[string]any{"field": "last_name", "message": "last_name is required"},
+	}, body["errors"])
+}
+
+func TestErrorHandler_DatabaseErrors(t *testing.T) {
+	status, body := sendError(t, fmt.Errorf("failed to get employee: %w", pgx.ErrNoRows))
+	assert.Equal(t, 404, status)
+	assert.Equal(t, problem.CodeNotFound, body["code"])
+
+	status, body = sendError(t, &pgconn.PgError{Code: "23505", TableName: "employees", ConstraintName: "employees_email_key"})
+	assert.Equal(t, 409, status)
+	assert.Equal(t, problem.CodeAlreadyExists, body["code"])
+	assert.Equal(t, "a record with the same email already exists", body["detail"])
+	assert.Equal(t, []any{map[string]any{"field": "email", "message": "email is already taken"}}, body["errors"])
+
+	status, body = sendError(t, &pgconn.PgError{Code: "23503", TableName: "users", ConstraintName: "users_role_fkey"})
+	assert.Equal(t, 400, status)
+	assert.Equal(t, problem.CodeInvalidReference, body["code"])
+	assert.Equal(t, "the referenced role does not exist", body["detail"])
+}
+
+func TestErrorHandler_InternalErrorHidesCause(t *testing.T) {
+	status, body := sendError(t, errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+	assert.Equal(t, 500, status)
+	assert.Equal(t, problem.CodeInternal, body["code"])
+	assert.NotContains(t, body, "detail")
+
+	// the cause of an application error isn't sent either
+	status, body = sendError(t, problem.New(fiber.StatusUnauthorized, problem.CodeInvalidToken, "invalid or expired token").
+		Wrap(errors.New("token signature is invalid")))
+	assert.Equal(t, 401, status)
+	assert.Equal(t, "invalid or expired token", body["detail"])
+}
+
+func TestErrorHandler_DefaultHandlerKeepsStatus(t *testing.T) {
+	// handlers mounted without ErrorHandler, like in most handler tests, still answer the right status
+	app := fiber.New()
+	app.Get("/test", func(c fiber.Ctx) error {
+		return problem.New(fiber.StatusConflict, problem.CodeTOTPAlreadyEnabled, "two-factor authentication is already enabled")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/test", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 409, resp.StatusCode)
+}
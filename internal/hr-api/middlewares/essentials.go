@@ -1,9 +1,6 @@
 package middlewares
 
 import (
-	"strings"
-	"web-boilerplate/internal/hr-api/config"
-
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/compress"
 	"github.com/gofiber/fiber/v3/middleware/cors"
@@ -23,10 +20,10 @@ func SetupMiddlewareHelmet(app *fiber.App) {
 	app.Use(helmet.New())
 }
 
-func SetupMiddlewareCORS(app *fiber.App) {
+func SetupMiddlewareCORS(app *fiber.App, origins []string) {
 	app.Use(cors.Config{
 		AllowCredentials: true,
-		AllowOrigins:     strings.Split(config.ALLOWED_ORIGINS, ","),
+		AllowOrigins:     origins,
 		AllowHeaders:     []string{"Origin", " Content-Type", " Accept", " Accept-Language", " Content-Length"},
 	})
 }
@@ -1,24 +1,147 @@
 package middlewares
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
 	"time"
 
+	idempotencypkg "web-boilerplate/internal/hr-api/pkg/idempotency"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/idempotency"
 )
 
+// IdempotentReplayedHeader is set on responses replayed from an earlier request
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+const fingerprintKeyPrefix = "fingerprint:"
+
+// credentialPaths answer with access and refresh tokens, the middleware leaves them alone
+// so no token is kept in the store for the lifetime of the responses
+var credentialPaths = []string{"/v1/login", "/v1/login/2fa", "/v1/token/refresh"}
+
+var errIdempotencyKeyReused = problem.New(fiber.StatusUnprocessableEntity, problem.CodeIdempotencyKeyReused, "idempotency key already used for a different request")
+
 // SetupIdempotency configures and applies the idempotency middleware
 // to prevent duplicate requests. By default, it skips safe methods
-// (GET, HEAD, OPTIONS, TRACE) and only processes POST, PUT, DELETE.
+// (GET, HEAD, OPTIONS, TRACE) and only processes POST, PUT, PATCH, DELETE.
 //
 // Usage:
 //
-//	middlewares.SetupIdempotency(app)
+//	middlewares.SetupIdempotency(app, store, cfg.RedisKeysTTL)
 //
-// The middleware checks for X-Idempotency-Key header (36-char UUID format)
-// and returns 409 Conflict for duplicate requests within the lifetime window.
-func SetupIdempotency(app *fiber.App) {
+// The middleware checks for X-Idempotency-Key header (36-char UUID format).
+// A retry with the same key and request within lifetime gets the status, headers
+// and body of the first response, marked with Idempotent-Replayed. Reusing the key
+// for a different method, path, body or caller is refused with 422.
+// Responses of handlers returning an error are not stored, so those requests can be retried.
+// Neither are the responses of the login and token refresh routes, they carry tokens.
+func SetupIdempotency(app *fiber.App, store idempotencypkg.Store, lifetime time.Duration) {
+	app.Use(checkIdempotencyFingerprint(store, lifetime))
 	app.Use(idempotency.New(idempotency.Config{
-		Lifetime: 5 * time.Minute,
+		Next:     skipIdempotency,
+		Lock:     store,
+		Storage:  store,
+		Lifetime: lifetime,
 	}))
 }
+
+// skipIdempotency skips the safe methods, like the middleware does by default, and the credential routes
+func skipIdempotency(c fiber.Ctx) bool {
+	if idempotency.ConfigDefault.Next(c) {
+		return true
+	}
+
+	// routing ignores the case and a trailing slash
+	path := strings.TrimSuffix(c.Path(), "/")
+	return slices.ContainsFunc(credentialPaths, func(credentialPath string) bool {
+		return strings.EqualFold(path, credentialPath)
+	})
+}
+
+// checkIdempotencyFingerprint remembers a hash of the first request sent with a key
+// and refuses later requests with the same key but another hash
+func checkIdempotencyFingerprint(store idempotencypkg.Store, lifetime time.Duration) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		key := c.Get(idempotency.ConfigDefault.KeyHeader)
+		if key == "" || skipIdempotency(c) {
+			return c.Next()
+		}
+		if err := idempotency.ConfigDefault.KeyHeaderValidate(key); err != nil {
+			return err
+		}
+
+		matches, err := claimFingerprint(c, store, key, requestFingerprint(c), lifetime)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			return errIdempotencyKeyReused
+		}
+
+		// the replay adds the stored headers to the ones the middlewares in front already set,
+		// those describe this request (X-Request-ID...) and replace their stored copy
+		outer := map[string]string{}
+		for k, v := range c.Response().Header.All() {
+			outer[string(k)] = string(v)
+		}
+		delete(outer, fiber.HeaderContentType)
+		delete(outer, fiber.HeaderContentLength)
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if idempotency.IsFromCache(c) {
+			for k, v := range outer {
+				// Set only replaces the first of the values
+				c.Response().Header.Del(k)
+				c.Set(k, v)
+			}
+			c.Set(IdempotentReplayedHeader, "true")
+		}
+		return nil
+	}
+}
+
+// claimFingerprint stores fingerprint for key unless one is stored already, and reports whether
+// the stored one is fingerprint. The key is locked meanwhile, so of two requests reusing a key
+// at once only one gets its fingerprint stored.
+func claimFingerprint(ctx context.Context, store idempotencypkg.Store, key, fingerprint string, lifetime time.Duration) (bool, error) {
+	storageKey := fingerprintKeyPrefix + key
+	if err := store.Lock(storageKey); err != nil {
+		return false, err
+	}
+	defer store.Unlock(storageKey)
+
+	stored, err := store.GetWithContext(ctx, storageKey)
+	if err != nil {
+		return false, err
+	}
+	if stored != nil {
+		return string(stored) == fingerprint, nil
+	}
+
+	return true, store.SetWithContext(ctx, storageKey, []byte(fingerprint), lifetime)
+}
+
+// requestFingerprint identifies a request by what the handler acts on, the caller included
+// so a key can't be used to read the response of somebody else's request
+func requestFingerprint(c fiber.Ctx) string {
+	h := sha256.New()
+	for _, part := range [][]byte{
+		[]byte(c.Method()),
+		[]byte(c.OriginalURL()),
+		[]byte(c.Get(fiber.HeaderAuthorization)),
+		c.Body(),
+	} {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
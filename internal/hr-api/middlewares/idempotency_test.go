@@ -1,12 +1,18 @@
 package middlewares
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+	idempotencypkg "web-boilerplate/internal/hr-api/pkg/idempotency"
 
 	"github.com/gofiber/fiber/v3"
-	"github.com/gofiber/fiber/v3/middleware/idempotency"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,7 +20,7 @@ func TestIdempotency_DuplicateRequest(t *testing.T) {
 	app := fiber.New()
 
 	// Setup idempotency middleware
-	SetupIdempotency(app)
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
 
 	requestCount := 0
 
@@ -48,7 +54,7 @@ func TestIdempotency_DifferentKeys(t *testing.T) {
 	app := fiber.New()
 
 	// Setup idempotency middleware
-	SetupIdempotency(app)
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
 
 	// Add a test handler
 	app.Post("/test", func(c fiber.Ctx) error {
@@ -74,7 +80,7 @@ func TestIdempotency_SafeMethodsSkipped(t *testing.T) {
 	app := fiber.New()
 
 	// Setup idempotency middleware
-	SetupIdempotency(app)
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
 
 	// Add a test handler
 	app.Get("/test", func(c fiber.Ctx) error {
@@ -98,7 +104,7 @@ func TestIdempotency_InvalidKeyFormat(t *testing.T) {
 	app := fiber.New()
 
 	// Setup idempotency middleware with default validation
-	SetupIdempotency(app)
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
 
 	// Add a test handler
 	app.Post("/test", func(c fiber.Ctx) error {
@@ -118,7 +124,7 @@ func TestIdempotency_NoKey(t *testing.T) {
 	app := fiber.New()
 
 	// Setup idempotency middleware
-	SetupIdempotency(app)
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
 
 	// Add a test handler
 	app.Post("/test", func(c fiber.Ctx) error {
@@ -136,9 +142,7 @@ func TestIdempotency_Expiration(t *testing.T) {
 	app := fiber.New()
 
 	// Setup idempotency middleware with very short lifetime for testing
-	app.Use(idempotency.New(idempotency.Config{
-		Lifetime: 100 * time.Millisecond, // Very short for testing
-	}))
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 100*time.Millisecond) // Very short for testing
 
 	// Add a test handler
 	app.Post("/test", func(c fiber.Ctx) error {
@@ -164,3 +168,240 @@ func TestIdempotency_Expiration(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 200, resp2.StatusCode, "Request after expiration should succeed")
 }
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	app := fiber.New()
+
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
+
+	requestCount := 0
+	app.Post("/test", func(c fiber.Ctx) error {
+		requestCount++
+		c.Set(fiber.HeaderLocation, "/test/1")
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": requestCount})
+	})
+
+	idempotencyKey := "550e8400-e29b-41d4-a716-446655440005"
+
+	var bodies []string
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(`{"name":"test"}`))
+		req.Header.Set("X-Idempotency-Key", idempotencyKey)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		assert.Equal(t, []string{"/test/1"}, resp.Header.Values(fiber.HeaderLocation))
+		assert.Equal(t, fiber.MIMEApplicationJSONCharsetUTF8, resp.Header.Get(fiber.HeaderContentType))
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		bodies = append(bodies, string(body))
+
+		if i == 0 {
+			assert.Empty(t, resp.Header.Get(IdempotentReplayedHeader))
+		} else {
+			assert.Equal(t, "true", resp.Header.Get(IdempotentReplayedHeader))
+		}
+	}
+
+	assert.Equal(t, 1, requestCount, "Handler should be called once")
+	assert.Equal(t, bodies[0], bodies[1], "Replayed body should match the original")
+}
+
+func TestIdempotency_ReplayKeepsCurrentRequestHeaders(t *testing.T) {
+	app := fiber.New()
+
+	SetupMiddlewareRequestID(app)
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
+
+	app.Post("/test", func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": "success"})
+	})
+
+	idempotencyKey := "550e8400-e29b-41d4-a716-446655440006"
+
+	var requestIDs [][]string
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/test", nil)
+		req.Header.Set("X-Idempotency-Key", idempotencyKey)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		requestIDs = append(requestIDs, resp.Header.Values(fiber.HeaderXRequestID))
+	}
+
+	// the replay carries the ID of the retry, not a second copy of the original one
+	assert.Len(t, requestIDs[1], 1)
+	assert.NotEqual(t, requestIDs[0], requestIDs[1])
+}
+
+func TestIdempotency_KeyReusedWithDifferentBody(t *testing.T) {
+	app := fiber.New()
+
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
+
+	requestCount := 0
+	app.Post("/test", func(c fiber.Ctx) error {
+		requestCount++
+		return c.JSON(fiber.Map{"status": "success"})
+	})
+
+	idempotencyKey := "550e8400-e29b-41d4-a716-446655440007"
+
+	req1 := httptest.NewRequest("POST", "/test", strings.NewReader(`{"amount":1}`))
+	req1.Header.Set("X-Idempotency-Key", idempotencyKey)
+	resp1, err := app.Test(req1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp1.StatusCode)
+
+	req2 := httptest.NewRequest("POST", "/test", strings.NewReader(`{"amount":2}`))
+	req2.Header.Set("X-Idempotency-Key", idempotencyKey)
+	resp2, err := app.Test(req2)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp2.StatusCode)
+	assert.Equal(t, 1, requestCount, "Handler should NOT be called for the conflicting request")
+}
+
+func TestIdempotency_KeyReusedByAnotherCaller(t *testing.T) {
+	app := fiber.New()
+
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
+
+	app.Post("/test", func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"owner": c.Get(fiber.HeaderAuthorization)})
+	})
+
+	idempotencyKey := "550e8400-e29b-41d4-a716-446655440008"
+
+	req1 := httptest.NewRequest("POST", "/test", nil)
+	req1.Header.Set("X-Idempotency-Key", idempotencyKey)
+	req1.Header.Set(fiber.HeaderAuthorization, "Bearer alice")
+	resp1, err := app.Test(req1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp1.StatusCode)
+
+	// same key, path and body but another token must not get alice's response
+	req2 := httptest.NewRequest("POST", "/test", nil)
+	req2.Header.Set("X-Idempotency-Key", idempotencyKey)
+	req2.Header.Set(fiber.HeaderAuthorization, "Bearer bob")
+	resp2, err := app.Test(req2)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp2.StatusCode)
+}
+
+func TestIdempotency_HandlerErrorNotStored(t *testing.T) {
+	app := fiber.New()
+
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
+
+	requestCount := 0
+	app.Post("/test", func(c fiber.Ctx) error {
+		requestCount++
+		if requestCount == 1 {
+			return fiber.ErrServiceUnavailable
+		}
+		return c.JSON(fiber.Map{"status": "success"})
+	})
+
+	idempotencyKey := "550e8400-e29b-41d4-a716-446655440009"
+
+	req1 := httptest.NewRequest("POST", "/test", nil)
+	req1.Header.Set("X-Idempotency-Key", idempotencyKey)
+	resp1, err := app.Test(req1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp1.StatusCode)
+
+	// the retry runs the handler again instead of replaying the failure
+	req2 := httptest.NewRequest("POST", "/test", nil)
+	req2.Header.Set("X-Idempotency-Key", idempotencyKey)
+	resp2, err := app.Test(req2)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp2.StatusCode)
+	assert.Equal(t, 2, requestCount)
+}
+
+// slowFingerprintStore widens the window between reading and storing a fingerprint
+type slowFingerprintStore struct {
+	*idempotencypkg.MemoryStore
+}
+
+func (s slowFingerprintStore) GetWithContext(ctx context.Context, key string) ([]byte, error) {
+	val, err := s.MemoryStore.GetWithContext(ctx, key)
+	if strings.HasPrefix(key, fingerprintKeyPrefix) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return val, err
+}
+
+func TestIdempotency_ConcurrentReuseWithDifferentBodies(t *testing.T) {
+	app := fiber.New()
+
+	SetupIdempotency(app, slowFingerprintStore{idempotencypkg.NewMemoryStore()}, 5*time.Minute)
+
+	var requestCount atomic.Int32
+	app.Post("/test", func(c fiber.Ctx) error {
+		requestCount.Add(1)
+		return c.JSON(fiber.Map{"status": "success"})
+	})
+
+	idempotencyKey := "550e8400-e29b-41d4-a716-446655440010"
+
+	statuses := make(chan int, 5)
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Go(func() {
+			req := httptest.NewRequest("POST", "/test", strings.NewReader(fmt.Sprintf(`{"amount":%d}`, i)))
+			req.Header.Set("X-Idempotency-Key", idempotencyKey)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			if err == nil {
+				statuses <- resp.StatusCode
+			}
+		})
+	}
+	wg.Wait()
+	close(statuses)
+
+	counts := map[int]int{}
+	for status := range statuses {
+		counts[status]++
+	}
+	// only the request whose fingerprint was stored first goes through
+	assert.Equal(t, map[int]int{200: 1, fiber.StatusUnprocessableEntity: 4}, counts)
+	assert.Equal(t, int32(1), requestCount.Load())
+}
+
+func TestIdempotency_CredentialResponsesNotStored(t *testing.T) {
+	store := idempotencypkg.NewMemoryStore()
+	app := fiber.New()
+
+	SetupIdempotency(app, store, 5*time.Minute)
+
+	requestCount := 0
+	for _, path := range credentialPaths {
+		app.Post(path, func(c fiber.Ctx) error {
+			requestCount++
+			return c.JSON(fiber.Map{"access_token": fmt.Sprintf("token-%d", requestCount)})
+		})
+	}
+
+	idempotencyKey := "550e8400-e29b-41d4-a716-446655440011"
+
+	// routing ignores the case and a trailing slash, so does the middleware
+	for _, path := range append(credentialPaths, "/V1/Login/") {
+		for range 2 {
+			req := httptest.NewRequest("POST", path, strings.NewReader(`{"username":"jane"}`))
+			req.Header.Set("X-Idempotency-Key", idempotencyKey)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+			assert.Empty(t, resp.Header.Get(IdempotentReplayedHeader))
+		}
+	}
+	assert.Equal(t, 2*(len(credentialPaths)+1), requestCount)
+
+	for _, key := range []string{idempotencyKey, fingerprintKeyPrefix + idempotencyKey} {
+		stored, err := store.Get(key)
+		assert.NoError(t, err)
+		assert.Nil(t, stored, "nothing is stored under %s", key)
+	}
+}
@@ -12,7 +12,8 @@ func SetupLogger(app *fiber.App, log *zerolog.Logger) {
 		GetLogger: func(c fiber.Ctx) zerolog.Logger {
 			// Custom IP extraction logic
 			ips := append(c.IPs(), c.IP(), c.Get("CF-Connecting-IP"))
-			return log.With().Strs("ips", ips).Logger()
+			// the context carries the request span, the log hook adds its ids
+			return log.With().Strs("ips", ips).Ctx(c.Context()).Logger()
 		},
 		Fields: []string{
 			fiberzerolog.FieldLatency,
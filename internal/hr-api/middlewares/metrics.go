@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	"web-boilerplate/internal/hr-api/pkg/metrics"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/idempotency"
+)
+
+// SetupMetrics records the count and latency of every request by route template and status.
+// It must come before the recover and idempotency middlewares so recovered panics and replayed
+// idempotent responses are counted too. Only the tracing middleware goes first, its span covers the whole request.
+//
+// Usage:
+//
+//	middlewares.SetupMetrics(app, m)
+func SetupMetrics(app *fiber.App, m *metrics.Metrics) {
+	app.Use(func(c fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		// errors are turned into responses by the error handler only after the middlewares returned
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		if idempotency.IsFromCache(c) {
+			m.IdempotencyHit()
+		}
+		m.ObserveRequest(c.Method(), c.Route().Path, strconv.Itoa(status), time.Since(start).Seconds())
+
+		return err
+	})
+}
@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-api/interfaces"
+	idempotencypkg "web-boilerplate/internal/hr-api/pkg/idempotency"
+	"web-boilerplate/internal/hr-api/pkg/metrics"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	app := fiber.New()
+	app.Get("/metrics", m.Handler())
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_RouteTemplateAndStatus(t *testing.T) {
+	m := metrics.New()
+
+	app := fiber.New()
+	SetupMetrics(app, m)
+	app.Get("/users/:id", func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/missing", func(c fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+
+	for _, path := range []string{"/users/1", "/users/2", "/missing"} {
+		_, err := app.Test(httptest.NewRequest("GET", path, nil))
+		assert.NoError(t, err)
+	}
+
+	body := scrape(t, m)
+	// the template is the label, not the path
+	assert.Contains(t, body, `hrapi_http_requests_total{method="GET",route="/users/:id",status="204"} 2`)
+	assert.Contains(t, body, `hrapi_http_requests_total{method="GET",route="/missing",status="404"} 1`)
+	assert.Contains(t, body, `hrapi_http_request_duration_seconds_count{method="GET",route="/users/:id",status="204"} 2`)
+}
+
+func TestMetrics_RecoveredPanic(t *testing.T) {
+	m := metrics.New()
+
+	mockLogger := interfaces.NewMockLogger(t)
+	mockLogger.EXPECT().Info("panic occurred", mock.Anything)
+
+	app := fiber.New()
+	SetupMetrics(app, m)
+	SetupMiddlewareRecover(app, mockLogger, m)
+	app.Get("/panic", func(c fiber.Ctx) error {
+		panic(errors.New("test panic"))
+	})
+
+	_, err := app.Test(httptest.NewRequest("GET", "/panic", nil))
+	assert.NoError(t, err)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, "hrapi_panics_recovered_total 1")
+	assert.Contains(t, body, `hrapi_http_requests_total{method="GET",route="/panic",status="500"} 1`)
+}
+
+func TestMetrics_IdempotencyHit(t *testing.T) {
+	m := metrics.New()
+
+	app := fiber.New()
+	SetupMetrics(app, m)
+	SetupIdempotency(app, idempotencypkg.NewMemoryStore(), 5*time.Minute)
+	app.Post("/test", func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": "success"})
+	})
+
+	for range 2 {
+		req := httptest.NewRequest("POST", "/test", nil)
+		req.Header.Set("X-Idempotency-Key", "550e8400-e29b-41d4-a716-446655440000")
+		_, err := app.Test(req)
+		assert.NoError(t, err)
+	}
+
+	assert.Contains(t, scrape(t, m), "hrapi_idempotency_hits_total 1")
+}
@@ -1,8 +1,14 @@
 package middlewares
 
-import "github.com/gofiber/fiber/v3"
+import (
+	"time"
+	"web-boilerplate/internal/hr-api/pkg/idempotency"
 
-func SetupMiddlewares(app *fiber.App) {
-	SetupIdempotency(app)
+	"github.com/gofiber/fiber/v3"
+)
+
+func SetupMiddlewares(app *fiber.App, idempotencyStore idempotency.Store, idempotencyLifetime time.Duration) {
 	SetupMiddlewaresEssentials(app)
+	// last so the stored responses are the uncompressed ones written by the handlers
+	SetupIdempotency(app, idempotencyStore, idempotencyLifetime)
 }
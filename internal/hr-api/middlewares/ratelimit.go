@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+	"web-boilerplate/internal/hr-api/pkg/ratelimit"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Rate limit response headers, draft-ietf-httpapi-ratelimit-headers
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// RateLimitKey returns who a request is counted against
+type RateLimitKey func(c fiber.Ctx) string
+
+type RateLimitPolicy struct {
+	// Name separates the counters of policies sharing a key
+	Name string
+	// Limit is the number of requests allowed within Window
+	Limit int64
+	// Window is how long a request counts against the limit
+	Window time.Duration
+	// Key defaults to RateLimitByIP
+	Key RateLimitKey
+}
+
+// RateLimitByIP counts the requests of a client IP. Behind a reverse proxy it needs the
+// PROXY_HEADER and TRUSTED_PROXIES config, every client would share the proxy's counter otherwise.
+func RateLimitByIP(c fiber.Ctx) string {
+	return "ip:" + c.IP()
+}
+
+// RateLimitByUser counts the requests of the user of the access token, it must run after Protected.
+// Requests without claims fall back to their IP.
+func RateLimitByUser(c fiber.Ctx) string {
+	claims, ok := c.Locals("user").(jwt.MapClaims)
+	if !ok {
+		return RateLimitByIP(c)
+	}
+
+	userID, ok := claims["id"].(string)
+	if !ok || userID == "" {
+		return RateLimitByIP(c)
+	}
+
+	return "user:" + userID
+}
+
+// RateLimit returns a middleware refusing with 429 the requests over the policy's limit.
+// Every response carries the RateLimit-* headers, refused ones Retry-After too.
+//
+// Usage:
+//
+//	limit := middlewares.RateLimit(limits, middlewares.RateLimitPolicy{Name: "login", Limit: 10, Window: time.Minute})
+//	v1.Post("/login", limit, h.Login)
+func RateLimit(store ratelimit.Store, policy RateLimitPolicy) fiber.Handler {
+	if policy.Key == nil {
+		policy.Key = RateLimitByIP
+	}
+	windowSeconds := int64(policy.Window.Seconds())
+
+	return func(c fiber.Ctx) error {
+		res, err := store.Allow(c.Context(), policy.Name+":"+policy.Key(c), policy.Limit, policy.Window)
+		if err != nil {
+			return fiber.ErrInternalServerError
+		}
+
+		reset := int64(math.Ceil(res.Reset.Seconds()))
+		c.Set(HeaderRateLimitLimit, strconv.FormatInt(policy.Limit, 10))
+		c.Set(HeaderRateLimitRemaining, strconv.FormatInt(res.Remaining, 10))
+		c.Set(HeaderRateLimitReset, strconv.FormatInt(reset, 10))
+		c.Set(HeaderRateLimitPolicy, fmt.Sprintf("%d;w=%d", policy.Limit, windowSeconds))
+
+		if !res.Allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(reset, 10))
+			return problem.New(fiber.StatusTooManyRequests, problem.CodeRateLimited, "too many requests").
+				With("retry_after", reset)
+		}
+
+		return c.Next()
+	}
+}
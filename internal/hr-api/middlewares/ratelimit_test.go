@@ -0,0 +1,122 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-api/pkg/ratelimit"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newRateLimitApp mounts RateLimit behind a middleware simulating Protected for the user in the X-User header
+func newRateLimitApp(store ratelimit.Store, policy RateLimitPolicy) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if user := c.Get("X-User"); user != "" {
+			c.Locals("user", jwt.MapClaims{"id": user})
+		}
+		return c.Next()
+	})
+	app.Post("/test", RateLimit(store, policy), func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": "success"})
+	})
+
+	return app
+}
+
+func TestRateLimit_AllowsUpToLimit(t *testing.T) {
+	app := newRateLimitApp(ratelimit.NewMemoryStore(), RateLimitPolicy{Name: "test", Limit: 3, Window: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		resp, err := app.Test(httptest.NewRequest("POST", "/test", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode, "request %d should succeed", i+1)
+		assert.Equal(t, "3", resp.Header.Get(HeaderRateLimitLimit))
+		assert.Equal(t, strconv.Itoa(2-i), resp.Header.Get(HeaderRateLimitRemaining))
+		assert.Equal(t, "60", resp.Header.Get(HeaderRateLimitReset))
+		assert.Equal(t, "3;w=60", resp.Header.Get(HeaderRateLimitPolicy))
+		assert.Empty(t, resp.Header.Get(fiber.HeaderRetryAfter))
+	}
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/test", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "0", resp.Header.Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "60", resp.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func TestRateLimit_WindowSlides(t *testing.T) {
+	app := newRateLimitApp(ratelimit.NewMemoryStore(), RateLimitPolicy{Name: "test", Limit: 1, Window: 100 * time.Millisecond})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/test", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/test", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+
+	// Wait for the first request to leave the window
+	time.Sleep(150 * time.Millisecond)
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/test", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode, "Request after the window should succeed")
+}
+
+func TestRateLimit_ByUser(t *testing.T) {
+	app := newRateLimitApp(ratelimit.NewMemoryStore(), RateLimitPolicy{
+		Name: "test", Limit: 1, Window: time.Minute, Key: RateLimitByUser,
+	})
+
+	send := func(user string) int {
+		req := httptest.NewRequest("POST", "/test", nil)
+		req.Header.Set("X-User", user)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	// every user has their own counter even though they share the IP
+	assert.Equal(t, 200, send("alice"))
+	assert.Equal(t, 200, send("bob"))
+	assert.Equal(t, fiber.StatusTooManyRequests, send("alice"))
+}
+
+func TestRateLimit_PoliciesAreSeparate(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+
+	app := fiber.New()
+	app.Post("/a", RateLimit(store, RateLimitPolicy{Name: "a", Limit: 1, Window: time.Minute}), func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Post("/b", RateLimit(store, RateLimitPolicy{Name: "b", Limit: 1, Window: time.Minute}), func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/a", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/b", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestRateLimit_StoreError(t *testing.T) {
+	store := ratelimit.NewMockStore(t)
+	store.EXPECT().Allow(mock.Anything, mock.Anything, int64(1), time.Minute).
+		Return(ratelimit.Result{}, errors.New("redis down"))
+
+	app := newRateLimitApp(store, RateLimitPolicy{Name: "test", Limit: 1, Window: time.Minute})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/test", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
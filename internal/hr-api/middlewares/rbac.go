@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"slices"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Permissions attached to roles by the roles and permissions migration
+const (
+	PermEmployeesRead  = "employees:read"
+	PermEmployeesWrite = "employees:write"
+	PermUsersRead      = "users:read"
+	PermUsersWrite     = "users:write"
+)
+
+// RequirePermission returns a middleware rejecting with 403 the requests whose access token
+// doesn't carry every given permission. It must run after Protected.
+//
+// Usage:
+//
+//	employees.Post("/", middlewares.RequirePermission(middlewares.PermEmployeesWrite), h.CreateEmployee)
+func RequirePermission(permissions ...string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		claims, ok := c.Locals("user").(jwt.MapClaims)
+		if !ok {
+			return fiber.ErrUnauthorized
+		}
+
+		granted := tokenPermissions(claims)
+		for _, permission := range permissions {
+			if !slices.Contains(granted, permission) {
+				return problem.New(fiber.StatusForbidden, problem.CodeForbidden, "missing permission "+permission).
+					With("missing", permission)
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// tokenPermissions returns the "permissions" claim, decoded by the jwt parser as []any
+func tokenPermissions(claims jwt.MapClaims) []string {
+	raw, _ := claims["permissions"].([]any)
+
+	permissions := make([]string, 0, len(raw))
+	for _, p := range raw {
+		if s, ok := p.(string); ok {
+			permissions = append(permissions, s)
+		}
+	}
+
+	return permissions
+}
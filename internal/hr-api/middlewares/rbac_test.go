@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"web-boilerplate/internal/hr-api/pkg/problem"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRBACApp mounts RequirePermission behind a middleware simulating Protected
+func newRBACApp(claims jwt.MapClaims, permissions ...string) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(func(c fiber.Ctx) error {
+		if claims != nil {
+			c.Locals("user", claims)
+		}
+		return c.Next()
+	})
+	app.Post("/employees", RequirePermission(permissions...), func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{"message": "success"})
+	})
+
+	return app
+}
+
+func TestRequirePermission_Granted(t *testing.T) {
+	app := newRBACApp(jwt.MapClaims{
+		"id":          "test-user-id",
+		"role":        "hr_manager",
+		"permissions": []any{PermEmployeesRead, PermEmployeesWrite},
+	}, PermEmployeesWrite)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/employees", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestRequirePermission_Forbidden(t *testing.T) {
+	app := newRBACApp(jwt.MapClaims{
+		"id":          "test-user-id",
+		"role":        "employee",
+		"permissions": []any{PermEmployeesRead},
+	}, PermEmployeesWrite)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/employees", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 403, resp.StatusCode)
+
+	var respBody map[string]any
+	json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.Equal(t, problem.CodeForbidden, respBody["code"])
+	assert.Equal(t, PermEmployeesWrite, respBody["missing"])
+}
+
+func TestRequirePermission_RequiresEvery(t *testing.T) {
+	app := newRBACApp(jwt.MapClaims{
+		"id":          "test-user-id",
+		"permissions": []any{PermUsersRead},
+	}, PermUsersRead, PermUsersWrite)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/employees", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 403, resp.StatusCode)
+}
+
+func TestRequirePermission_NoPermissionsClaim(t *testing.T) {
+	// tokens issued before roles existed carry no permissions
+	app := newRBACApp(jwt.MapClaims{"id": "test-user-id"}, PermEmployeesRead)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/employees", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 403, resp.StatusCode)
+}
+
+func TestRequirePermission_Unauthenticated(t *testing.T) {
+	app := newRBACApp(nil, PermEmployeesRead)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/employees", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
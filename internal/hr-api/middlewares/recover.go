@@ -6,14 +6,16 @@ import (
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/recover"
 	"web-boilerplate/internal/hr-api/interfaces"
+	"web-boilerplate/internal/hr-api/pkg/metrics"
 )
 
 // SetupMiddlewareRecover adds panic recovery middleware to the Fiber app
 // It recovers from panics, logs them with stack trace, and returns a 500 error
-func SetupMiddlewareRecover(app *fiber.App, log interfaces.Logger) {
+func SetupMiddlewareRecover(app *fiber.App, log interfaces.Logger, metrics *metrics.Metrics) {
 	app.Use(recover.New(recover.Config{
 		EnableStackTrace: true,
 		StackTraceHandler: func(c fiber.Ctx, e any) {
+			metrics.PanicRecovered()
 			stack := debug.Stack()
 			// Log panic details using Info to support structured key-value logging
 			log.Info("panic occurred",
@@ -17,7 +17,7 @@ func TestRecoverMiddleware_Panic(t *testing.T) {
 	mockLogger.EXPECT().Info("panic occurred", mock.Anything)
 
 	app := fiber.New()
-	SetupMiddlewareRecover(app, mockLogger)
+	SetupMiddlewareRecover(app, mockLogger, nil)
 
 	// Add a route that panics
 	app.Get("/panic", func(c fiber.Ctx) error {
@@ -35,7 +35,7 @@ func TestRecoverMiddleware_NoPanic(t *testing.T) {
 	// Logger should not be called when no panic occurs
 
 	app := fiber.New()
-	SetupMiddlewareRecover(app, mockLogger)
+	SetupMiddlewareRecover(app, mockLogger, nil)
 
 	app.Get("/ok", func(c fiber.Ctx) error {
 		return c.Status(200).SendString("ok")
@@ -52,7 +52,7 @@ func TestRecoverMiddleware_StringPanic(t *testing.T) {
 	mockLogger.EXPECT().Info("panic occurred", mock.Anything)
 
 	app := fiber.New()
-	SetupMiddlewareRecover(app, mockLogger)
+	SetupMiddlewareRecover(app, mockLogger, nil)
 
 	app.Get("/panic-string", func(c fiber.Ctx) error {
 		panic("string panic")
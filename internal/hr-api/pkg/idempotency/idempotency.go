@@ -0,0 +1,19 @@
+// Package idempotency keeps what the idempotency middleware needs to answer a retried
+// request with the response of the first one.
+//
+// The middleware stores the responses and the request fingerprints through the
+// fiber.Storage half of a Store and holds its lock while the first request is handled,
+// so a retry arriving meanwhile waits for the response instead of running the handler again.
+package idempotency
+
+import (
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/idempotency"
+)
+
+// Store keeps the stored responses and serializes the requests sharing a key,
+// keys are opaque to it
+type Store interface {
+	fiber.Storage
+	idempotency.Locker
+}
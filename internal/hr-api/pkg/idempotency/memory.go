@@ -0,0 +1,110 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v3/middleware/idempotency"
+)
+
+type memoryEntry struct {
+	val       []byte
+	expiresAt time.Time
+}
+
+// MemoryStore is an in process Store, responses are lost on restart and not shared
+// between instances so it is only meant for tests and local development
+type MemoryStore struct {
+	*idempotency.MemoryLock
+
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		MemoryLock: idempotency.NewMemoryLock(),
+		entries:    map[string]memoryEntry{},
+	}
+}
+
+func (s *MemoryStore) GetWithContext(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, nil
+	}
+
+	return entry.val, nil
+}
+
+func (s *MemoryStore) Get(key string) ([]byte, error) {
+	return s.GetWithContext(context.Background(), key)
+}
+
+func (s *MemoryStore) SetWithContext(_ context.Context, key string, val []byte, exp time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the caller may reuse val, fasthttp does with response bodies
+	entry := memoryEntry{val: append([]byte(nil), val...)}
+	if exp > 0 {
+		entry.expiresAt = time.Now().Add(exp)
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+func (s *MemoryStore) Set(key string, val []byte, exp time.Duration) error {
+	return s.SetWithContext(context.Background(), key, val, exp)
+}
+
+func (s *MemoryStore) DeleteWithContext(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	return s.DeleteWithContext(context.Background(), key)
+}
+
+func (s *MemoryStore) ResetWithContext(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = map[string]memoryEntry{}
+	return nil
+}
+
+func (s *MemoryStore) Reset() error {
+	return s.ResetWithContext(context.Background())
+}
+
+// Sweep forgets the responses whose expiration passed, Get only forgets the ones
+// it is asked about. It is meant to be called periodically.
+func (s *MemoryStore) Sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range s.entries {
+		if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,25 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_SweepDropsExpiredResponses(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	require.NoError(t, store.SetWithContext(ctx, "expired", []byte("201"), time.Millisecond))
+	require.NoError(t, store.SetWithContext(ctx, "valid", []byte("200"), time.Hour))
+
+	time.Sleep(5 * time.Millisecond)
+	store.Sweep()
+
+	assert.NotContains(t, store.entries, "expired")
+	val, err := store.GetWithContext(ctx, "valid")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("200"), val)
+}
@@ -0,0 +1,142 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v3/middleware/idempotency"
+)
+
+const (
+	keyPrefix     = "idempotency:"
+	lockKeyPrefix = "idempotency_lock:"
+
+	// lockTTL frees the lock of an instance that died while handling the request
+	lockTTL = 30 * time.Second
+	// lockRetry is how often a request waits for the lock held by another one
+	lockRetry = 50 * time.Millisecond
+)
+
+// only deletes the lock when it is still ours, it may have expired and been taken meanwhile
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisStore is a Store shared by every hr-api instance using the same redis
+type RedisStore struct {
+	client *redis.Client
+
+	// local lets a single request per key of this instance hold or wait for the redis lock,
+	// so the token stored for a key is the one of the request that will unlock it
+	local *idempotency.MemoryLock
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		client: client,
+		local:  idempotency.NewMemoryLock(),
+		tokens: map[string]string{},
+	}
+}
+
+func (s *RedisStore) GetWithContext(ctx context.Context, key string) ([]byte, error) {
+	val, err := s.client.Get(ctx, keyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	return val, err
+}
+
+func (s *RedisStore) Get(key string) ([]byte, error) {
+	return s.GetWithContext(context.Background(), key)
+}
+
+func (s *RedisStore) SetWithContext(ctx context.Context, key string, val []byte, exp time.Duration) error {
+	return s.client.Set(ctx, keyPrefix+key, val, exp).Err()
+}
+
+func (s *RedisStore) Set(key string, val []byte, exp time.Duration) error {
+	return s.SetWithContext(context.Background(), key, val, exp)
+}
+
+func (s *RedisStore) DeleteWithContext(ctx context.Context, key string) error {
+	return s.client.Del(ctx, keyPrefix+key).Err()
+}
+
+func (s *RedisStore) Delete(key string) error {
+	return s.DeleteWithContext(context.Background(), key)
+}
+
+// ResetWithContext deletes every stored response, the other keys of the database are left alone
+func (s *RedisStore) ResetWithContext(ctx context.Context) error {
+	iter := s.client.Scan(ctx, 0, keyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := s.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+
+	return iter.Err()
+}
+
+func (s *RedisStore) Reset() error {
+	return s.ResetWithContext(context.Background())
+}
+
+// Close doesn't close the client, it is shared with the other stores
+func (s *RedisStore) Close() error {
+	return nil
+}
+
+// Lock waits until no other request, on any instance, holds key
+func (s *RedisStore) Lock(key string) error {
+	ctx := context.Background()
+	token := rand.Text()
+
+	if err := s.local.Lock(key); err != nil {
+		return err
+	}
+	for {
+		ok, err := s.client.SetNX(ctx, lockKeyPrefix+key, token, lockTTL).Result()
+		if err != nil {
+			s.local.Unlock(key)
+			return err
+		}
+		if ok {
+			break
+		}
+		time.Sleep(lockRetry)
+	}
+
+	s.mu.Lock()
+	s.tokens[key] = token
+	s.mu.Unlock()
+	return nil
+}
+
+// Unlock releases the redis lock taken by the request of this instance holding key, unless
+// it expired and was taken by another instance meanwhile
+func (s *RedisStore) Unlock(key string) error {
+	s.mu.Lock()
+	token, ok := s.tokens[key]
+	delete(s.tokens, key)
+	s.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	defer s.local.Unlock(key)
+
+	return unlockScript.Run(context.Background(), s.client, []string{lockKeyPrefix + key}, token).Err()
+}
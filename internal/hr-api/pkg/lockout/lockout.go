@@ -0,0 +1,120 @@
+// Package lockout slows down and then blocks password guessing.
+//
+// Failed attempts are counted per account and per client IP. Every failure on an account
+// delays the next attempt a little more, and reaching the threshold of either counter
+// locks it out for the lockout duration. Callers answer 429 with Retry-After while locked.
+package lockout
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Store keeps the failed attempt counters and the lockouts, keys are opaque to it
+type Store interface {
+	// Fail records a failed attempt for key and returns the failures within the window,
+	// the counter is forgotten once no failure happened for window
+	Fail(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Lock refuses attempts for key during d
+	Lock(ctx context.Context, key string, d time.Duration) error
+	// LockedFor returns how long key is still locked, 0 when it isn't
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// Reset forgets the failures and the lock of key
+	Reset(ctx context.Context, key string) error
+}
+
+type Policy struct {
+	// MaxAttempts is the number of failures locking an account out
+	MaxAttempts int64
+	// MaxAttemptsPerIP is the number of failures locking a client IP out,
+	// it is higher than MaxAttempts since many users can share an IP
+	MaxAttemptsPerIP int64
+	// Window is how long failures are remembered after the last one
+	Window time.Duration
+	// LockoutDuration is how long an account or IP stays locked once its threshold is reached
+	LockoutDuration time.Duration
+	// BaseDelay is the wait imposed after the first failure on an account, it doubles with every failure
+	BaseDelay time.Duration
+}
+
+// Guard applies a Policy to login attempts
+type Guard struct {
+	store  Store
+	policy Policy
+}
+
+func NewGuard(store Store, policy Policy) *Guard {
+	return &Guard{store: store, policy: policy}
+}
+
+// Check returns how long the caller must wait before attempting to log in, 0 when it may go ahead
+func (g *Guard) Check(ctx context.Context, username, ip string) (time.Duration, error) {
+	wait, err := g.store.LockedFor(ctx, accountKey(username))
+	if err != nil {
+		return 0, err
+	}
+
+	ipWait, err := g.store.LockedFor(ctx, ipKey(ip))
+	if err != nil {
+		return 0, err
+	}
+
+	return max(wait, ipWait), nil
+}
+
+// Failed records a failed attempt and locks the account or the IP as the policy requires
+func (g *Guard) Failed(ctx context.Context, username, ip string) error {
+	failures, err := g.store.Fail(ctx, accountKey(username), g.policy.Window)
+	if err != nil {
+		return err
+	}
+
+	if err := g.store.Lock(ctx, accountKey(username), g.accountDelay(failures)); err != nil {
+		return err
+	}
+
+	ipFailures, err := g.store.Fail(ctx, ipKey(ip), g.policy.Window)
+	if err != nil {
+		return err
+	}
+
+	if ipFailures >= g.policy.MaxAttemptsPerIP {
+		return g.store.Lock(ctx, ipKey(ip), g.policy.LockoutDuration)
+	}
+
+	return nil
+}
+
+// Succeeded clears the failures of the account. The IP counter is kept,
+// otherwise an attacker could reset it by logging into their own account.
+func (g *Guard) Succeeded(ctx context.Context, username string) error {
+	return g.store.Reset(ctx, accountKey(username))
+}
+
+// Unlock lifts the lockout of an account and clears its failures
+func (g *Guard) Unlock(ctx context.Context, username string) error {
+	return g.store.Reset(ctx, accountKey(username))
+}
+
+// accountDelay is BaseDelay doubled for every failure, the lockout duration once the threshold is reached
+func (g *Guard) accountDelay(failures int64) time.Duration {
+	if failures >= g.policy.MaxAttempts {
+		return g.policy.LockoutDuration
+	}
+
+	delay := g.policy.BaseDelay
+	for i := int64(1); i < failures && delay < g.policy.LockoutDuration; i++ {
+		delay *= 2
+	}
+
+	return min(delay, g.policy.LockoutDuration)
+}
+
+func accountKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,108 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type counter struct {
+	failures  int64
+	expiresAt time.Time
+}
+
+// MemoryStore is an in process Store, counters are lost on restart and not shared
+// between instances so it is only meant for tests and local development
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]counter
+	locks    map[string]time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: map[string]counter{},
+		locks:    map[string]time.Time{},
+	}
+}
+
+func (s *MemoryStore) Fail(_ context.Context, key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	c := s.counters[key]
+	if now.After(c.expiresAt) {
+		c = counter{}
+	}
+
+	c.failures++
+	c.expiresAt = now.Add(window)
+	s.counters[key] = c
+
+	return c.failures, nil
+}
+
+func (s *MemoryStore) Lock(_ context.Context, key string, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d <= 0 {
+		return nil
+	}
+
+	until := time.Now().Add(d)
+	// never shorten a longer lock
+	if until.After(s.locks[key]) {
+		s.locks[key] = until
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) LockedFor(_ context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, ok := s.locks[key]
+	if !ok {
+		return 0, nil
+	}
+
+	remaining := time.Until(until)
+	if remaining <= 0 {
+		delete(s.locks, key)
+		return 0, nil
+	}
+
+	return remaining, nil
+}
+
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.counters, key)
+	delete(s.locks, key)
+
+	return nil
+}
+
+// Sweep forgets the counters and the locks that expired, the other methods only forget
+// the ones they are asked about. It is meant to be called periodically.
+func (s *MemoryStore) Sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, c := range s.counters {
+		if now.After(c.expiresAt) {
+			delete(s.counters, key)
+		}
+	}
+	for key, until := range s.locks {
+		if now.After(until) {
+			delete(s.locks, key)
+		}
+	}
+}
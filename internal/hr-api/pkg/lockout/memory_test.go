@@ -0,0 +1,32 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_SweepDropsExpiredEntries(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	_, err := store.Fail(ctx, "ip:203.0.113.1", time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, store.Lock(ctx, "ip:203.0.113.1", time.Millisecond))
+	_, err = store.Fail(ctx, "user:jane", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, store.Lock(ctx, "user:jane", time.Hour))
+
+	time.Sleep(5 * time.Millisecond)
+	store.Sweep()
+
+	assert.NotContains(t, store.counters, "ip:203.0.113.1")
+	assert.NotContains(t, store.locks, "ip:203.0.113.1")
+	assert.Contains(t, store.counters, "user:jane")
+	wait, err := store.LockedFor(ctx, "user:jane")
+	require.NoError(t, err)
+	assert.Positive(t, wait)
+}
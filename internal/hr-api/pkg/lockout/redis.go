@@ -0,0 +1,71 @@
+package lockout
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	failuresKeyPrefix = "login_failures:"
+	lockKeyPrefix     = "login_lock:"
+)
+
+// RedisStore is a Store shared by every hr-api instance using the same redis
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Fail(ctx context.Context, key string, window time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, failuresKeyPrefix+key)
+		pipe.Expire(ctx, failuresKeyPrefix+key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (s *RedisStore) Lock(ctx context.Context, key string, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	remaining, err := s.LockedFor(ctx, key)
+	if err != nil {
+		return err
+	}
+	// never shorten a longer lock
+	if remaining >= d {
+		return nil
+	}
+
+	return s.client.Set(ctx, lockKeyPrefix+key, 1, d).Err()
+}
+
+func (s *RedisStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := s.client.PTTL(ctx, lockKeyPrefix+key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// -2 when the key doesn't exist, -1 when it has no expiry which Lock never does
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (s *RedisStore) Reset(ctx context.Context, key string) error {
+	return s.client.Del(ctx, failuresKeyPrefix+key, lockKeyPrefix+key).Err()
+}
@@ -3,6 +3,7 @@ package logger
 import (
 	"os"
 	"time"
+	"web-boilerplate/shared/tracing"
 
 	"github.com/rs/zerolog"
 )
@@ -19,7 +20,8 @@ func New(logLevel string) *zerolog.Logger {
 		With().
 		Timestamp().
 		Stack().
-		Logger()
+		Logger().
+		Hook(tracing.LogHook{})
 
 	return &logger
 }
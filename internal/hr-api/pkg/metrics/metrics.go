@@ -0,0 +1,124 @@
+// Package metrics holds the Prometheus collectors of hr-api.
+//
+// Every recording method is safe on a nil *Metrics so handlers and middlewares built
+// without metrics, as in tests, don't need a stand-in.
+package metrics
+
+import (
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/adaptor"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "hrapi"
+
+// Login results recorded by LoginAttempt
+const (
+	LoginSuccess     = "success"
+	LoginFailure     = "failure"
+	LoginLocked      = "locked"
+	LoginMFARequired = "mfa_required"
+)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	logins          *prometheus.CounterVec
+	idempotencyHits prometheus.Counter
+	panics          prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests handled, by method, route template and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency, by method, route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "login_attempts_total",
+			Help:      "Login attempts, by result.",
+		}, []string{"result"}),
+		idempotencyHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "idempotency_hits_total",
+			Help:      "Requests answered with the stored response of an earlier request with the same idempotency key.",
+		}),
+		panics: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "panics_recovered_total",
+			Help:      "Panics recovered while handling requests.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.logins,
+		m.idempotencyHits,
+		m.panics,
+	)
+
+	// every result is exported from the start, not only after its first attempt
+	for _, result := range []string{LoginSuccess, LoginFailure, LoginLocked, LoginMFARequired} {
+		m.logins.WithLabelValues(result)
+	}
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+// RegisterPool exports the connection stats of pool
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPoolCollector(pool))
+}
+
+// ObserveRequest records a handled request, route must be the route template and not the path
+// so ids don't end up in the labels
+func (m *Metrics) ObserveRequest(method, route, status string, seconds float64) {
+	if m == nil {
+		return
+	}
+	m.requests.WithLabelValues(method, route, status).Inc()
+	m.requestDuration.WithLabelValues(method, route, status).Observe(seconds)
+}
+
+func (m *Metrics) LoginAttempt(result string) {
+	if m == nil {
+		return
+	}
+	m.logins.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) IdempotencyHit() {
+	if m == nil {
+		return
+	}
+	m.idempotencyHits.Inc()
+}
+
+func (m *Metrics) PanicRecovered() {
+	if m == nil {
+		return
+	}
+	m.panics.Inc()
+}
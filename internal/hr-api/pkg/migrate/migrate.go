@@ -0,0 +1,413 @@
+// Package migrate applies the embedded SQL migrations of hr-api.
+//
+// Migrations are NNNNNN_name.up.sql and NNNNNN_name.down.sql files. The current version is
+// kept in the schema_migrations table in the same format as golang-migrate, so databases
+// migrated with its CLI carry on with this runner and the other way around.
+//
+// Every migration runs in its own transaction together with the version update, and the
+// whole run holds a Postgres advisory lock so instances starting together don't race.
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockID is the key of the advisory lock held while migrating, any constant
+// not used by another advisory lock of the database does
+const lockID = 7312986451
+
+var fileNameRegex = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+var ErrDirty = errors.New("database is dirty, a migration failed halfway: fix it by hand then force the version")
+
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Step is a migration applied, or to apply in a dry run, in one direction
+type Step struct {
+	Migration
+	Up bool
+}
+
+// SQL returns the statements the step runs
+func (s Step) SQL() string {
+	if s.Up {
+		return s.Migration.Up
+	}
+	return s.Migration.Down
+}
+
+func (s Step) String() string {
+	direction := "down"
+	if s.Up {
+		direction = "up"
+	}
+	return fmt.Sprintf("%06d_%s (%s)", s.Version, s.Name, direction)
+}
+
+type MigrationStatus struct {
+	Migration
+	Applied bool
+}
+
+type Status struct {
+	// Version is the last applied migration, 0 when none is
+	Version    uint64
+	Dirty      bool
+	Migrations []MigrationStatus
+}
+
+// Pending returns the number of migrations not applied yet
+func (s Status) Pending() int {
+	n := 0
+	for _, m := range s.Migrations {
+		if !m.Applied {
+			n++
+		}
+	}
+	return n
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	// DryRun only returns the steps a move would apply
+	DryRun bool
+}
+
+// New reads the migrations at the root of fsys
+func New(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// Load reads the migrations at the root of fsys sorted by version, both files of a migration must be present
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[uint64]*Migration{}
+	for _, entry := range entries {
+		match := fileNameRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		sql, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(sql)
+		} else {
+			m.Down = string(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %06d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	defer conn.Release()
+
+	version, dirty, err := readVersion(ctx, conn.Conn())
+	if err != nil {
+		return Status{}, err
+	}
+
+	status := Status{Version: version, Dirty: dirty}
+	for _, migration := range m.migrations {
+		status.Migrations = append(status.Migrations, MigrationStatus{
+			Migration: migration,
+			Applied:   migration.Version <= version,
+		})
+	}
+
+	return status, nil
+}
+
+// Up applies the next n pending migrations, all of them when n is 0
+func (m *Migrator) Up(ctx context.Context, n int) ([]Step, error) {
+	return m.move(ctx, m.upTarget(n))
+}
+
+// Down reverts the last n applied migrations
+func (m *Migrator) Down(ctx context.Context, n int) ([]Step, error) {
+	return m.move(ctx, m.downTarget(n))
+}
+
+// Goto applies or reverts migrations until version is the current one, 0 reverts them all
+func (m *Migrator) Goto(ctx context.Context, version uint64) ([]Step, error) {
+	return m.move(ctx, m.gotoTarget(version))
+}
+
+func (m *Migrator) upTarget(n int) func(current uint64) (uint64, error) {
+	return func(current uint64) (uint64, error) {
+		i := m.index(current) + 1
+		pending := len(m.migrations) - i
+		if pending == 0 {
+			return current, nil
+		}
+		if n <= 0 || n > pending {
+			n = pending
+		}
+		return m.migrations[i+n-1].Version, nil
+	}
+}
+
+func (m *Migrator) downTarget(n int) func(current uint64) (uint64, error) {
+	return func(current uint64) (uint64, error) {
+		i := m.index(current) - n
+		if i < 0 {
+			return 0, nil
+		}
+		return m.migrations[i].Version, nil
+	}
+}
+
+func (m *Migrator) gotoTarget(version uint64) func(current uint64) (uint64, error) {
+	return func(uint64) (uint64, error) {
+		if version != 0 && !m.known(version) {
+			return 0, fmt.Errorf("unknown migration version %d", version)
+		}
+		return version, nil
+	}
+}
+
+// Force sets the version and clears the dirty flag without running anything,
+// once a failed migration was fixed by hand
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	if m.DryRun {
+		return nil
+	}
+
+	return m.locked(ctx, func(conn *pgx.Conn) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		if err := writeVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	})
+}
+
+// move runs the steps between the current version and the one target picks
+func (m *Migrator) move(ctx context.Context, target func(current uint64) (uint64, error)) ([]Step, error) {
+	var applied []Step
+	err := m.locked(ctx, func(conn *pgx.Conn) error {
+		current, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		steps, err := m.plan(current, dirty, target)
+		if err != nil {
+			return err
+		}
+
+		for _, step := range steps {
+			if !m.DryRun {
+				if err := m.apply(ctx, conn, step); err != nil {
+					return fmt.Errorf("migration %s failed: %w", step, err)
+				}
+			}
+			applied = append(applied, step)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// plan returns the steps from the current version of the database to the one target picks
+func (m *Migrator) plan(current uint64, dirty bool, target func(current uint64) (uint64, error)) ([]Step, error) {
+	if dirty {
+		return nil, ErrDirty
+	}
+	if current != 0 && !m.known(current) {
+		return nil, fmt.Errorf("database is at version %d which has no migration file", current)
+	}
+
+	to, err := target(current)
+	if err != nil {
+		return nil, err
+	}
+	return m.steps(current, to), nil
+}
+
+// steps lists the migrations to apply going from the from version to the to version
+func (m *Migrator) steps(from, to uint64) []Step {
+	var steps []Step
+	if to > from {
+		for _, migration := range m.migrations {
+			if migration.Version > from && migration.Version <= to {
+				steps = append(steps, Step{Migration: migration, Up: true})
+			}
+		}
+		return steps
+	}
+
+	for _, migration := range slices.Backward(m.migrations) {
+		if migration.Version <= from && migration.Version > to {
+			steps = append(steps, Step{Migration: migration, Up: false})
+		}
+	}
+	return steps
+}
+
+// apply runs a step and records the version it leaves the database at in the same transaction
+func (m *Migrator) apply(ctx context.Context, conn *pgx.Conn, step Step) error {
+	version := step.Version
+	if !step.Up {
+		version = 0
+		if i := m.index(step.Version); i > 0 {
+			version = m.migrations[i-1].Version
+		}
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// without arguments pgx uses the simple protocol, which accepts several statements
+	if _, err := tx.Exec(ctx, step.SQL()); err != nil {
+		return err
+	}
+	if err := writeVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// locked runs fn on a connection holding the migration advisory lock, waiting for other instances to release it
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgx.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// the context may be done already, the lock must be released anyway or the pooled connection keeps it
+		_, _ = conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", lockID)
+	}()
+
+	// a dry run must not write anything, readVersion copes with the missing table
+	if !m.DryRun {
+		if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`); err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+	}
+
+	return fn(conn.Conn())
+}
+
+// index returns the position of version in the migrations, -1 for version 0
+func (m *Migrator) index(version uint64) int {
+	return slices.IndexFunc(m.migrations, func(migration Migration) bool {
+		return migration.Version == version
+	})
+}
+
+func (m *Migrator) known(version uint64) bool {
+	return m.index(version) >= 0
+}
+
+func readVersion(ctx context.Context, conn *pgx.Conn) (uint64, bool, error) {
+	var exists bool
+	err := conn.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists)
+	if err != nil || !exists {
+		return 0, false, err
+	}
+
+	var version int64
+	var dirty bool
+	err = conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	// golang-migrate leaves -1 after reverting everything
+	if version < 0 {
+		return 0, dirty, nil
+	}
+
+	return uint64(version), dirty, nil
+}
+
+func writeVersion(ctx context.Context, tx pgx.Tx, version uint64) error {
+	if _, err := tx.Exec(ctx, `TRUNCATE schema_migrations`); err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, int64(version))
+	return err
+}
@@ -0,0 +1,169 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func migrationFiles(names ...string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for _, name := range names {
+		fsys[name] = &fstest.MapFile{Data: []byte("-- " + name)}
+	}
+	return fsys
+}
+
+func newTestMigrator(t *testing.T) *Migrator {
+	migrations, err := Load(migrationFiles(
+		"000001_users.up.sql", "000001_users.down.sql",
+		"000002_roles.up.sql", "000002_roles.down.sql",
+		"000005_employees.up.sql", "000005_employees.down.sql",
+	))
+	require.NoError(t, err)
+	return &Migrator{migrations: migrations}
+}
+
+// versions lists the versions of steps, negative for the reverted ones
+func versions(steps []Step) []int {
+	var out []int
+	for _, step := range steps {
+		if step.Up {
+			out = append(out, int(step.Version))
+		} else {
+			out = append(out, -int(step.Version))
+		}
+	}
+	return out
+}
+
+func TestLoad_PairsFilesSortedByVersion(t *testing.T) {
+	fsys := migrationFiles(
+		"000010_employees.down.sql", "000002_roles.up.sql", "000010_employees.up.sql",
+		"000002_roles.down.sql", "README.md", "000003_draft.sql",
+	)
+	fsys["000004_folder.up.sql/nested.sql"] = &fstest.MapFile{}
+
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 2, Name: "roles", Up: "-- 000002_roles.up.sql", Down: "-- 000002_roles.down.sql"},
+		{Version: 10, Name: "employees", Up: "-- 000010_employees.up.sql", Down: "-- 000010_employees.down.sql"},
+	}, migrations)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		err   string
+	}{
+		{"missing down", []string{"000001_users.up.sql"}, "000001_users needs both an up and a down file"},
+		{"missing up", []string{"000001_users.down.sql"}, "000001_users needs both an up and a down file"},
+		{"two names", []string{"000001_users.up.sql", "000001_accounts.down.sql"}, "migration 1 has two names"},
+		{"version 0", []string{"000000_init.up.sql", "000000_init.down.sql"}, "invalid migration version in 000000_init"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(migrationFiles(tt.files...))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestMigrator_Plan(t *testing.T) {
+	m := newTestMigrator(t)
+
+	tests := []struct {
+		name    string
+		current uint64
+		target  func(current uint64) (uint64, error)
+		want    []int
+	}{
+		{"up all from empty", 0, m.upTarget(0), []int{1, 2, 5}},
+		{"up n", 0, m.upTarget(2), []int{1, 2}},
+		{"up more than pending", 2, m.upTarget(10), []int{5}},
+		{"up at latest", 5, m.upTarget(0), nil},
+		{"down one", 5, m.downTarget(1), []int{-5}},
+		{"down in reverse order", 5, m.downTarget(2), []int{-5, -2}},
+		{"down past the first", 2, m.downTarget(3), []int{-2, -1}},
+		{"down from empty", 0, m.downTarget(1), nil},
+		{"goto forward", 1, m.gotoTarget(5), []int{2, 5}},
+		{"goto backward", 5, m.gotoTarget(1), []int{-5, -2}},
+		{"goto 0", 5, m.gotoTarget(0), []int{-5, -2, -1}},
+		{"goto current", 2, m.gotoTarget(2), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := m.plan(tt.current, false, tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, versions(steps))
+		})
+	}
+}
+
+func TestMigrator_PlanStepsCarryTheirSQL(t *testing.T) {
+	m := newTestMigrator(t)
+
+	steps, err := m.plan(1, false, m.gotoTarget(2))
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, "-- 000002_roles.up.sql", steps[0].SQL())
+	assert.Equal(t, "000002_roles (up)", steps[0].String())
+
+	steps, err = m.plan(2, false, m.downTarget(1))
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, "-- 000002_roles.down.sql", steps[0].SQL())
+	assert.Equal(t, "000002_roles (down)", steps[0].String())
+}
+
+func TestMigrator_PlanRefusesDirtyDatabase(t *testing.T) {
+	m := newTestMigrator(t)
+
+	for _, target := range []func(uint64) (uint64, error){m.upTarget(0), m.downTarget(1), m.gotoTarget(0)} {
+		steps, err := m.plan(2, true, target)
+		assert.ErrorIs(t, err, ErrDirty)
+		assert.Empty(t, steps)
+	}
+}
+
+func TestMigrator_PlanRefusesUnknownVersions(t *testing.T) {
+	m := newTestMigrator(t)
+
+	// the database is ahead of the migration files
+	_, err := m.plan(3, false, m.upTarget(0))
+	assert.ErrorContains(t, err, "database is at version 3 which has no migration file")
+
+	_, err = m.plan(1, false, m.gotoTarget(4))
+	assert.ErrorContains(t, err, "unknown migration version 4")
+}
+
+func TestMigrator_Force(t *testing.T) {
+	m := newTestMigrator(t)
+	m.DryRun = true
+
+	assert.ErrorContains(t, m.Force(context.Background(), 4), "unknown migration version 4")
+	// a dry run checks the version without touching the database, there is no pool
+	assert.NoError(t, m.Force(context.Background(), 2))
+	assert.NoError(t, m.Force(context.Background(), 0))
+
+	// the version is checked before the database is reached
+	m.DryRun = false
+	assert.ErrorContains(t, m.Force(context.Background(), 3), "unknown migration version 3")
+}
+
+func TestStatus_Pending(t *testing.T) {
+	status := Status{Version: 2, Migrations: []MigrationStatus{
+		{Migration: Migration{Version: 1}, Applied: true},
+		{Migration: Migration{Version: 2}, Applied: true},
+		{Migration: Migration{Version: 5}},
+	}}
+
+	assert.Equal(t, 1, status.Pending())
+}
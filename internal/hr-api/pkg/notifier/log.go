@@ -0,0 +1,21 @@
+package notifier
+
+import (
+	"context"
+	"web-boilerplate/internal/hr-api/interfaces"
+)
+
+// LogNotifier writes messages to the application log instead of delivering them,
+// for local development only since the log then contains the secrets being sent
+type LogNotifier struct {
+	log interfaces.Logger
+}
+
+func NewLogNotifier(log interfaces.Logger) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+func (n *LogNotifier) Send(_ context.Context, msg Message) error {
+	n.log.Info("notification", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
@@ -0,0 +1,16 @@
+package notifier
+
+import "context"
+
+// Message is a notification addressed to a single recipient, To is an email address
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages to users, e.g. password reset links.
+// Real transports (SMTP, SES, ...) implement it next to the local stand-ins below.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,123 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the keyset position of the last row of a page. It is handed to clients
+// as an opaque string and carries the sort it was issued for so it can't be replayed
+// against a different ordering.
+type Cursor struct {
+	Sort string    `json:"s"`
+	Desc bool      `json:"d"`
+	Key  string    `json:"k"`
+	ID   uuid.UUID `json:"i"`
+}
+
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func Decode(encoded string) (Cursor, error) {
+	var c Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Page holds the pagination and sorting options of a list request
+type Page struct {
+	Limit  int
+	Sort   string
+	Desc   bool
+	Cursor *Cursor
+	// Total asks for the exact number of matching rows, which filtered listings only count on request
+	Total bool
+}
+
+// Next returns the encoded cursor pointing after the row with the given sort key and id
+func (p Page) Next(key string, id uuid.UUID) string {
+	return Cursor{Sort: p.Sort, Desc: p.Desc, Key: key, ID: id}.Encode()
+}
+
+// Meta is returned alongside list responses
+type Meta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	// TotalEstimate is nil when the listing is filtered and the total wasn't asked for
+	TotalEstimate *int64 `json:"total_estimate,omitempty"`
+}
+
+// Parse reads the limit, cursor, sort, order and include_total query params. sortFields lists the
+// accepted sort fields, the first one being the default.
+func Parse(c fiber.Ctx, sortFields ...string) (Page, error) {
+	page := Page{Limit: DefaultLimit}
+	if len(sortFields) > 0 {
+		page.Sort = sortFields[0]
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return page, fmt.Errorf("limit must be a number between 1 and %d", MaxLimit)
+		}
+		page.Limit = limit
+	}
+
+	if sort := c.Query("sort"); sort != "" {
+		if !slices.Contains(sortFields, sort) {
+			return page, fmt.Errorf("sort must be one of %v", sortFields)
+		}
+		page.Sort = sort
+	}
+
+	switch c.Query("order") {
+	case "", "asc":
+	case "desc":
+		page.Desc = true
+	default:
+		return page, errors.New("order must be asc or desc")
+	}
+
+	if raw := c.Query("include_total"); raw != "" {
+		total, err := strconv.ParseBool(raw)
+		if err != nil {
+			return page, errors.New("include_total must be true or false")
+		}
+		page.Total = total
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := Decode(raw)
+		if err != nil {
+			return page, err
+		}
+		if cursor.Sort != page.Sort || cursor.Desc != page.Desc {
+			return page, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidCursor)
+		}
+		page.Cursor = &cursor
+	}
+
+	return page, nil
+}
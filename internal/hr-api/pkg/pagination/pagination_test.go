@@ -0,0 +1,117 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parse runs Parse on a request with the given query, sorting by name by default
+func parse(t *testing.T, query string) (Page, error) {
+	var (
+		page Page
+		err  error
+	)
+	app := fiber.New()
+	app.Get("/", func(c fiber.Ctx) error {
+		page, err = Parse(c, "name", "email")
+		return nil
+	})
+
+	_, testErr := app.Test(httptest.NewRequest("GET", "/?"+query, nil))
+	require.NoError(t, testErr)
+	return page, err
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := Cursor{Sort: "email", Desc: true, Key: "jane.doe@example.com", ID: uuid.New()}
+
+	decoded, err := Decode(cursor.Encode())
+
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestDecode_InvalidCursor(t *testing.T) {
+	valid := Cursor{Sort: "name", Key: "Doe Jane", ID: uuid.New()}.Encode()
+
+	tests := map[string]string{
+		"not base64":   "not a cursor!",
+		"not json":     base64.RawURLEncoding.EncodeToString([]byte("name:Doe Jane")),
+		"truncated":    valid[:len(valid)/2],
+		"invalid id":   base64.RawURLEncoding.EncodeToString([]byte(`{"s":"name","k":"Doe Jane","i":"42"}`)),
+		"wrong fields": base64.RawURLEncoding.EncodeToString([]byte(`{"s":1}`)),
+	}
+
+	for name, encoded := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decode(encoded)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestParse_Defaults(t *testing.T) {
+	page, err := parse(t, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, Page{Limit: DefaultLimit, Sort: "name"}, page)
+}
+
+func TestParse_Options(t *testing.T) {
+	cursor := Cursor{Sort: "email", Desc: true, Key: "jane.doe@example.com", ID: uuid.New()}
+
+	page, err := parse(t, "limit=5&sort=email&order=desc&include_total=true&cursor="+cursor.Encode())
+
+	require.NoError(t, err)
+	assert.Equal(t, Page{Limit: 5, Sort: "email", Desc: true, Cursor: &cursor, Total: true}, page)
+
+	// the next cursor is issued for the same sort
+	next, err := Decode(page.Next("john.doe@example.com", cursor.ID))
+	require.NoError(t, err)
+	assert.Equal(t, Cursor{Sort: "email", Desc: true, Key: "john.doe@example.com", ID: cursor.ID}, next)
+}
+
+func TestParse_InvalidOptions(t *testing.T) {
+	tests := map[string]string{
+		"limit not a number": "limit=ten",
+		"limit too low":      "limit=0",
+		"limit too high":     "limit=101",
+		"unknown sort":       "sort=password",
+		"unknown order":      "order=up",
+		"invalid total":      "include_total=maybe",
+	}
+
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parse(t, query)
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestParse_InvalidCursor(t *testing.T) {
+	byName := Cursor{Sort: "name", Key: "Doe Jane", ID: uuid.New()}.Encode()
+
+	tests := map[string]string{
+		"not a cursor": "cursor=not-a-cursor",
+		"tampered":     "cursor=" + byName[1:],
+		// a cursor only continues the sort and order it was issued for
+		"another sort":           "sort=email&cursor=" + byName,
+		"another order":          "order=desc&cursor=" + byName,
+		"another sort and order": "sort=email&order=desc&cursor=" + byName,
+	}
+
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parse(t, query)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
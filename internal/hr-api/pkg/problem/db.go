@@ -0,0 +1,69 @@
+package problem
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// fromDB maps the errors returned by the repositories that are the client's doing,
+// nil for the others
+func fromDB(err error) *Error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &Error{Status: fiber.StatusNotFound, Code: CodeNotFound, Err: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		field := constraintField(pgErr)
+		return &Error{
+			Status: fiber.StatusConflict,
+			Code:   CodeAlreadyExists,
+			Detail: "a record with the same " + field + " already exists",
+			Fields: []FieldError{{Field: field, Message: field + " is already taken"}},
+			Err:    err,
+		}
+	case pgForeignKeyViolation:
+		field := constraintField(pgErr)
+		return &Error{
+			Status: fiber.StatusBadRequest,
+			Code:   CodeInvalidReference,
+			Detail: "the referenced " + field + " does not exist",
+			Fields: []FieldError{{Field: field, Message: field + " does not exist"}},
+			Err:    err,
+		}
+	}
+
+	return nil
+}
+
+// constraintField returns a human readable name for the column behind a constraint violation,
+// relying on postgres' default <table>_<column>_key and <table>_<column>_fkey constraint naming
+func constraintField(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+
+	name := strings.TrimPrefix(pgErr.ConstraintName, pgErr.TableName+"_")
+	name = strings.TrimSuffix(name, "_fkey")
+	name = strings.TrimSuffix(name, "_key")
+	if name == "" {
+		return "value"
+	}
+
+	return name
+}
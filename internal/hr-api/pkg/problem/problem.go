@@ -0,0 +1,232 @@
+// Package problem defines the application errors of hr-api and the RFC 7807 problem details
+// documents they are answered with.
+//
+// Every problem carries a stable code next to the status, clients branch on the code and only
+// show the detail. The cause of an error is logged but never sent.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// ContentType is the media type of problem documents
+const ContentType = "application/problem+json"
+
+// typePrefix turns a code into the problem type URI, the codes are the stable part
+const typePrefix = "urn:hr-api:problem:"
+
+// Stable error codes, errors created from a bare status get the snake cased status text
+const (
+	CodeBadRequest       = "bad_request"
+	CodeValidationFailed = "validation_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeInvalidToken     = "invalid_token"
+	CodeTokenRevoked     = "token_revoked"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeAlreadyExists    = "already_exists"
+	CodeInvalidReference = "invalid_reference"
+	CodeRateLimited      = "rate_limited"
+	CodeInternal         = "internal_error"
+
+	CodeLoginLocked          = "login_locked"
+	CodeIdempotencyKeyReused = "idempotency_key_reused"
+	CodeInvalidResetToken    = "invalid_reset_token"
+	CodeInvalidChallenge     = "invalid_challenge"
+	CodeInvalidCode          = "invalid_code"
+	CodeTOTPNotEnrolled      = "totp_not_enrolled"
+	CodeTOTPNotEnabled       = "totp_not_enabled"
+	CodeTOTPAlreadyEnabled   = "totp_already_enabled"
+)
+
+// Problem is a problem details document, RFC 7807 members first then the extension members
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	// Extensions are added as members of the document, they can't replace the ones above
+	Extensions map[string]any `json:"-"`
+}
+
+// FieldError is the reason a field of the request was refused
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type document Problem
+	data, err := json.Marshal(document(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := map[string]any{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for name, value := range p.Extensions {
+		if _, ok := members[name]; !ok {
+			members[name] = value
+		}
+	}
+
+	return json.Marshal(members)
+}
+
+// Error is an error answered with a problem document
+type Error struct {
+	Status int
+	Code   string
+	// Detail is sent to the client, it must not reveal internals
+	Detail     string
+	Fields     []FieldError
+	Extensions map[string]any
+	// Err is the cause, only logged
+	Err error
+}
+
+// New returns an error answered with status, code and detail
+func New(status int, code, detail string) *Error {
+	return &Error{Status: status, Code: code, Detail: detail}
+}
+
+// Validation returns the 400 answered for a request with invalid fields,
+// errs maps the field names to the reason they were refused
+func Validation(errs map[string]string) *Error {
+	fields := make([]FieldError, 0, len(errs))
+	for _, name := range slices.Sorted(maps.Keys(errs)) {
+		fields = append(fields, FieldError{Field: name, Message: errs[name]})
+	}
+
+	return &Error{
+		Status: fiber.StatusBadRequest,
+		Code:   CodeValidationFailed,
+		Detail: "the request has invalid fields",
+		Fields: fields,
+	}
+}
+
+func (e *Error) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = http.StatusText(e.Status)
+	}
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// As lets fiber's default error handler and the middlewares looking for a *fiber.Error
+// find the status of the error
+func (e *Error) As(target any) bool {
+	fiberErr, ok := target.(**fiber.Error)
+	if !ok {
+		return false
+	}
+
+	*fiberErr = fiber.NewError(e.Status, e.Error())
+	return true
+}
+
+// With returns a copy of the error carrying the extension member name
+func (e *Error) With(name string, value any) *Error {
+	copied := *e
+	copied.Extensions = maps.Clone(e.Extensions)
+	if copied.Extensions == nil {
+		copied.Extensions = map[string]any{}
+	}
+	copied.Extensions[name] = value
+	return &copied
+}
+
+// Wrap returns a copy of the error caused by err
+func (e *Error) Wrap(err error) *Error {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+// Problem returns the document answered for the error
+func (e *Error) Problem() Problem {
+	return Problem{
+		Type:       typePrefix + e.Code,
+		Title:      http.StatusText(e.Status),
+		Status:     e.Status,
+		Detail:     e.Detail,
+		Code:       e.Code,
+		Errors:     e.Fields,
+		Extensions: e.Extensions,
+	}
+}
+
+// From returns the application error answered for err: application errors as they are,
+// fiber errors by their status and repository errors by what went wrong.
+// Anything else is an internal error without detail.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	if dbErr := fromDB(err); dbErr != nil {
+		return dbErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		detail := fiberErr.Message
+		// fiber's predefined errors only repeat the status text
+		if detail == http.StatusText(fiberErr.Code) {
+			detail = ""
+		}
+		return &Error{Status: fiberErr.Code, Code: codeForStatus(fiberErr.Code), Detail: detail, Err: err}
+	}
+
+	return &Error{Status: fiber.StatusInternalServerError, Code: CodeInternal, Err: err}
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case fiber.StatusBadRequest:
+		return CodeBadRequest
+	case fiber.StatusUnauthorized:
+		return CodeUnauthorized
+	case fiber.StatusForbidden:
+		return CodeForbidden
+	case fiber.StatusNotFound:
+		return CodeNotFound
+	case fiber.StatusConflict:
+		return CodeConflict
+	case fiber.StatusTooManyRequests:
+		return CodeRateLimited
+	case fiber.StatusInternalServerError:
+		return CodeInternal
+	}
+
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	text = strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text)
+	return strings.ToLower(text)
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// keyHits are the hits of a key within its window
+type keyHits struct {
+	hits   []time.Time
+	window time.Duration
+}
+
+// MemoryStore is an in process Store, hits are lost on restart and not shared
+// between instances so it is only meant for tests and local development
+type MemoryStore struct {
+	mu   sync.Mutex
+	keys map[string]keyHits
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		keys: map[string]keyHits{},
+	}
+}
+
+func (s *MemoryStore) Allow(_ context.Context, key string, limit int64, window time.Duration) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	// hits are appended in order, drop the ones that left the window
+	hits := s.keys[key].hits
+	for len(hits) > 0 && !hits[0].After(now.Add(-window)) {
+		hits = hits[1:]
+	}
+
+	allowed := int64(len(hits)) < limit
+	if allowed {
+		hits = append(hits, now)
+	}
+
+	if len(hits) == 0 {
+		delete(s.keys, key)
+		return Result{Allowed: allowed, Remaining: limit}, nil
+	}
+	s.keys[key] = keyHits{hits: hits, window: window}
+
+	return Result{
+		Allowed:   allowed,
+		Remaining: limit - int64(len(hits)),
+		Reset:     hits[0].Add(window).Sub(now),
+	}, nil
+}
+
+// Sweep forgets the keys whose hits all left their window, Allow only forgets the ones
+// it is asked about. It is meant to be called periodically.
+func (s *MemoryStore) Sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, k := range s.keys {
+		if !k.hits[len(k.hits)-1].After(now.Add(-k.window)) {
+			delete(s.keys, key)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_SweepDropsKeysPastTheirWindow(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	_, err := store.Allow(ctx, "ip:203.0.113.1", 10, time.Millisecond)
+	require.NoError(t, err)
+	_, err = store.Allow(ctx, "user:jane", 10, time.Hour)
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+	store.Sweep()
+
+	assert.NotContains(t, store.keys, "ip:203.0.113.1")
+	result, err := store.Allow(ctx, "user:jane", 10, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), result.Remaining, "the hits within the window are kept")
+}
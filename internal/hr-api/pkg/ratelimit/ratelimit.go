@@ -0,0 +1,26 @@
+// Package ratelimit counts requests in a sliding window.
+//
+// Every hit is remembered for the length of the window, a hit is refused once limit hits
+// happened within the last window. Unlike fixed windows this never lets a burst of twice
+// the limit through around the boundary of two windows.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Store records the hits of every key, keys are opaque to it
+type Store interface {
+	// Allow records a hit for key unless limit hits already happened within window
+	Allow(ctx context.Context, key string, limit int64, window time.Duration) (Result, error)
+}
+
+type Result struct {
+	// Allowed is false when the hit was refused and not recorded
+	Allowed bool
+	// Remaining is the number of hits still allowed within the window
+	Remaining int64
+	// Reset is how long until the oldest hit leaves the window and frees a slot
+	Reset time.Duration
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const keyPrefix = "ratelimit:"
+
+// allowScript keeps the hits of a key in a sorted set scored by their time in milliseconds,
+// run as a script so concurrent hits from several instances can't all take the last slot
+var allowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+
+local count = redis.call("ZCARD", KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, ARGV[4])
+	redis.call("PEXPIRE", KEYS[1], window)
+	count = count + 1
+	allowed = 1
+end
+
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+return {allowed, count, oldest[2] or ""}
+`)
+
+// RedisStore is a Store shared by every hr-api instance using the same redis
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Allow(ctx context.Context, key string, limit int64, window time.Duration) (Result, error) {
+	now := time.Now().UnixMilli()
+
+	// the member only has to be unique, two hits can happen in the same millisecond
+	member := strconv.FormatInt(now, 10) + ":" + rand.Text()
+
+	res, err := allowScript.Run(ctx, s.client, []string{keyPrefix + key},
+		now, window.Milliseconds(), limit, member).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(res) != 3 {
+		return Result{}, fmt.Errorf("unexpected rate limit script result: %v", res)
+	}
+
+	allowed, _ := res[0].(int64)
+	count, _ := res[1].(int64)
+
+	result := Result{
+		Allowed:   allowed == 1,
+		Remaining: limit - count,
+	}
+
+	if oldest, _ := res[2].(string); oldest != "" {
+		// scores come back formatted as floats
+		oldestMs, err := strconv.ParseFloat(oldest, 64)
+		if err != nil {
+			return Result{}, err
+		}
+		result.Reset = time.Duration(int64(oldestMs)+window.Milliseconds()-now) * time.Millisecond
+	}
+
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in process Store, revocations are lost on restart and not shared
+// between instances so it is only meant for tests and local development
+type MemoryStore struct {
+	mu       sync.Mutex
+	revoked  map[string]time.Time
+	versions map[string]int64
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		revoked:  map[string]time.Time{},
+		versions: map[string]int64{},
+	}
+}
+
+func (s *MemoryStore) Revoke(_ context.Context, jti string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.revoked[jti] = expiresAt
+	return nil
+}
+
+func (s *MemoryStore) IsRevoked(_ context.Context, jti string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.revoked[jti]
+	if !ok {
+		return false, nil
+	}
+
+	// the token expired on its own, no need to remember it anymore
+	if time.Now().After(expiresAt) {
+		delete(s.revoked, jti)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (s *MemoryStore) TokenVersion(_ context.Context, userID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.versions[userID], nil
+}
+
+func (s *MemoryStore) BumpTokenVersion(_ context.Context, userID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.versions[userID]++
+	return s.versions[userID], nil
+}
+
+// Sweep forgets the revocations of the tokens that expired since, IsRevoked only forgets
+// the ones it is asked about. It is meant to be called periodically.
+func (s *MemoryStore) Sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for jti, expiresAt := range s.revoked {
+		if now.After(expiresAt) {
+			delete(s.revoked, jti)
+		}
+	}
+}
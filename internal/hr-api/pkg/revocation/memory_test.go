@@ -0,0 +1,24 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_SweepDropsExpiredRevocations(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	require.NoError(t, store.Revoke(ctx, "expired", time.Now().Add(-time.Second)))
+	require.NoError(t, store.Revoke(ctx, "valid", time.Now().Add(time.Hour)))
+
+	store.Sweep()
+
+	assert.NotContains(t, store.revoked, "expired")
+	revoked, err := store.IsRevoked(ctx, "valid")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
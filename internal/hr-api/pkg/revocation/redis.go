@@ -0,0 +1,55 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	revokedKeyPrefix = "revoked_jti:"
+	versionKeyPrefix = "token_version:"
+)
+
+// RedisStore is a Store shared by every hr-api instance using the same redis
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		// already expired, Protected rejects it anyway
+		return nil
+	}
+
+	return s.client.Set(ctx, revokedKeyPrefix+jti, 1, ttl).Err()
+}
+
+func (s *RedisStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	n, err := s.client.Exists(ctx, revokedKeyPrefix+jti).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func (s *RedisStore) TokenVersion(ctx context.Context, userID string) (int64, error) {
+	version, err := s.client.Get(ctx, versionKeyPrefix+userID).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+
+	return version, err
+}
+
+func (s *RedisStore) BumpTokenVersion(ctx context.Context, userID string) (int64, error) {
+	return s.client.Incr(ctx, versionKeyPrefix+userID).Result()
+}
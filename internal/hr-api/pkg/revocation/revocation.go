@@ -0,0 +1,23 @@
+package revocation
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps track of access tokens that must no longer be accepted even though
+// their signature and expiry are still valid.
+//
+// Single tokens are revoked by their jti claim until they expire, every token of a user
+// is revoked at once by bumping the user's token version, tokens carrying a "ver" claim
+// lower than the current version are rejected.
+type Store interface {
+	// Revoke marks the token with the given jti as revoked, the entry can be dropped after expiresAt
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	// IsRevoked reports whether the token with the given jti was revoked
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	// TokenVersion returns the current token version of the user, 0 when it was never bumped
+	TokenVersion(ctx context.Context, userID string) (int64, error)
+	// BumpTokenVersion revokes every token issued to the user so far and returns the new version
+	BumpTokenVersion(ctx context.Context, userID string) (int64, error)
+}
@@ -0,0 +1,398 @@
+// Package signing signs and verifies the JWTs issued by hr-api with asymmetric keys.
+//
+// Tokens are signed with the newest key of a KeySet and carry its id in the kid header.
+// Rotation adds a new key, the previous ones keep verifying the tokens they signed until
+// they expire and are pruned afterwards. The public keys are published as a JWKS so
+// other services can verify tokens without holding any secret.
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"web-boilerplate/shared/jwks"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmRS256 = "RS256"
+
+	rsaKeyBits = 2048
+	keyFileExt = ".pem"
+	// createdHeader is the PEM header holding the creation time of a key, file times change
+	// when the keys dir is copied or restored from a backup
+	createdHeader = "Created"
+	// minReloadInterval limits how often a token with an unknown kid reloads the keys dir,
+	// so tokens with made up kids can't keep the instance reading it
+	minReloadInterval = 10 * time.Second
+)
+
+type Config struct {
+	// Algorithm of the keys created by rotations, AlgorithmEdDSA or AlgorithmRS256
+	Algorithm string
+	// Dir stores the private keys as <kid>.pem along with their creation time so they survive restarts and are shared
+	// by every instance mounting it, keys only live in memory when empty
+	Dir string
+	// RotationInterval is the age at which the signing key is replaced, 0 disables rotation
+	RotationInterval time.Duration
+	// Retention is how long a replaced key keeps verifying tokens,
+	// it must be at least the lifetime of the tokens it signed
+	Retention time.Duration
+}
+
+type key struct {
+	id        string
+	method    jwt.SigningMethod
+	private   crypto.Signer
+	createdAt time.Time
+}
+
+// KeySet holds the signing keys, it is safe for concurrent use
+type KeySet struct {
+	cfg Config
+
+	mu sync.RWMutex
+	// keys ordered from the oldest to the newest, the last one signs
+	keys []*key
+
+	reloadMu   sync.Mutex
+	reloadedAt time.Time
+}
+
+// NewKeySet loads the keys from cfg.Dir and creates a first key when there are none
+func NewKeySet(cfg Config) (*KeySet, error) {
+	if _, err := signingMethod(cfg.Algorithm); err != nil {
+		return nil, err
+	}
+
+	if cfg.Dir != "" {
+		if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+			return nil, fmt.Errorf("error creating signing keys dir err: %w", err)
+		}
+	}
+
+	s := &KeySet{cfg: cfg}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	if len(s.keys) == 0 {
+		if err := s.Rotate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Sign returns the token for claims signed with the current key
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	s.mu.RLock()
+	k := s.keys[len(s.keys)-1]
+	s.mu.RUnlock()
+
+	token := jwt.NewWithClaims(k.method, claims)
+	token.Header["kid"] = k.id
+
+	return token.SignedString(k.private)
+}
+
+// Keyfunc is a jwt.Keyfunc returning the public key named by the token's kid header,
+// tokens signed with an unknown key or another algorithm than the key's are refused.
+// An unknown kid reloads cfg.Dir first, another instance may have just rotated.
+func (s *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	k := s.find(kid)
+	if k == nil {
+		if err := s.reload(); err != nil {
+			return nil, err
+		}
+		k = s.find(kid)
+	}
+	if k == nil {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return k.private.Public(), nil
+}
+
+func (s *KeySet) find(kid string) *key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, k := range s.keys {
+		if k.id == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+// reload loads cfg.Dir again unless it was reloaded less than minReloadInterval ago
+func (s *KeySet) reload() error {
+	if s.cfg.Dir == "" {
+		return nil
+	}
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	if time.Since(s.reloadedAt) < minReloadInterval {
+		return nil
+	}
+	s.reloadedAt = time.Now()
+
+	return s.load()
+}
+
+// JWKS returns the public keys of every key still verifying tokens
+func (s *KeySet) JWKS() (jwks.Set, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := jwks.Set{Keys: make([]jwks.Key, 0, len(s.keys))}
+	for _, k := range s.keys {
+		jwk, err := jwks.NewKey(k.id, k.method.Alg(), k.private.Public())
+		if err != nil {
+			return jwks.Set{}, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set, nil
+}
+
+// Rotate creates a new key which signs from now on
+func (s *KeySet) Rotate() error {
+	k, err := newKey(s.cfg.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	if s.cfg.Dir != "" {
+		if err := writeKey(s.cfg.Dir, k); err != nil {
+			return err
+		}
+		// other instances may have rotated as well, the directory is the source of truth
+		return s.load()
+	}
+
+	s.mu.Lock()
+	s.keys = append(s.keys, k)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Maintain reloads the keys shared in cfg.Dir, rotates the signing key once it is older
+// than cfg.RotationInterval and prunes the replaced keys past cfg.Retention.
+// Call it periodically.
+func (s *KeySet) Maintain() error {
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	newest := s.keys[len(s.keys)-1]
+	s.mu.RUnlock()
+
+	if s.cfg.RotationInterval > 0 && time.Since(newest.createdAt) >= s.cfg.RotationInterval {
+		if err := s.Rotate(); err != nil {
+			return err
+		}
+	}
+
+	return s.prune()
+}
+
+// prune drops the keys replaced for longer than the retention period
+func (s *KeySet) prune() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	kept := make([]*key, 0, len(s.keys))
+	for i, k := range s.keys {
+		// a key is replaced when the next one is created
+		if i < len(s.keys)-1 && now.Sub(s.keys[i+1].createdAt) > s.cfg.Retention {
+			if s.cfg.Dir != "" {
+				err := os.Remove(filepath.Join(s.cfg.Dir, k.id+keyFileExt))
+				if err != nil && !errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("error removing signing key %s err: %w", k.id, err)
+				}
+			}
+			continue
+		}
+		kept = append(kept, k)
+	}
+	s.keys = kept
+
+	return nil
+}
+
+// load replaces the keys with the ones stored in cfg.Dir
+func (s *KeySet) load() error {
+	if s.cfg.Dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		return fmt.Errorf("error reading signing keys dir err: %w", err)
+	}
+
+	keys := make([]*key, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyFileExt {
+			continue
+		}
+		k, err := readKey(filepath.Join(s.cfg.Dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+	}
+
+	slices.SortFunc(keys, func(a, b *key) int {
+		return a.createdAt.Compare(b.createdAt)
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(keys) > 0 || len(s.keys) == 0 {
+		s.keys = keys
+	}
+
+	return nil
+}
+
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q, use %s or %s", algorithm, AlgorithmEdDSA, AlgorithmRS256)
+	}
+}
+
+func newKey(algorithm string) (*key, error) {
+	method, err := signingMethod(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	var private crypto.Signer
+	switch algorithm {
+	case AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	case AlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error generating signing key err: %w", err)
+	}
+
+	return &key{
+		id:        uuid.NewString(),
+		method:    method,
+		private:   private,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// writeKey stores the key as a PKCS #8 PEM file, renamed into place so other instances
+// never read a partial file
+func writeKey(dir string, k *key) error {
+	der, err := x509.MarshalPKCS8PrivateKey(k.private)
+	if err != nil {
+		return fmt.Errorf("error encoding signing key err: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating signing key file err: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	block := &pem.Block{
+		Type:    "PRIVATE KEY",
+		Headers: map[string]string{createdHeader: k.createdAt.UTC().Format(time.RFC3339Nano)},
+		Bytes:   der,
+	}
+	if err := pem.Encode(tmp, block); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing signing key err: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing signing key err: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, k.id+keyFileExt)); err != nil {
+		return fmt.Errorf("error storing signing key err: %w", err)
+	}
+
+	return nil
+}
+
+// readKey loads a key written by writeKey, its id is the file name
+func readKey(path string) (*key, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading signing key err: %w", err)
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("error decoding signing key %s: no PRIVATE KEY block", path)
+	}
+
+	created, ok := block.Headers[createdHeader]
+	if !ok {
+		return nil, fmt.Errorf("error decoding signing key %s: no %s header", path, createdHeader)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, created)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing creation time of signing key %s err: %w", path, err)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing signing key %s err: %w", path, err)
+	}
+
+	var method jwt.SigningMethod
+	switch parsed.(type) {
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	case *rsa.PrivateKey:
+		method = jwt.SigningMethodRS256
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T in %s", parsed, path)
+	}
+
+	return &key{
+		id:        strings.TrimSuffix(filepath.Base(path), keyFileExt),
+		method:    method,
+		private:   parsed.(crypto.Signer),
+		createdAt: createdAt,
+	}, nil
+}
@@ -0,0 +1,237 @@
+package signing
+
+import (
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(t *testing.T, s *KeySet) string {
+	token, err := s.Sign(jwt.MapClaims{"sub": "test"})
+	require.NoError(t, err)
+	return token
+}
+
+func verify(s *KeySet, token string) error {
+	_, err := jwt.Parse(token, s.Keyfunc)
+	return err
+}
+
+func keyIDs(s *KeySet) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.keys))
+	for _, k := range s.keys {
+		ids = append(ids, k.id)
+	}
+	return ids
+}
+
+func keyFiles(t *testing.T, dir string) []string {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+keyFileExt))
+	require.NoError(t, err)
+	return matches
+}
+
+// age moves the creation time of every key back by d, the newest staying the newest
+func age(s *KeySet, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range s.keys {
+		k.createdAt = k.createdAt.Add(-d)
+	}
+}
+
+func TestNewKeySet_UnsupportedAlgorithm(t *testing.T) {
+	_, err := NewKeySet(Config{Algorithm: "HS256"})
+	assert.ErrorContains(t, err, "unsupported signing algorithm")
+}
+
+func TestRotate_PreviousKeyKeepsVerifying(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmEdDSA, AlgorithmRS256} {
+		t.Run(algorithm, func(t *testing.T) {
+			s, err := NewKeySet(Config{Algorithm: algorithm, Retention: time.Hour})
+			require.NoError(t, err)
+			before := sign(t, s)
+
+			require.NoError(t, s.Rotate())
+			after := sign(t, s)
+
+			ids := keyIDs(s)
+			require.Len(t, ids, 2)
+			parsed, _, err := jwt.NewParser().ParseUnverified(after, jwt.MapClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, ids[1], parsed.Header["kid"], "the newest key signs")
+
+			assert.NoError(t, verify(s, before))
+			assert.NoError(t, verify(s, after))
+
+			set, err := s.JWKS()
+			require.NoError(t, err)
+			assert.Len(t, set.Keys, 2)
+		})
+	}
+}
+
+func TestKeyfunc_RefusesUnknownKeyAndOtherAlgorithm(t *testing.T) {
+	s, err := NewKeySet(Config{Algorithm: AlgorithmEdDSA})
+	require.NoError(t, err)
+	other, err := NewKeySet(Config{Algorithm: AlgorithmRS256})
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, verify(s, sign(t, other)), "unknown key id")
+
+	// a token claiming the kid of s but signed with another algorithm
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{})
+	token.Header["kid"] = keyIDs(s)[0]
+	signed, err := token.SignedString(other.keys[0].private)
+	require.NoError(t, err)
+	assert.ErrorContains(t, verify(s, signed), "unexpected signing method")
+}
+
+func TestKeyfunc_ReloadsDirOnUnknownKey(t *testing.T) {
+	cfg := Config{Algorithm: AlgorithmEdDSA, Dir: t.TempDir(), Retention: time.Hour}
+	a, err := NewKeySet(cfg)
+	require.NoError(t, err)
+	b, err := NewKeySet(cfg)
+	require.NoError(t, err)
+
+	// a rotated since b last maintained its keys
+	require.NoError(t, a.Rotate())
+	assert.NoError(t, verify(b, sign(t, a)))
+	assert.Equal(t, keyIDs(a), keyIDs(b))
+
+	// the dir isn't read again before minReloadInterval
+	require.NoError(t, a.Rotate())
+	assert.ErrorContains(t, verify(b, sign(t, a)), "unknown key id")
+
+	b.reloadMu.Lock()
+	b.reloadedAt = b.reloadedAt.Add(-minReloadInterval)
+	b.reloadMu.Unlock()
+	assert.NoError(t, verify(b, sign(t, a)))
+}
+
+func TestMaintain_RotatesAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewKeySet(Config{
+		Algorithm:        AlgorithmEdDSA,
+		Dir:              dir,
+		RotationInterval: time.Hour,
+		Retention:        time.Hour,
+	})
+	require.NoError(t, err)
+	first := keyIDs(s)
+	token := sign(t, s)
+
+	// a young key is kept
+	require.NoError(t, s.Maintain())
+	assert.Equal(t, first, keyIDs(s))
+
+	// an old one is replaced but still verifies its tokens during the retention
+	rewriteCreatedAt(t, dir, first[0], time.Now().Add(-2*time.Hour))
+	require.NoError(t, s.Maintain())
+	ids := keyIDs(s)
+	require.Len(t, ids, 2)
+	assert.Equal(t, first[0], ids[0])
+	assert.NoError(t, verify(s, token))
+
+	// once the retention passed since it was replaced, the old key and its file are gone
+	rewriteCreatedAt(t, dir, ids[1], time.Now().Add(-90*time.Minute))
+	rewriteCreatedAt(t, dir, ids[0], time.Now().Add(-3*time.Hour))
+	require.NoError(t, s.Maintain())
+	// the replacement is past the rotation interval too and was rotated in turn
+	ids = keyIDs(s)
+	assert.NotContains(t, ids, first[0])
+	assert.Len(t, ids, 2)
+	assert.Len(t, keyFiles(t, dir), 2)
+	assert.Error(t, verify(s, token))
+}
+
+func TestPrune_InMemory(t *testing.T) {
+	s, err := NewKeySet(Config{Algorithm: AlgorithmEdDSA, Retention: time.Minute})
+	require.NoError(t, err)
+	require.NoError(t, s.Rotate())
+	require.NoError(t, s.Rotate())
+	ids := keyIDs(s)
+
+	age(s, 2*time.Minute)
+	require.NoError(t, s.prune())
+
+	// the signing key is never pruned, however old
+	assert.Equal(t, ids[2:], keyIDs(s))
+}
+
+func TestLoad_SharesKeysBetweenInstances(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{Algorithm: AlgorithmEdDSA, Dir: dir, Retention: time.Hour}
+
+	a, err := NewKeySet(cfg)
+	require.NoError(t, err)
+	b, err := NewKeySet(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, keyIDs(a), keyIDs(b), "the second instance loads the key of the first")
+
+	require.NoError(t, a.Rotate())
+	require.NoError(t, b.Maintain())
+	assert.Equal(t, keyIDs(a), keyIDs(b))
+	assert.NoError(t, verify(b, sign(t, a)))
+
+	// other files of the dir are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("keys"), 0o600))
+	require.NoError(t, b.load())
+	assert.Len(t, keyIDs(b), 2)
+}
+
+func TestLoad_OrdersByStoredCreationTime(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewKeySet(Config{Algorithm: AlgorithmEdDSA, Dir: dir})
+	require.NoError(t, err)
+	require.NoError(t, s.Rotate())
+	ids := keyIDs(s)
+
+	// file times are those of a copy or a restore, not of the keys
+	older := time.Now().Add(-24 * time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, ids[1]+keyFileExt), older, older))
+
+	require.NoError(t, s.load())
+	assert.Equal(t, ids, keyIDs(s), "the newest key still signs")
+}
+
+func TestLoad_RequiresCreationTime(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewKeySet(Config{Algorithm: AlgorithmEdDSA, Dir: dir})
+	require.NoError(t, err)
+	id := keyIDs(s)[0]
+
+	// a key file without its creation time, file times can't stand in for it
+	path := filepath.Join(dir, id+keyFileExt)
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	block.Headers = nil
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+
+	_, err = readKey(path)
+	assert.ErrorContains(t, err, "no Created header")
+	assert.Error(t, s.load())
+	_, err = NewKeySet(Config{Algorithm: AlgorithmEdDSA, Dir: dir})
+	assert.Error(t, err)
+}
+
+// rewriteCreatedAt stores createdAt as the creation time of the key id of dir
+func rewriteCreatedAt(t *testing.T, dir, id string, createdAt time.Time) {
+	path := filepath.Join(dir, id+keyFileExt)
+	k, err := readKey(path)
+	require.NoError(t, err)
+
+	k.createdAt = createdAt
+	require.NoError(t, writeKey(dir, k))
+}
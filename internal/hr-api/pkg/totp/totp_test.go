@@ -0,0 +1,95 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 seed of RFC 6238 Appendix B, "12345678901234567890" base32 encoded
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// TestGenerateCode_RFC6238 checks the SHA1 test vectors of RFC 6238 Appendix B,
+// codes being 6 digits they are the last 6 of the 8 digits ones of the RFC
+func TestGenerateCode_RFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		code, err := GenerateCode(rfcSecret, time.Unix(tt.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, code, "time %d", tt.unix)
+	}
+}
+
+func TestGenerateCode_LowercaseSecret(t *testing.T) {
+	code, err := GenerateCode(strings.ToLower(rfcSecret), time.Unix(59, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+}
+
+func TestGenerateCode_InvalidSecret(t *testing.T) {
+	_, err := GenerateCode("not base32!", time.Now())
+	assert.Error(t, err)
+}
+
+func TestValidate_AcceptsSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		ok   bool
+	}{
+		{"previous period", now.Add(-Period), true},
+		{"current period", now, true},
+		{"next period", now.Add(Period), true},
+		{"two periods ago", now.Add(-2 * Period), false},
+		{"in two periods", now.Add(2 * Period), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateCode(rfcSecret, tt.at)
+			require.NoError(t, err)
+
+			step, ok := Validate(rfcSecret, code, now)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, Step(tt.at), step)
+				assert.InDelta(t, current, step, Skew)
+			}
+		})
+	}
+}
+
+func TestValidate_RefusesMalformedCodes(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	for _, code := range []string{"", "28708", "2870820", "abcdef"} {
+		_, ok := Validate(rfcSecret, code, now)
+		assert.False(t, ok, "code %q", code)
+	}
+
+	// surrounding spaces are what users paste along with it
+	_, ok := Validate(rfcSecret, " 287082 ", now)
+	assert.True(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("HR App", "jane", rfcSecret)
+	assert.Equal(t, "otpauth://totp/HR%20App:jane?algorithm=SHA1&digits=6&issuer=HR%20App&period=30&secret="+rfcSecret, uri)
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// MinPasswordLength is the shortest password accepted when setting a new one
+	MinPasswordLength = 8
+	// MaxPasswordLength is bcrypt's limit, it ignores what comes after 72 bytes
+	MaxPasswordLength = 72
+)
+
+func init() {
+	Register("strong_password", strongPassword, func(field, _ string) string {
+		return fmt.Sprintf("%s must be %d to %d bytes long and mix at least two of lowercase letters, uppercase letters, digits and symbols",
+			field, MinPasswordLength, MaxPasswordLength)
+	})
+	Register("department", department, func(field, _ string) string {
+		return field + " must only contain letters, digits, spaces and & , . - / ( )"
+	})
+}
+
+// strongPassword accepts passwords within bcrypt's limits using at least two classes of characters,
+// so neither a lone dictionary word nor a row of digits passes
+func strongPassword(_ context.Context, fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+	if utf8.RuneCountInString(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return false
+	}
+
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+
+	return lower+upper+digit+symbol >= 2
+}
+
+// department accepts department names like "Research & Development" or "Sales (EMEA)"
+func department(_ context.Context, fl validator.FieldLevel) bool {
+	for _, r := range fl.Field().String() {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			continue
+		}
+		switch r {
+		case ' ', '&', ',', '.', '-', '/', '(', ')':
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrongPassword(t *testing.T) {
+	type params struct {
+		Password string `json:"password" validate:"strong_password"`
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		valid    bool
+	}{
+		{"lower and upper", "CorrectHorse", true},
+		{"lower and digits", "horse1234", true},
+		{"digits and symbols", "1234-5678", true},
+		{"shortest", "abcd123!", true},
+		{"longest", strings.Repeat("a1", MaxPasswordLength/2), true},
+		{"multibyte letters count as characters", "pässwörd1", true},
+		{"empty", "", false},
+		{"too short", "abc123!", false},
+		{"too long", strings.Repeat("a1", MaxPasswordLength/2) + "b", false},
+		// bcrypt's limit is in bytes, 37 runes of two bytes
+		{"too long in bytes", strings.Repeat("é", 36) + "1", false},
+		{"lowercase only", "correcthorse", false},
+		{"uppercase only", "CORRECTHORSE", false},
+		{"digits only", "1234567890", false},
+		{"symbols only", "!@#$%^&*()", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(context.Background(), params{Password: tt.password})
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, Errors{
+				"password": "password must be 8 to 72 bytes long and mix at least two of lowercase letters, uppercase letters, digits and symbols",
+			}, err)
+		})
+	}
+}
+
+func TestDepartment(t *testing.T) {
+	type params struct {
+		Department string `json:"department" validate:"department"`
+	}
+
+	tests := []struct {
+		name       string
+		department string
+		valid      bool
+	}{
+		{"single word", "Engineering", true},
+		{"ampersand", "Research & Development", true},
+		{"parentheses", "Sales (EMEA)", true},
+		{"punctuation", "Ops, Support/Help-desk. 2", true},
+		{"letters of other scripts", "Ventes Île-de-France", true},
+		{"empty is left to required", "", true},
+		{"angle brackets", "<script>", false},
+		{"quote", "Sales'", false},
+		{"semicolon", "HR; DROP", false},
+		{"underscore", "human_resources", false},
+		{"newline", "Sales\nEMEA", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(context.Background(), params{Department: tt.department})
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, Errors{
+				"department": "department must only contain letters, digits, spaces and & , . - / ( )",
+			}, err)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Package validation checks request structs against their validate struct tags.
+//
+// The tags are those of github.com/go-playground/validator, plus the custom rules registered
+// with Register. Field errors are named after the json tag of the field and come with a message
+// meant for the client:
+//
+//	type EmployeeParams struct {
+//		Email      string `json:"email" validate:"required,email,max=254"`
+//		Department string `json:"department" validate:"required,department"`
+//	}
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Errors maps the json names of the invalid fields to the reason they were refused
+type Errors map[string]string
+
+func (e Errors) Error() string {
+	reasons := make([]string, 0, len(e))
+	for _, reason := range e {
+		reasons = append(reasons, reason)
+	}
+	return "invalid fields: " + strings.Join(reasons, ", ")
+}
+
+// Rule reports whether a field passes a custom check, ctx is the one given to Struct
+// so rules can look things up for the request
+type Rule func(ctx context.Context, fl validator.FieldLevel) bool
+
+// Message returns the reason given for a field refused by a rule, param is the rule's parameter
+type Message func(field, param string) string
+
+var validate = newValidate()
+
+// messages of the built-in rules the request types use, others get a generic message
+var messages = map[string]Message{
+	"required": func(field, _ string) string {
+		return field + " is required"
+	},
+	"email": func(field, _ string) string {
+		return field + " must be a valid email address"
+	},
+	"uuid": func(field, _ string) string {
+		return field + " must be a valid UUID"
+	},
+	"min": func(field, param string) string {
+		return fmt.Sprintf("%s must be at least %s characters", field, param)
+	},
+	"max": func(field, param string) string {
+		return fmt.Sprintf("%s must be at most %s characters", field, param)
+	},
+	"len": func(field, param string) string {
+		return fmt.Sprintf("%s must be exactly %s characters", field, param)
+	},
+	"oneof": func(field, param string) string {
+		return field + " must be one of " + strings.Join(strings.Fields(param), ", ")
+	},
+}
+
+func newValidate() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// Register adds a custom rule usable as the tag validate tag, it must be called before
+// the first validation, typically from an init function
+func Register(tag string, rule Rule, message Message) {
+	err := validate.RegisterValidationCtx(tag, func(ctx context.Context, fl validator.FieldLevel) bool {
+		return rule(ctx, fl)
+	})
+	if err != nil {
+		panic(fmt.Sprintf("validation: failed to register rule %s: %v", tag, err))
+	}
+	messages[tag] = message
+}
+
+// Struct validates s, a struct or a pointer to one. It returns Errors when fields are invalid,
+// any other error means the tags themselves are wrong.
+func Struct(ctx context.Context, s any) error {
+	err := validate.StructCtx(ctx, s)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	errs := Errors{}
+	for _, fieldErr := range fieldErrs {
+		name := fieldName(fieldErr)
+		// the first failing rule of a field is the one worth telling
+		if _, ok := errs[name]; ok {
+			continue
+		}
+		errs[name] = message(fieldErr.Tag(), name, fieldErr.Param())
+	}
+
+	return errs
+}
+
+// fieldName returns the path of the field without the name of the validated struct,
+// nested fields are joined with dots
+func fieldName(fieldErr validator.FieldError) string {
+	_, name, ok := strings.Cut(fieldErr.Namespace(), ".")
+	if !ok {
+		return fieldErr.Field()
+	}
+	return name
+}
+
+func message(tag, field, param string) string {
+	if msg, ok := messages[tag]; ok {
+		return msg(field, param)
+	}
+	return field + " is invalid"
+}
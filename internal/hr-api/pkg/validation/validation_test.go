@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAddress struct {
+	PostalCode string `json:"postal_code" validate:"required,len=5"`
+}
+
+type testParams struct {
+	FirstName string       `json:"first_name,omitempty" validate:"required,max=5"`
+	Email     string       `json:"email" validate:"required,email"`
+	Role      string       `json:"role" validate:"oneof=admin employee"`
+	Untagged  string       `validate:"required"`
+	Address   testAddress  `json:"address"`
+	Previous  *testAddress `json:"previous" validate:"omitempty"`
+}
+
+func validParams() testParams {
+	return testParams{
+		FirstName: "Ada",
+		Email:     "ada@example.com",
+		Role:      "admin",
+		Untagged:  "set",
+		Address:   testAddress{PostalCode: "75001"},
+	}
+}
+
+func TestStruct_Valid(t *testing.T) {
+	params := validParams()
+
+	assert.NoError(t, Struct(context.Background(), params))
+	assert.NoError(t, Struct(context.Background(), &params))
+}
+
+func TestStruct_FieldNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *testParams)
+		want   Errors
+	}{
+		{
+			name:   "json name without its options",
+			modify: func(p *testParams) { p.FirstName = "" },
+			want:   Errors{"first_name": "first_name is required"},
+		},
+		{
+			name:   "go name without json tag",
+			modify: func(p *testParams) { p.Untagged = "" },
+			want:   Errors{"Untagged": "Untagged is required"},
+		},
+		{
+			name:   "nested fields joined with dots",
+			modify: func(p *testParams) { p.Address.PostalCode = "123" },
+			want:   Errors{"address.postal_code": "address.postal_code must be exactly 5 characters"},
+		},
+		{
+			name:   "nested through a pointer",
+			modify: func(p *testParams) { p.Previous = &testAddress{} },
+			want:   Errors{"previous.postal_code": "previous.postal_code is required"},
+		},
+		{
+			name: "every invalid field",
+			modify: func(p *testParams) {
+				p.Email = "ada"
+				p.Role = "root"
+			},
+			want: Errors{
+				"email": "email must be a valid email address",
+				"role":  "role must be one of admin, employee",
+			},
+		},
+		{
+			name:   "rule parameter in the message",
+			modify: func(p *testParams) { p.FirstName = "Augusta" },
+			want:   Errors{"first_name": "first_name must be at most 5 characters"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := validParams()
+			tt.modify(&params)
+
+			assert.Equal(t, tt.want, Struct(context.Background(), params))
+		})
+	}
+}
+
+func TestStruct_GenericMessage(t *testing.T) {
+	params := struct {
+		Website string `json:"website" validate:"url"`
+	}{Website: "not a url"}
+
+	assert.Equal(t, Errors{"website": "website is invalid"}, Struct(context.Background(), params))
+}
+
+func TestStruct_InvalidTarget(t *testing.T) {
+	err := Struct(context.Background(), "not a struct")
+
+	require.Error(t, err)
+	var errs Errors
+	assert.NotErrorAs(t, err, &errs)
+}
@@ -11,6 +11,31 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countEmployees = `-- name: CountEmployees :one
+SELECT count(*) FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+`
+
+type CountEmployeesParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+}
+
+func (q *Queries) CountEmployees(ctx context.Context, arg CountEmployeesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countEmployees, arg.Department, arg.EmailDomain, arg.Search)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createEmployee = `-- name: CreateEmployee :one
 INSERT INTO employees (id, first_name, last_name, email, department)
 VALUES ($1, $2, $3, $4, $5)
@@ -44,14 +69,29 @@ func (q *Queries) CreateEmployee(ctx context.Context, arg CreateEmployeeParams)
 	return i, err
 }
 
-const deleteEmployee = `-- name: DeleteEmployee :exec
+const deleteEmployee = `-- name: DeleteEmployee :execrows
 DELETE FROM employees
 WHERE id = $1
 `
 
-func (q *Queries) DeleteEmployee(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteEmployee, id)
-	return err
+func (q *Queries) DeleteEmployee(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteEmployee, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const estimateEmployeesCount = `-- name: EstimateEmployeesCount :one
+SELECT reltuples::bigint AS estimate FROM pg_catalog.pg_class
+WHERE oid = 'employees'::regclass
+`
+
+func (q *Queries) EstimateEmployeesCount(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, estimateEmployeesCount)
+	var estimate int64
+	err := row.Scan(&estimate)
+	return estimate, err
 }
 
 const getEmployee = `-- name: GetEmployee :one
@@ -72,6 +112,31 @@ func (q *Queries) GetEmployee(ctx context.Context, id pgtype.UUID) (Employee, er
 	return i, err
 }
 
+const listDepartments = `-- name: ListDepartments :many
+SELECT DISTINCT department FROM employees
+ORDER BY department
+`
+
+func (q *Queries) ListDepartments(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, listDepartments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var department string
+		if err := rows.Scan(&department); err != nil {
+			return nil, err
+		}
+		items = append(items, department)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEmployees = `-- name: ListEmployees :many
 SELECT id, first_name, last_name, email, department FROM employees
 ORDER BY last_name, first_name
@@ -103,6 +168,576 @@ func (q *Queries) ListEmployees(ctx context.Context) ([]Employee, error) {
 	return items, nil
 }
 
+const listEmployeesByDepartment = `-- name: ListEmployeesByDepartment :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (department, id) > ($4::text, $5::uuid))
+ORDER BY department, id
+LIMIT $6
+`
+
+type ListEmployeesByDepartmentParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByDepartment(ctx context.Context, arg ListEmployeesByDepartmentParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByDepartment,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByDepartmentDesc = `-- name: ListEmployeesByDepartmentDesc :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (department, id) < ($4::text, $5::uuid))
+ORDER BY department DESC, id DESC
+LIMIT $6
+`
+
+type ListEmployeesByDepartmentDescParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByDepartmentDesc(ctx context.Context, arg ListEmployeesByDepartmentDescParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByDepartmentDesc,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByEmail = `-- name: ListEmployeesByEmail :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (email, id) > ($4::text, $5::uuid))
+ORDER BY email, id
+LIMIT $6
+`
+
+type ListEmployeesByEmailParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByEmail(ctx context.Context, arg ListEmployeesByEmailParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByEmail,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByEmailDesc = `-- name: ListEmployeesByEmailDesc :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (email, id) < ($4::text, $5::uuid))
+ORDER BY email DESC, id DESC
+LIMIT $6
+`
+
+type ListEmployeesByEmailDescParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByEmailDesc(ctx context.Context, arg ListEmployeesByEmailDescParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByEmailDesc,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByFirstName = `-- name: ListEmployeesByFirstName :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (first_name, id) > ($4::text, $5::uuid))
+ORDER BY first_name, id
+LIMIT $6
+`
+
+type ListEmployeesByFirstNameParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByFirstName(ctx context.Context, arg ListEmployeesByFirstNameParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByFirstName,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByFirstNameDesc = `-- name: ListEmployeesByFirstNameDesc :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (first_name, id) < ($4::text, $5::uuid))
+ORDER BY first_name DESC, id DESC
+LIMIT $6
+`
+
+type ListEmployeesByFirstNameDescParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByFirstNameDesc(ctx context.Context, arg ListEmployeesByFirstNameDescParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByFirstNameDesc,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByLastName = `-- name: ListEmployeesByLastName :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (last_name, id) > ($4::text, $5::uuid))
+ORDER BY last_name, id
+LIMIT $6
+`
+
+type ListEmployeesByLastNameParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByLastName(ctx context.Context, arg ListEmployeesByLastNameParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByLastName,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByLastNameDesc = `-- name: ListEmployeesByLastNameDesc :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (last_name, id) < ($4::text, $5::uuid))
+ORDER BY last_name DESC, id DESC
+LIMIT $6
+`
+
+type ListEmployeesByLastNameDescParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByLastNameDesc(ctx context.Context, arg ListEmployeesByLastNameDescParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByLastNameDesc,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByName = `-- name: ListEmployeesByName :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (last_name || ' ' || first_name, id) > ($4::text, $5::uuid))
+ORDER BY last_name || ' ' || first_name, id
+LIMIT $6
+`
+
+type ListEmployeesByNameParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByName(ctx context.Context, arg ListEmployeesByNameParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByName,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByNameDesc = `-- name: ListEmployeesByNameDesc :many
+SELECT id, first_name, last_name, email, department FROM employees
+WHERE ($1::text IS NULL OR lower(department) = lower($1::text))
+  AND ($2::text IS NULL OR split_part(lower(email), '@', 2) = lower($2::text))
+  AND (
+    $3::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || $3::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || $3::text || '%'
+    OR email ILIKE '%' || $3::text || '%'
+  )
+  AND ($4::text IS NULL OR (last_name || ' ' || first_name, id) < ($4::text, $5::uuid))
+ORDER BY last_name || ' ' || first_name DESC, id DESC
+LIMIT $6
+`
+
+type ListEmployeesByNameDescParams struct {
+	Department  pgtype.Text `json:"department"`
+	EmailDomain pgtype.Text `json:"email_domain"`
+	Search      pgtype.Text `json:"search"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListEmployeesByNameDesc(ctx context.Context, arg ListEmployeesByNameDescParams) ([]Employee, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByNameDesc,
+		arg.Department,
+		arg.EmailDomain,
+		arg.Search,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Employee
+	for rows.Next() {
+		var i Employee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateEmployee = `-- name: UpdateEmployee :one
 UPDATE employees
 SET first_name = $2, last_name = $3, email = $4, department = $5
@@ -0,0 +1,38 @@
+CREATE TABLE roles (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE permissions (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE role_permissions (
+    role TEXT NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+    permission TEXT NOT NULL REFERENCES permissions (name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full access to every resource'),
+    ('hr_manager', 'Manages employees and can view users'),
+    ('employee', 'Read only access to the employee directory');
+
+INSERT INTO permissions (name, description) VALUES
+    ('employees:read', 'List and view employees'),
+    ('employees:write', 'Create, update and delete employees'),
+    ('users:read', 'List and view users'),
+    ('users:write', 'Manage users and their roles');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'employees:read'),
+    ('admin', 'employees:write'),
+    ('admin', 'users:read'),
+    ('admin', 'users:write'),
+    ('hr_manager', 'employees:read'),
+    ('hr_manager', 'employees:write'),
+    ('hr_manager', 'users:read'),
+    ('employee', 'employees:read');
+
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'employee' REFERENCES roles (name);
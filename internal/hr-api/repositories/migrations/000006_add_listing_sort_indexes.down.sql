@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS users_username_id_idx;
+DROP INDEX IF EXISTS users_email_id_idx;
+DROP INDEX IF EXISTS users_name_id_idx;
+
+DROP INDEX IF EXISTS employees_department_id_idx;
+DROP INDEX IF EXISTS employees_email_id_idx;
+DROP INDEX IF EXISTS employees_last_name_id_idx;
+DROP INDEX IF EXISTS employees_first_name_id_idx;
+DROP INDEX IF EXISTS employees_name_id_idx;
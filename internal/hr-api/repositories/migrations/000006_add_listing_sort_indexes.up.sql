@@ -0,0 +1,9 @@
+CREATE INDEX employees_name_id_idx ON employees ((last_name || ' ' || first_name), id);
+CREATE INDEX employees_first_name_id_idx ON employees (first_name, id);
+CREATE INDEX employees_last_name_id_idx ON employees (last_name, id);
+CREATE INDEX employees_email_id_idx ON employees (email, id);
+CREATE INDEX employees_department_id_idx ON employees (department, id);
+
+CREATE INDEX users_name_id_idx ON users (name, id);
+CREATE INDEX users_email_id_idx ON users (email, id);
+CREATE INDEX users_username_id_idx ON users (username, id);
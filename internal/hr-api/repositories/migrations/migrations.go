@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations of the hr-api database so the binaries
+// can apply them without the files next to them.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -305,6 +305,138 @@ func (_m *MockQuerier) EXPECT() *MockQuerier_Expecter {
 	return &MockQuerier_Expecter{mock: &_m.Mock}
 }
 
+// CountEmployees provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CountEmployees(ctx context.Context, arg CountEmployeesParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountEmployees")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, CountEmployeesParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, CountEmployeesParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, CountEmployeesParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CountEmployees_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountEmployees'
+type MockQuerier_CountEmployees_Call struct {
+	*mock.Call
+}
+
+// CountEmployees is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg CountEmployeesParams
+func (_e *MockQuerier_Expecter) CountEmployees(ctx any, arg any) *MockQuerier_CountEmployees_Call {
+	return &MockQuerier_CountEmployees_Call{Call: _e.mock.On("CountEmployees", ctx, arg)}
+}
+
+func (_c *MockQuerier_CountEmployees_Call) Run(run func(ctx context.Context, arg CountEmployeesParams)) *MockQuerier_CountEmployees_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 CountEmployeesParams
+		if args[1] != nil {
+			arg1 = args[1].(CountEmployeesParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountEmployees_Call) Return(n int64, err error) *MockQuerier_CountEmployees_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_CountEmployees_Call) RunAndReturn(run func(ctx context.Context, arg CountEmployeesParams) (int64, error)) *MockQuerier_CountEmployees_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUsers provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CountUsers(ctx context.Context, emailDomain pgtype.Text) (int64, error) {
+	ret := _mock.Called(ctx, emailDomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.Text) (int64, error)); ok {
+		return returnFunc(ctx, emailDomain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.Text) int64); ok {
+		r0 = returnFunc(ctx, emailDomain)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.Text) error); ok {
+		r1 = returnFunc(ctx, emailDomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CountUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUsers'
+type MockQuerier_CountUsers_Call struct {
+	*mock.Call
+}
+
+// CountUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - emailDomain pgtype.Text
+func (_e *MockQuerier_Expecter) CountUsers(ctx any, emailDomain any) *MockQuerier_CountUsers_Call {
+	return &MockQuerier_CountUsers_Call{Call: _e.mock.On("CountUsers", ctx, emailDomain)}
+}
+
+func (_c *MockQuerier_CountUsers_Call) Run(run func(ctx context.Context, emailDomain pgtype.Text)) *MockQuerier_CountUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.Text
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.Text)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountUsers_Call) Return(n int64, err error) *MockQuerier_CountUsers_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_CountUsers_Call) RunAndReturn(run func(ctx context.Context, emailDomain pgtype.Text) (int64, error)) *MockQuerier_CountUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateEmployee provides a mock function for the type MockQuerier
 func (_mock *MockQuerier) CreateEmployee(ctx context.Context, arg CreateEmployeeParams) (Employee, error) {
 	ret := _mock.Called(ctx, arg)
@@ -371,6 +503,195 @@ func (_c *MockQuerier_CreateEmployee_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// CreatePasswordResetToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordResetToken")
+	}
+
+	var r0 PasswordResetToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, CreatePasswordResetTokenParams) (PasswordResetToken, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, CreatePasswordResetTokenParams) PasswordResetToken); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(PasswordResetToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, CreatePasswordResetTokenParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreatePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePasswordResetToken'
+type MockQuerier_CreatePasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// CreatePasswordResetToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg CreatePasswordResetTokenParams
+func (_e *MockQuerier_Expecter) CreatePasswordResetToken(ctx any, arg any) *MockQuerier_CreatePasswordResetToken_Call {
+	return &MockQuerier_CreatePasswordResetToken_Call{Call: _e.mock.On("CreatePasswordResetToken", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreatePasswordResetToken_Call) Run(run func(ctx context.Context, arg CreatePasswordResetTokenParams)) *MockQuerier_CreatePasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 CreatePasswordResetTokenParams
+		if args[1] != nil {
+			arg1 = args[1].(CreatePasswordResetTokenParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreatePasswordResetToken_Call) Return(passwordResetToken PasswordResetToken, err error) *MockQuerier_CreatePasswordResetToken_Call {
+	_c.Call.Return(passwordResetToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreatePasswordResetToken_Call) RunAndReturn(run func(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error)) *MockQuerier_CreatePasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRecoveryCode provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecoveryCode")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, CreateRecoveryCodeParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_CreateRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRecoveryCode'
+type MockQuerier_CreateRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// CreateRecoveryCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg CreateRecoveryCodeParams
+func (_e *MockQuerier_Expecter) CreateRecoveryCode(ctx any, arg any) *MockQuerier_CreateRecoveryCode_Call {
+	return &MockQuerier_CreateRecoveryCode_Call{Call: _e.mock.On("CreateRecoveryCode", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateRecoveryCode_Call) Run(run func(ctx context.Context, arg CreateRecoveryCodeParams)) *MockQuerier_CreateRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 CreateRecoveryCodeParams
+		if args[1] != nil {
+			arg1 = args[1].(CreateRecoveryCodeParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateRecoveryCode_Call) Return(err error) *MockQuerier_CreateRecoveryCode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateRecoveryCode_Call) RunAndReturn(run func(ctx context.Context, arg CreateRecoveryCodeParams) error) *MockQuerier_CreateRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRefreshToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 RefreshToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, CreateRefreshTokenParams) (RefreshToken, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, CreateRefreshTokenParams) RefreshToken); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(RefreshToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, CreateRefreshTokenParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRefreshToken'
+type MockQuerier_CreateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// CreateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg CreateRefreshTokenParams
+func (_e *MockQuerier_Expecter) CreateRefreshToken(ctx any, arg any) *MockQuerier_CreateRefreshToken_Call {
+	return &MockQuerier_CreateRefreshToken_Call{Call: _e.mock.On("CreateRefreshToken", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateRefreshToken_Call) Run(run func(ctx context.Context, arg CreateRefreshTokenParams)) *MockQuerier_CreateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 CreateRefreshTokenParams
+		if args[1] != nil {
+			arg1 = args[1].(CreateRefreshTokenParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateRefreshToken_Call) Return(refreshToken RefreshToken, err error) *MockQuerier_CreateRefreshToken_Call {
+	_c.Call.Return(refreshToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateRefreshToken_Call) RunAndReturn(run func(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)) *MockQuerier_CreateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function for the type MockQuerier
 func (_mock *MockQuerier) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
 	ret := _mock.Called(ctx, arg)
@@ -438,20 +759,29 @@ func (_c *MockQuerier_CreateUser_Call) RunAndReturn(run func(ctx context.Context
 }
 
 // DeleteEmployee provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) DeleteEmployee(ctx context.Context, id pgtype.UUID) error {
+func (_mock *MockQuerier) DeleteEmployee(ctx context.Context, id pgtype.UUID) (int64, error) {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteEmployee")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (int64, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) int64); ok {
 		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockQuerier_DeleteEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEmployee'
@@ -484,12 +814,12 @@ func (_c *MockQuerier_DeleteEmployee_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockQuerier_DeleteEmployee_Call) Return(err error) *MockQuerier_DeleteEmployee_Call {
-	_c.Call.Return(err)
+func (_c *MockQuerier_DeleteEmployee_Call) Return(n int64, err error) *MockQuerier_DeleteEmployee_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteEmployee_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) error) *MockQuerier_DeleteEmployee_Call {
+func (_c *MockQuerier_DeleteEmployee_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (int64, error)) *MockQuerier_DeleteEmployee_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -502,36 +832,2505 @@ func (_mock *MockQuerier) DeleteUser(ctx context.Context, id pgtype.UUID) error
 		panic("no return value specified for DeleteUser")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockQuerier_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id pgtype.UUID
+func (_e *MockQuerier_Expecter) DeleteUser(ctx any, id any) *MockQuerier_DeleteUser_Call {
+	return &MockQuerier_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteUser_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUser_Call) Return(err error) *MockQuerier_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) error) *MockQuerier_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserRecoveryCodes provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteUserRecoveryCodes(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserRecoveryCodes")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteUserRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserRecoveryCodes'
+type MockQuerier_DeleteUserRecoveryCodes_Call struct {
+	*mock.Call
+}
+
+// DeleteUserRecoveryCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockQuerier_Expecter) DeleteUserRecoveryCodes(ctx any, userID any) *MockQuerier_DeleteUserRecoveryCodes_Call {
+	return &MockQuerier_DeleteUserRecoveryCodes_Call{Call: _e.mock.On("DeleteUserRecoveryCodes", ctx, userID)}
+}
+
+func (_c *MockQuerier_DeleteUserRecoveryCodes_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_DeleteUserRecoveryCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUserRecoveryCodes_Call) Return(err error) *MockQuerier_DeleteUserRecoveryCodes_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUserRecoveryCodes_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_DeleteUserRecoveryCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserTOTP provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteUserTOTP(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserTOTP")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteUserTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserTOTP'
+type MockQuerier_DeleteUserTOTP_Call struct {
+	*mock.Call
+}
+
+// DeleteUserTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockQuerier_Expecter) DeleteUserTOTP(ctx any, userID any) *MockQuerier_DeleteUserTOTP_Call {
+	return &MockQuerier_DeleteUserTOTP_Call{Call: _e.mock.On("DeleteUserTOTP", ctx, userID)}
+}
+
+func (_c *MockQuerier_DeleteUserTOTP_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_DeleteUserTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUserTOTP_Call) Return(err error) *MockQuerier_DeleteUserTOTP_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUserTOTP_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_DeleteUserTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableUserTOTP provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableUserTOTP")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, EnableUserTOTPParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, EnableUserTOTPParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, EnableUserTOTPParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_EnableUserTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableUserTOTP'
+type MockQuerier_EnableUserTOTP_Call struct {
+	*mock.Call
+}
+
+// EnableUserTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg EnableUserTOTPParams
+func (_e *MockQuerier_Expecter) EnableUserTOTP(ctx any, arg any) *MockQuerier_EnableUserTOTP_Call {
+	return &MockQuerier_EnableUserTOTP_Call{Call: _e.mock.On("EnableUserTOTP", ctx, arg)}
+}
+
+func (_c *MockQuerier_EnableUserTOTP_Call) Run(run func(ctx context.Context, arg EnableUserTOTPParams)) *MockQuerier_EnableUserTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 EnableUserTOTPParams
+		if args[1] != nil {
+			arg1 = args[1].(EnableUserTOTPParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_EnableUserTOTP_Call) Return(n int64, err error) *MockQuerier_EnableUserTOTP_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_EnableUserTOTP_Call) RunAndReturn(run func(ctx context.Context, arg EnableUserTOTPParams) (int64, error)) *MockQuerier_EnableUserTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EstimateEmployeesCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) EstimateEmployeesCount(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateEmployeesCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_EstimateEmployeesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateEmployeesCount'
+type MockQuerier_EstimateEmployeesCount_Call struct {
+	*mock.Call
+}
+
+// EstimateEmployeesCount is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) EstimateEmployeesCount(ctx any) *MockQuerier_EstimateEmployeesCount_Call {
+	return &MockQuerier_EstimateEmployeesCount_Call{Call: _e.mock.On("EstimateEmployeesCount", ctx)}
+}
+
+func (_c *MockQuerier_EstimateEmployeesCount_Call) Run(run func(ctx context.Context)) *MockQuerier_EstimateEmployeesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_EstimateEmployeesCount_Call) Return(n int64, err error) *MockQuerier_EstimateEmployeesCount_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_EstimateEmployeesCount_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockQuerier_EstimateEmployeesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EstimateUsersCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) EstimateUsersCount(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateUsersCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_EstimateUsersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateUsersCount'
+type MockQuerier_EstimateUsersCount_Call struct {
+	*mock.Call
+}
+
+// EstimateUsersCount is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) EstimateUsersCount(ctx any) *MockQuerier_EstimateUsersCount_Call {
+	return &MockQuerier_EstimateUsersCount_Call{Call: _e.mock.On("EstimateUsersCount", ctx)}
+}
+
+func (_c *MockQuerier_EstimateUsersCount_Call) Run(run func(ctx context.Context)) *MockQuerier_EstimateUsersCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_EstimateUsersCount_Call) Return(n int64, err error) *MockQuerier_EstimateUsersCount_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_EstimateUsersCount_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockQuerier_EstimateUsersCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEmployee provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetEmployee(ctx context.Context, id pgtype.UUID) (Employee, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmployee")
+	}
+
+	var r0 Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (Employee, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) Employee); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Employee)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmployee'
+type MockQuerier_GetEmployee_Call struct {
+	*mock.Call
+}
+
+// GetEmployee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id pgtype.UUID
+func (_e *MockQuerier_Expecter) GetEmployee(ctx any, id any) *MockQuerier_GetEmployee_Call {
+	return &MockQuerier_GetEmployee_Call{Call: _e.mock.On("GetEmployee", ctx, id)}
+}
+
+func (_c *MockQuerier_GetEmployee_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetEmployee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetEmployee_Call) Return(employee Employee, err error) *MockQuerier_GetEmployee_Call {
+	_c.Call.Return(employee, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetEmployee_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (Employee, error)) *MockQuerier_GetEmployee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPasswordResetTokenByHash provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordResetTokenByHash")
+	}
+
+	var r0 PasswordResetToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (PasswordResetToken, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) PasswordResetToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(PasswordResetToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetPasswordResetTokenByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPasswordResetTokenByHash'
+type MockQuerier_GetPasswordResetTokenByHash_Call struct {
+	*mock.Call
+}
+
+// GetPasswordResetTokenByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockQuerier_Expecter) GetPasswordResetTokenByHash(ctx any, tokenHash any) *MockQuerier_GetPasswordResetTokenByHash_Call {
+	return &MockQuerier_GetPasswordResetTokenByHash_Call{Call: _e.mock.On("GetPasswordResetTokenByHash", ctx, tokenHash)}
+}
+
+func (_c *MockQuerier_GetPasswordResetTokenByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockQuerier_GetPasswordResetTokenByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetPasswordResetTokenByHash_Call) Return(passwordResetToken PasswordResetToken, err error) *MockQuerier_GetPasswordResetTokenByHash_Call {
+	_c.Call.Return(passwordResetToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetPasswordResetTokenByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (PasswordResetToken, error)) *MockQuerier_GetPasswordResetTokenByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRefreshTokenByHash provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshTokenByHash")
+	}
+
+	var r0 RefreshToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (RefreshToken, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) RefreshToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(RefreshToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetRefreshTokenByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRefreshTokenByHash'
+type MockQuerier_GetRefreshTokenByHash_Call struct {
+	*mock.Call
+}
+
+// GetRefreshTokenByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockQuerier_Expecter) GetRefreshTokenByHash(ctx any, tokenHash any) *MockQuerier_GetRefreshTokenByHash_Call {
+	return &MockQuerier_GetRefreshTokenByHash_Call{Call: _e.mock.On("GetRefreshTokenByHash", ctx, tokenHash)}
+}
+
+func (_c *MockQuerier_GetRefreshTokenByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockQuerier_GetRefreshTokenByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetRefreshTokenByHash_Call) Return(refreshToken RefreshToken, err error) *MockQuerier_GetRefreshTokenByHash_Call {
+	_c.Call.Return(refreshToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetRefreshTokenByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (RefreshToken, error)) *MockQuerier_GetRefreshTokenByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockQuerier_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id pgtype.UUID
+func (_e *MockQuerier_Expecter) GetUser(ctx any, id any) *MockQuerier_GetUser_Call {
+	return &MockQuerier_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+}
+
+func (_c *MockQuerier_GetUser_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUser_Call) Return(user User, err error) *MockQuerier_GetUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetUser_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (User, error)) *MockQuerier_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByEmail provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (User, error)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) User); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type MockQuerier_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockQuerier_Expecter) GetUserByEmail(ctx any, email any) *MockQuerier_GetUserByEmail_Call {
+	return &MockQuerier_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+}
+
+func (_c *MockQuerier_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockQuerier_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByEmail_Call) Return(user User, err error) *MockQuerier_GetUserByEmail_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (User, error)) *MockQuerier_GetUserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByUsername provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByUsername")
+	}
+
+	var r0 User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (User, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) User); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUsername'
+type MockQuerier_GetUserByUsername_Call struct {
+	*mock.Call
+}
+
+// GetUserByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockQuerier_Expecter) GetUserByUsername(ctx any, username any) *MockQuerier_GetUserByUsername_Call {
+	return &MockQuerier_GetUserByUsername_Call{Call: _e.mock.On("GetUserByUsername", ctx, username)}
+}
+
+func (_c *MockQuerier_GetUserByUsername_Call) Run(run func(ctx context.Context, username string)) *MockQuerier_GetUserByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByUsername_Call) Return(user User, err error) *MockQuerier_GetUserByUsername_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (User, error)) *MockQuerier_GetUserByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserTOTP provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetUserTOTP(ctx context.Context, userID pgtype.UUID) (UserTotp, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserTOTP")
+	}
+
+	var r0 UserTotp
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (UserTotp, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) UserTotp); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(UserTotp)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetUserTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserTOTP'
+type MockQuerier_GetUserTOTP_Call struct {
+	*mock.Call
+}
+
+// GetUserTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockQuerier_Expecter) GetUserTOTP(ctx any, userID any) *MockQuerier_GetUserTOTP_Call {
+	return &MockQuerier_GetUserTOTP_Call{Call: _e.mock.On("GetUserTOTP", ctx, userID)}
+}
+
+func (_c *MockQuerier_GetUserTOTP_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_GetUserTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserTOTP_Call) Return(userTotp UserTotp, err error) *MockQuerier_GetUserTOTP_Call {
+	_c.Call.Return(userTotp, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserTOTP_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) (UserTotp, error)) *MockQuerier_GetUserTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateUserPasswordResetTokens provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) InvalidateUserPasswordResetTokens(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateUserPasswordResetTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_InvalidateUserPasswordResetTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateUserPasswordResetTokens'
+type MockQuerier_InvalidateUserPasswordResetTokens_Call struct {
+	*mock.Call
+}
+
+// InvalidateUserPasswordResetTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockQuerier_Expecter) InvalidateUserPasswordResetTokens(ctx any, userID any) *MockQuerier_InvalidateUserPasswordResetTokens_Call {
+	return &MockQuerier_InvalidateUserPasswordResetTokens_Call{Call: _e.mock.On("InvalidateUserPasswordResetTokens", ctx, userID)}
+}
+
+func (_c *MockQuerier_InvalidateUserPasswordResetTokens_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_InvalidateUserPasswordResetTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InvalidateUserPasswordResetTokens_Call) Return(err error) *MockQuerier_InvalidateUserPasswordResetTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_InvalidateUserPasswordResetTokens_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_InvalidateUserPasswordResetTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDepartments provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListDepartments(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDepartments")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListDepartments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDepartments'
+type MockQuerier_ListDepartments_Call struct {
+	*mock.Call
+}
+
+// ListDepartments is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListDepartments(ctx any) *MockQuerier_ListDepartments_Call {
+	return &MockQuerier_ListDepartments_Call{Call: _e.mock.On("ListDepartments", ctx)}
+}
+
+func (_c *MockQuerier_ListDepartments_Call) Run(run func(ctx context.Context)) *MockQuerier_ListDepartments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListDepartments_Call) Return(strings []string, err error) *MockQuerier_ListDepartments_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListDepartments_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockQuerier_ListDepartments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployees provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployees(ctx context.Context) ([]Employee, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployees")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]Employee, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []Employee); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployees_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployees'
+type MockQuerier_ListEmployees_Call struct {
+	*mock.Call
+}
+
+// ListEmployees is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListEmployees(ctx any) *MockQuerier_ListEmployees_Call {
+	return &MockQuerier_ListEmployees_Call{Call: _e.mock.On("ListEmployees", ctx)}
+}
+
+func (_c *MockQuerier_ListEmployees_Call) Run(run func(ctx context.Context)) *MockQuerier_ListEmployees_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployees_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployees_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployees_Call) RunAndReturn(run func(ctx context.Context) ([]Employee, error)) *MockQuerier_ListEmployees_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByDepartment provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByDepartment(ctx context.Context, arg ListEmployeesByDepartmentParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByDepartment")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByDepartmentParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByDepartmentParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByDepartmentParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByDepartment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByDepartment'
+type MockQuerier_ListEmployeesByDepartment_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByDepartment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByDepartmentParams
+func (_e *MockQuerier_Expecter) ListEmployeesByDepartment(ctx any, arg any) *MockQuerier_ListEmployeesByDepartment_Call {
+	return &MockQuerier_ListEmployeesByDepartment_Call{Call: _e.mock.On("ListEmployeesByDepartment", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByDepartment_Call) Run(run func(ctx context.Context, arg ListEmployeesByDepartmentParams)) *MockQuerier_ListEmployeesByDepartment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByDepartmentParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByDepartmentParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByDepartment_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByDepartment_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByDepartment_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByDepartmentParams) ([]Employee, error)) *MockQuerier_ListEmployeesByDepartment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByDepartmentDesc provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByDepartmentDesc(ctx context.Context, arg ListEmployeesByDepartmentDescParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByDepartmentDesc")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByDepartmentDescParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByDepartmentDescParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByDepartmentDescParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByDepartmentDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByDepartmentDesc'
+type MockQuerier_ListEmployeesByDepartmentDesc_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByDepartmentDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByDepartmentDescParams
+func (_e *MockQuerier_Expecter) ListEmployeesByDepartmentDesc(ctx any, arg any) *MockQuerier_ListEmployeesByDepartmentDesc_Call {
+	return &MockQuerier_ListEmployeesByDepartmentDesc_Call{Call: _e.mock.On("ListEmployeesByDepartmentDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByDepartmentDesc_Call) Run(run func(ctx context.Context, arg ListEmployeesByDepartmentDescParams)) *MockQuerier_ListEmployeesByDepartmentDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByDepartmentDescParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByDepartmentDescParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByDepartmentDesc_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByDepartmentDesc_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByDepartmentDesc_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByDepartmentDescParams) ([]Employee, error)) *MockQuerier_ListEmployeesByDepartmentDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByEmail provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByEmail(ctx context.Context, arg ListEmployeesByEmailParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByEmail")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByEmailParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByEmailParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByEmailParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByEmail'
+type MockQuerier_ListEmployeesByEmail_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByEmailParams
+func (_e *MockQuerier_Expecter) ListEmployeesByEmail(ctx any, arg any) *MockQuerier_ListEmployeesByEmail_Call {
+	return &MockQuerier_ListEmployeesByEmail_Call{Call: _e.mock.On("ListEmployeesByEmail", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByEmail_Call) Run(run func(ctx context.Context, arg ListEmployeesByEmailParams)) *MockQuerier_ListEmployeesByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByEmailParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByEmailParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByEmail_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByEmail_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByEmail_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByEmailParams) ([]Employee, error)) *MockQuerier_ListEmployeesByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByEmailDesc provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByEmailDesc(ctx context.Context, arg ListEmployeesByEmailDescParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByEmailDesc")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByEmailDescParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByEmailDescParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByEmailDescParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByEmailDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByEmailDesc'
+type MockQuerier_ListEmployeesByEmailDesc_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByEmailDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByEmailDescParams
+func (_e *MockQuerier_Expecter) ListEmployeesByEmailDesc(ctx any, arg any) *MockQuerier_ListEmployeesByEmailDesc_Call {
+	return &MockQuerier_ListEmployeesByEmailDesc_Call{Call: _e.mock.On("ListEmployeesByEmailDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByEmailDesc_Call) Run(run func(ctx context.Context, arg ListEmployeesByEmailDescParams)) *MockQuerier_ListEmployeesByEmailDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByEmailDescParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByEmailDescParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByEmailDesc_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByEmailDesc_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByEmailDesc_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByEmailDescParams) ([]Employee, error)) *MockQuerier_ListEmployeesByEmailDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByFirstName provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByFirstName(ctx context.Context, arg ListEmployeesByFirstNameParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByFirstName")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByFirstNameParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByFirstNameParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByFirstNameParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByFirstName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByFirstName'
+type MockQuerier_ListEmployeesByFirstName_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByFirstName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByFirstNameParams
+func (_e *MockQuerier_Expecter) ListEmployeesByFirstName(ctx any, arg any) *MockQuerier_ListEmployeesByFirstName_Call {
+	return &MockQuerier_ListEmployeesByFirstName_Call{Call: _e.mock.On("ListEmployeesByFirstName", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByFirstName_Call) Run(run func(ctx context.Context, arg ListEmployeesByFirstNameParams)) *MockQuerier_ListEmployeesByFirstName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByFirstNameParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByFirstNameParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByFirstName_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByFirstName_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByFirstName_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByFirstNameParams) ([]Employee, error)) *MockQuerier_ListEmployeesByFirstName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByFirstNameDesc provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByFirstNameDesc(ctx context.Context, arg ListEmployeesByFirstNameDescParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByFirstNameDesc")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByFirstNameDescParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByFirstNameDescParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByFirstNameDescParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByFirstNameDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByFirstNameDesc'
+type MockQuerier_ListEmployeesByFirstNameDesc_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByFirstNameDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByFirstNameDescParams
+func (_e *MockQuerier_Expecter) ListEmployeesByFirstNameDesc(ctx any, arg any) *MockQuerier_ListEmployeesByFirstNameDesc_Call {
+	return &MockQuerier_ListEmployeesByFirstNameDesc_Call{Call: _e.mock.On("ListEmployeesByFirstNameDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByFirstNameDesc_Call) Run(run func(ctx context.Context, arg ListEmployeesByFirstNameDescParams)) *MockQuerier_ListEmployeesByFirstNameDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByFirstNameDescParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByFirstNameDescParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByFirstNameDesc_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByFirstNameDesc_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByFirstNameDesc_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByFirstNameDescParams) ([]Employee, error)) *MockQuerier_ListEmployeesByFirstNameDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByLastName provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByLastName(ctx context.Context, arg ListEmployeesByLastNameParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByLastName")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByLastNameParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByLastNameParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByLastNameParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByLastName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByLastName'
+type MockQuerier_ListEmployeesByLastName_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByLastName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByLastNameParams
+func (_e *MockQuerier_Expecter) ListEmployeesByLastName(ctx any, arg any) *MockQuerier_ListEmployeesByLastName_Call {
+	return &MockQuerier_ListEmployeesByLastName_Call{Call: _e.mock.On("ListEmployeesByLastName", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByLastName_Call) Run(run func(ctx context.Context, arg ListEmployeesByLastNameParams)) *MockQuerier_ListEmployeesByLastName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByLastNameParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByLastNameParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByLastName_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByLastName_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByLastName_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByLastNameParams) ([]Employee, error)) *MockQuerier_ListEmployeesByLastName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByLastNameDesc provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByLastNameDesc(ctx context.Context, arg ListEmployeesByLastNameDescParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByLastNameDesc")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByLastNameDescParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByLastNameDescParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByLastNameDescParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByLastNameDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByLastNameDesc'
+type MockQuerier_ListEmployeesByLastNameDesc_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByLastNameDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByLastNameDescParams
+func (_e *MockQuerier_Expecter) ListEmployeesByLastNameDesc(ctx any, arg any) *MockQuerier_ListEmployeesByLastNameDesc_Call {
+	return &MockQuerier_ListEmployeesByLastNameDesc_Call{Call: _e.mock.On("ListEmployeesByLastNameDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByLastNameDesc_Call) Run(run func(ctx context.Context, arg ListEmployeesByLastNameDescParams)) *MockQuerier_ListEmployeesByLastNameDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByLastNameDescParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByLastNameDescParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByLastNameDesc_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByLastNameDesc_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByLastNameDesc_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByLastNameDescParams) ([]Employee, error)) *MockQuerier_ListEmployeesByLastNameDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByName provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByName(ctx context.Context, arg ListEmployeesByNameParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByName")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByNameParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByNameParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByNameParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByName'
+type MockQuerier_ListEmployeesByName_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByNameParams
+func (_e *MockQuerier_Expecter) ListEmployeesByName(ctx any, arg any) *MockQuerier_ListEmployeesByName_Call {
+	return &MockQuerier_ListEmployeesByName_Call{Call: _e.mock.On("ListEmployeesByName", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByName_Call) Run(run func(ctx context.Context, arg ListEmployeesByNameParams)) *MockQuerier_ListEmployeesByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByNameParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByNameParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByName_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByName_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByName_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByNameParams) ([]Employee, error)) *MockQuerier_ListEmployeesByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEmployeesByNameDesc provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListEmployeesByNameDesc(ctx context.Context, arg ListEmployeesByNameDescParams) ([]Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmployeesByNameDesc")
+	}
+
+	var r0 []Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByNameDescParams) ([]Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListEmployeesByNameDescParams) []Employee); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Employee)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListEmployeesByNameDescParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListEmployeesByNameDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployeesByNameDesc'
+type MockQuerier_ListEmployeesByNameDesc_Call struct {
+	*mock.Call
+}
+
+// ListEmployeesByNameDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListEmployeesByNameDescParams
+func (_e *MockQuerier_Expecter) ListEmployeesByNameDesc(ctx any, arg any) *MockQuerier_ListEmployeesByNameDesc_Call {
+	return &MockQuerier_ListEmployeesByNameDesc_Call{Call: _e.mock.On("ListEmployeesByNameDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListEmployeesByNameDesc_Call) Run(run func(ctx context.Context, arg ListEmployeesByNameDescParams)) *MockQuerier_ListEmployeesByNameDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListEmployeesByNameDescParams
+		if args[1] != nil {
+			arg1 = args[1].(ListEmployeesByNameDescParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByNameDesc_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployeesByNameDesc_Call {
+	_c.Call.Return(employees, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListEmployeesByNameDesc_Call) RunAndReturn(run func(ctx context.Context, arg ListEmployeesByNameDescParams) ([]Employee, error)) *MockQuerier_ListEmployeesByNameDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRolePermissions provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListRolePermissions(ctx context.Context, role string) ([]string, error) {
+	ret := _mock.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRolePermissions")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, role)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListRolePermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRolePermissions'
+type MockQuerier_ListRolePermissions_Call struct {
+	*mock.Call
+}
+
+// ListRolePermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - role string
+func (_e *MockQuerier_Expecter) ListRolePermissions(ctx any, role any) *MockQuerier_ListRolePermissions_Call {
+	return &MockQuerier_ListRolePermissions_Call{Call: _e.mock.On("ListRolePermissions", ctx, role)}
+}
+
+func (_c *MockQuerier_ListRolePermissions_Call) Run(run func(ctx context.Context, role string)) *MockQuerier_ListRolePermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListRolePermissions_Call) Return(strings []string, err error) *MockQuerier_ListRolePermissions_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListRolePermissions_Call) RunAndReturn(run func(ctx context.Context, role string) ([]string, error)) *MockQuerier_ListRolePermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoles provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListRoles(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoles'
+type MockQuerier_ListRoles_Call struct {
+	*mock.Call
+}
+
+// ListRoles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListRoles(ctx any) *MockQuerier_ListRoles_Call {
+	return &MockQuerier_ListRoles_Call{Call: _e.mock.On("ListRoles", ctx)}
+}
+
+func (_c *MockQuerier_ListRoles_Call) Run(run func(ctx context.Context)) *MockQuerier_ListRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListRoles_Call) Return(strings []string, err error) *MockQuerier_ListRoles_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListRoles_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockQuerier_ListRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUsers(ctx context.Context) ([]User, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]User, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []User); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type MockQuerier_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListUsers(ctx any) *MockQuerier_ListUsers_Call {
+	return &MockQuerier_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx)}
+}
+
+func (_c *MockQuerier_ListUsers_Call) Run(run func(ctx context.Context)) *MockQuerier_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUsers_Call) Return(users []User, err error) *MockQuerier_ListUsers_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUsers_Call) RunAndReturn(run func(ctx context.Context) ([]User, error)) *MockQuerier_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsersByEmail provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUsersByEmail(ctx context.Context, arg ListUsersByEmailParams) ([]User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersByEmail")
+	}
+
+	var r0 []User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByEmailParams) ([]User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByEmailParams) []User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListUsersByEmailParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUsersByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsersByEmail'
+type MockQuerier_ListUsersByEmail_Call struct {
+	*mock.Call
+}
+
+// ListUsersByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListUsersByEmailParams
+func (_e *MockQuerier_Expecter) ListUsersByEmail(ctx any, arg any) *MockQuerier_ListUsersByEmail_Call {
+	return &MockQuerier_ListUsersByEmail_Call{Call: _e.mock.On("ListUsersByEmail", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListUsersByEmail_Call) Run(run func(ctx context.Context, arg ListUsersByEmailParams)) *MockQuerier_ListUsersByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListUsersByEmailParams
+		if args[1] != nil {
+			arg1 = args[1].(ListUsersByEmailParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByEmail_Call) Return(users []User, err error) *MockQuerier_ListUsersByEmail_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByEmail_Call) RunAndReturn(run func(ctx context.Context, arg ListUsersByEmailParams) ([]User, error)) *MockQuerier_ListUsersByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsersByEmailDesc provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUsersByEmailDesc(ctx context.Context, arg ListUsersByEmailDescParams) ([]User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersByEmailDesc")
+	}
+
+	var r0 []User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByEmailDescParams) ([]User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByEmailDescParams) []User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListUsersByEmailDescParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUsersByEmailDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsersByEmailDesc'
+type MockQuerier_ListUsersByEmailDesc_Call struct {
+	*mock.Call
+}
+
+// ListUsersByEmailDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListUsersByEmailDescParams
+func (_e *MockQuerier_Expecter) ListUsersByEmailDesc(ctx any, arg any) *MockQuerier_ListUsersByEmailDesc_Call {
+	return &MockQuerier_ListUsersByEmailDesc_Call{Call: _e.mock.On("ListUsersByEmailDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListUsersByEmailDesc_Call) Run(run func(ctx context.Context, arg ListUsersByEmailDescParams)) *MockQuerier_ListUsersByEmailDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListUsersByEmailDescParams
+		if args[1] != nil {
+			arg1 = args[1].(ListUsersByEmailDescParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByEmailDesc_Call) Return(users []User, err error) *MockQuerier_ListUsersByEmailDesc_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByEmailDesc_Call) RunAndReturn(run func(ctx context.Context, arg ListUsersByEmailDescParams) ([]User, error)) *MockQuerier_ListUsersByEmailDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsersByName provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUsersByName(ctx context.Context, arg ListUsersByNameParams) ([]User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersByName")
+	}
+
+	var r0 []User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByNameParams) ([]User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByNameParams) []User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListUsersByNameParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUsersByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsersByName'
+type MockQuerier_ListUsersByName_Call struct {
+	*mock.Call
+}
+
+// ListUsersByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListUsersByNameParams
+func (_e *MockQuerier_Expecter) ListUsersByName(ctx any, arg any) *MockQuerier_ListUsersByName_Call {
+	return &MockQuerier_ListUsersByName_Call{Call: _e.mock.On("ListUsersByName", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListUsersByName_Call) Run(run func(ctx context.Context, arg ListUsersByNameParams)) *MockQuerier_ListUsersByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListUsersByNameParams
+		if args[1] != nil {
+			arg1 = args[1].(ListUsersByNameParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByName_Call) Return(users []User, err error) *MockQuerier_ListUsersByName_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByName_Call) RunAndReturn(run func(ctx context.Context, arg ListUsersByNameParams) ([]User, error)) *MockQuerier_ListUsersByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsersByNameDesc provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUsersByNameDesc(ctx context.Context, arg ListUsersByNameDescParams) ([]User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersByNameDesc")
+	}
+
+	var r0 []User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByNameDescParams) ([]User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByNameDescParams) []User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListUsersByNameDescParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUsersByNameDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsersByNameDesc'
+type MockQuerier_ListUsersByNameDesc_Call struct {
+	*mock.Call
+}
+
+// ListUsersByNameDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListUsersByNameDescParams
+func (_e *MockQuerier_Expecter) ListUsersByNameDesc(ctx any, arg any) *MockQuerier_ListUsersByNameDesc_Call {
+	return &MockQuerier_ListUsersByNameDesc_Call{Call: _e.mock.On("ListUsersByNameDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListUsersByNameDesc_Call) Run(run func(ctx context.Context, arg ListUsersByNameDescParams)) *MockQuerier_ListUsersByNameDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListUsersByNameDescParams
+		if args[1] != nil {
+			arg1 = args[1].(ListUsersByNameDescParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByNameDesc_Call) Return(users []User, err error) *MockQuerier_ListUsersByNameDesc_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByNameDesc_Call) RunAndReturn(run func(ctx context.Context, arg ListUsersByNameDescParams) ([]User, error)) *MockQuerier_ListUsersByNameDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsersByUsername provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUsersByUsername(ctx context.Context, arg ListUsersByUsernameParams) ([]User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersByUsername")
+	}
+
+	var r0 []User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByUsernameParams) ([]User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByUsernameParams) []User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListUsersByUsernameParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUsersByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsersByUsername'
+type MockQuerier_ListUsersByUsername_Call struct {
+	*mock.Call
+}
+
+// ListUsersByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListUsersByUsernameParams
+func (_e *MockQuerier_Expecter) ListUsersByUsername(ctx any, arg any) *MockQuerier_ListUsersByUsername_Call {
+	return &MockQuerier_ListUsersByUsername_Call{Call: _e.mock.On("ListUsersByUsername", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListUsersByUsername_Call) Run(run func(ctx context.Context, arg ListUsersByUsernameParams)) *MockQuerier_ListUsersByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListUsersByUsernameParams
+		if args[1] != nil {
+			arg1 = args[1].(ListUsersByUsernameParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByUsername_Call) Return(users []User, err error) *MockQuerier_ListUsersByUsername_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByUsername_Call) RunAndReturn(run func(ctx context.Context, arg ListUsersByUsernameParams) ([]User, error)) *MockQuerier_ListUsersByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsersByUsernameDesc provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUsersByUsernameDesc(ctx context.Context, arg ListUsersByUsernameDescParams) ([]User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersByUsernameDesc")
+	}
+
+	var r0 []User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByUsernameDescParams) ([]User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ListUsersByUsernameDescParams) []User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ListUsersByUsernameDescParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUsersByUsernameDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsersByUsernameDesc'
+type MockQuerier_ListUsersByUsernameDesc_Call struct {
+	*mock.Call
+}
+
+// ListUsersByUsernameDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg ListUsersByUsernameDescParams
+func (_e *MockQuerier_Expecter) ListUsersByUsernameDesc(ctx any, arg any) *MockQuerier_ListUsersByUsernameDesc_Call {
+	return &MockQuerier_ListUsersByUsernameDesc_Call{Call: _e.mock.On("ListUsersByUsernameDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListUsersByUsernameDesc_Call) Run(run func(ctx context.Context, arg ListUsersByUsernameDescParams)) *MockQuerier_ListUsersByUsernameDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ListUsersByUsernameDescParams
+		if args[1] != nil {
+			arg1 = args[1].(ListUsersByUsernameDescParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByUsernameDesc_Call) Return(users []User, err error) *MockQuerier_ListUsersByUsernameDesc_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUsersByUsernameDesc_Call) RunAndReturn(run func(ctx context.Context, arg ListUsersByUsernameDescParams) ([]User, error)) *MockQuerier_ListUsersByUsernameDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeRefreshTokenFamily provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error {
+	ret := _mock.Called(ctx, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshTokenFamily")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_RevokeRefreshTokenFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeRefreshTokenFamily'
+type MockQuerier_RevokeRefreshTokenFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeRefreshTokenFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID pgtype.UUID
+func (_e *MockQuerier_Expecter) RevokeRefreshTokenFamily(ctx any, familyID any) *MockQuerier_RevokeRefreshTokenFamily_Call {
+	return &MockQuerier_RevokeRefreshTokenFamily_Call{Call: _e.mock.On("RevokeRefreshTokenFamily", ctx, familyID)}
+}
+
+func (_c *MockQuerier_RevokeRefreshTokenFamily_Call) Run(run func(ctx context.Context, familyID pgtype.UUID)) *MockQuerier_RevokeRefreshTokenFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RevokeRefreshTokenFamily_Call) Return(err error) *MockQuerier_RevokeRefreshTokenFamily_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_RevokeRefreshTokenFamily_Call) RunAndReturn(run func(ctx context.Context, familyID pgtype.UUID) error) *MockQuerier_RevokeRefreshTokenFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserRefreshTokens provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RevokeUserRefreshTokens(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserRefreshTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_RevokeUserRefreshTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserRefreshTokens'
+type MockQuerier_RevokeUserRefreshTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeUserRefreshTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockQuerier_Expecter) RevokeUserRefreshTokens(ctx any, userID any) *MockQuerier_RevokeUserRefreshTokens_Call {
+	return &MockQuerier_RevokeUserRefreshTokens_Call{Call: _e.mock.On("RevokeUserRefreshTokens", ctx, userID)}
+}
+
+func (_c *MockQuerier_RevokeUserRefreshTokens_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_RevokeUserRefreshTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RevokeUserRefreshTokens_Call) Return(err error) *MockQuerier_RevokeUserRefreshTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_RevokeUserRefreshTokens_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_RevokeUserRefreshTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateRefreshToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RotateRefreshToken(ctx context.Context, arg RotateRefreshTokenParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, RotateRefreshTokenParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, RotateRefreshTokenParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, RotateRefreshTokenParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_RotateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateRefreshToken'
+type MockQuerier_RotateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// RotateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg RotateRefreshTokenParams
+func (_e *MockQuerier_Expecter) RotateRefreshToken(ctx any, arg any) *MockQuerier_RotateRefreshToken_Call {
+	return &MockQuerier_RotateRefreshToken_Call{Call: _e.mock.On("RotateRefreshToken", ctx, arg)}
+}
+
+func (_c *MockQuerier_RotateRefreshToken_Call) Run(run func(ctx context.Context, arg RotateRefreshTokenParams)) *MockQuerier_RotateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 RotateRefreshTokenParams
+		if args[1] != nil {
+			arg1 = args[1].(RotateRefreshTokenParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RotateRefreshToken_Call) Return(n int64, err error) *MockQuerier_RotateRefreshToken_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_RotateRefreshToken_Call) RunAndReturn(run func(ctx context.Context, arg RotateRefreshTokenParams) (int64, error)) *MockQuerier_RotateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEmployee provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateEmployee(ctx context.Context, arg UpdateEmployeeParams) (Employee, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmployee")
+	}
+
+	var r0 Employee
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateEmployeeParams) (Employee, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateEmployeeParams) Employee); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(Employee)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, UpdateEmployeeParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockQuerier_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
-type MockQuerier_DeleteUser_Call struct {
+// MockQuerier_UpdateEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEmployee'
+type MockQuerier_UpdateEmployee_Call struct {
 	*mock.Call
 }
 
-// DeleteUser is a helper method to define mock.On call
+// UpdateEmployee is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id pgtype.UUID
-func (_e *MockQuerier_Expecter) DeleteUser(ctx any, id any) *MockQuerier_DeleteUser_Call {
-	return &MockQuerier_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+//   - arg UpdateEmployeeParams
+func (_e *MockQuerier_Expecter) UpdateEmployee(ctx any, arg any) *MockQuerier_UpdateEmployee_Call {
+	return &MockQuerier_UpdateEmployee_Call{Call: _e.mock.On("UpdateEmployee", ctx, arg)}
 }
 
-func (_c *MockQuerier_DeleteUser_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_DeleteUser_Call {
+func (_c *MockQuerier_UpdateEmployee_Call) Run(run func(ctx context.Context, arg UpdateEmployeeParams)) *MockQuerier_UpdateEmployee_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 pgtype.UUID
+		var arg1 UpdateEmployeeParams
 		if args[1] != nil {
-			arg1 = args[1].(pgtype.UUID)
+			arg1 = args[1].(UpdateEmployeeParams)
 		}
 		run(
 			arg0,
@@ -541,63 +3340,63 @@ func (_c *MockQuerier_DeleteUser_Call) Run(run func(ctx context.Context, id pgty
 	return _c
 }
 
-func (_c *MockQuerier_DeleteUser_Call) Return(err error) *MockQuerier_DeleteUser_Call {
-	_c.Call.Return(err)
+func (_c *MockQuerier_UpdateEmployee_Call) Return(employee Employee, err error) *MockQuerier_UpdateEmployee_Call {
+	_c.Call.Return(employee, err)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) error) *MockQuerier_DeleteUser_Call {
+func (_c *MockQuerier_UpdateEmployee_Call) RunAndReturn(run func(ctx context.Context, arg UpdateEmployeeParams) (Employee, error)) *MockQuerier_UpdateEmployee_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEmployee provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetEmployee(ctx context.Context, id pgtype.UUID) (Employee, error) {
-	ret := _mock.Called(ctx, id)
+// UpdateUser provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEmployee")
+		panic("no return value specified for UpdateUser")
 	}
 
-	var r0 Employee
+	var r0 User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (Employee, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateUserParams) (User, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) Employee); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateUserParams) User); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(Employee)
+		r0 = ret.Get(0).(User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, UpdateUserParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmployee'
-type MockQuerier_GetEmployee_Call struct {
+// MockQuerier_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockQuerier_UpdateUser_Call struct {
 	*mock.Call
 }
 
-// GetEmployee is a helper method to define mock.On call
+// UpdateUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id pgtype.UUID
-func (_e *MockQuerier_Expecter) GetEmployee(ctx any, id any) *MockQuerier_GetEmployee_Call {
-	return &MockQuerier_GetEmployee_Call{Call: _e.mock.On("GetEmployee", ctx, id)}
+//   - arg UpdateUserParams
+func (_e *MockQuerier_Expecter) UpdateUser(ctx any, arg any) *MockQuerier_UpdateUser_Call {
+	return &MockQuerier_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetEmployee_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetEmployee_Call {
+func (_c *MockQuerier_UpdateUser_Call) Run(run func(ctx context.Context, arg UpdateUserParams)) *MockQuerier_UpdateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 pgtype.UUID
+		var arg1 UpdateUserParams
 		if args[1] != nil {
-			arg1 = args[1].(pgtype.UUID)
+			arg1 = args[1].(UpdateUserParams)
 		}
 		run(
 			arg0,
@@ -607,63 +3406,54 @@ func (_c *MockQuerier_GetEmployee_Call) Run(run func(ctx context.Context, id pgt
 	return _c
 }
 
-func (_c *MockQuerier_GetEmployee_Call) Return(employee Employee, err error) *MockQuerier_GetEmployee_Call {
-	_c.Call.Return(employee, err)
+func (_c *MockQuerier_UpdateUser_Call) Return(user User, err error) *MockQuerier_UpdateUser_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetEmployee_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (Employee, error)) *MockQuerier_GetEmployee_Call {
+func (_c *MockQuerier_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, arg UpdateUserParams) (User, error)) *MockQuerier_UpdateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUser provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
-	ret := _mock.Called(ctx, id)
+// UpdateUserPassword provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUser")
+		panic("no return value specified for UpdateUserPassword")
 	}
 
-	var r0 User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (User, error)); ok {
-		return returnFunc(ctx, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) User); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		r0 = ret.Get(0).(User)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateUserPasswordParams) error); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockQuerier_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
-type MockQuerier_GetUser_Call struct {
+// MockQuerier_UpdateUserPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserPassword'
+type MockQuerier_UpdateUserPassword_Call struct {
 	*mock.Call
 }
 
-// GetUser is a helper method to define mock.On call
+// UpdateUserPassword is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id pgtype.UUID
-func (_e *MockQuerier_Expecter) GetUser(ctx any, id any) *MockQuerier_GetUser_Call {
-	return &MockQuerier_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+//   - arg UpdateUserPasswordParams
+func (_e *MockQuerier_Expecter) UpdateUserPassword(ctx any, arg any) *MockQuerier_UpdateUserPassword_Call {
+	return &MockQuerier_UpdateUserPassword_Call{Call: _e.mock.On("UpdateUserPassword", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetUser_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetUser_Call {
+func (_c *MockQuerier_UpdateUserPassword_Call) Run(run func(ctx context.Context, arg UpdateUserPasswordParams)) *MockQuerier_UpdateUserPassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 pgtype.UUID
+		var arg1 UpdateUserPasswordParams
 		if args[1] != nil {
-			arg1 = args[1].(pgtype.UUID)
+			arg1 = args[1].(UpdateUserPasswordParams)
 		}
 		run(
 			arg0,
@@ -673,63 +3463,63 @@ func (_c *MockQuerier_GetUser_Call) Run(run func(ctx context.Context, id pgtype.
 	return _c
 }
 
-func (_c *MockQuerier_GetUser_Call) Return(user User, err error) *MockQuerier_GetUser_Call {
-	_c.Call.Return(user, err)
+func (_c *MockQuerier_UpdateUserPassword_Call) Return(err error) *MockQuerier_UpdateUserPassword_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockQuerier_GetUser_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (User, error)) *MockQuerier_GetUser_Call {
+func (_c *MockQuerier_UpdateUserPassword_Call) RunAndReturn(run func(ctx context.Context, arg UpdateUserPasswordParams) error) *MockQuerier_UpdateUserPassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByUsername provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetUserByUsername(ctx context.Context, username string) (User, error) {
-	ret := _mock.Called(ctx, username)
+// UpdateUserRole provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByUsername")
+		panic("no return value specified for UpdateUserRole")
 	}
 
 	var r0 User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (User, error)); ok {
-		return returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateUserRoleParams) (User, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) User); ok {
-		r0 = returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateUserRoleParams) User); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, UpdateUserRoleParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUsername'
-type MockQuerier_GetUserByUsername_Call struct {
+// MockQuerier_UpdateUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserRole'
+type MockQuerier_UpdateUserRole_Call struct {
 	*mock.Call
 }
 
-// GetUserByUsername is a helper method to define mock.On call
+// UpdateUserRole is a helper method to define mock.On call
 //   - ctx context.Context
-//   - username string
-func (_e *MockQuerier_Expecter) GetUserByUsername(ctx any, username any) *MockQuerier_GetUserByUsername_Call {
-	return &MockQuerier_GetUserByUsername_Call{Call: _e.mock.On("GetUserByUsername", ctx, username)}
+//   - arg UpdateUserRoleParams
+func (_e *MockQuerier_Expecter) UpdateUserRole(ctx any, arg any) *MockQuerier_UpdateUserRole_Call {
+	return &MockQuerier_UpdateUserRole_Call{Call: _e.mock.On("UpdateUserRole", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetUserByUsername_Call) Run(run func(ctx context.Context, username string)) *MockQuerier_GetUserByUsername_Call {
+func (_c *MockQuerier_UpdateUserRole_Call) Run(run func(ctx context.Context, arg UpdateUserRoleParams)) *MockQuerier_UpdateUserRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 UpdateUserRoleParams
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(UpdateUserRoleParams)
 		}
 		run(
 			arg0,
@@ -739,159 +3529,167 @@ func (_c *MockQuerier_GetUserByUsername_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockQuerier_GetUserByUsername_Call) Return(user User, err error) *MockQuerier_GetUserByUsername_Call {
+func (_c *MockQuerier_UpdateUserRole_Call) Return(user User, err error) *MockQuerier_UpdateUserRole_Call {
 	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetUserByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (User, error)) *MockQuerier_GetUserByUsername_Call {
+func (_c *MockQuerier_UpdateUserRole_Call) RunAndReturn(run func(ctx context.Context, arg UpdateUserRoleParams) (User, error)) *MockQuerier_UpdateUserRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListEmployees provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) ListEmployees(ctx context.Context) ([]Employee, error) {
-	ret := _mock.Called(ctx)
+// UpsertUserTOTP provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpsertUserTOTP(ctx context.Context, arg UpsertUserTOTPParams) (UserTotp, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListEmployees")
+		panic("no return value specified for UpsertUserTOTP")
 	}
 
-	var r0 []Employee
+	var r0 UserTotp
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]Employee, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UpsertUserTOTPParams) (UserTotp, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []Employee); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UpsertUserTOTPParams) UserTotp); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]Employee)
-		}
+		r0 = ret.Get(0).(UserTotp)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, UpsertUserTOTPParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_ListEmployees_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmployees'
-type MockQuerier_ListEmployees_Call struct {
+// MockQuerier_UpsertUserTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertUserTOTP'
+type MockQuerier_UpsertUserTOTP_Call struct {
 	*mock.Call
 }
 
-// ListEmployees is a helper method to define mock.On call
+// UpsertUserTOTP is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockQuerier_Expecter) ListEmployees(ctx any) *MockQuerier_ListEmployees_Call {
-	return &MockQuerier_ListEmployees_Call{Call: _e.mock.On("ListEmployees", ctx)}
+//   - arg UpsertUserTOTPParams
+func (_e *MockQuerier_Expecter) UpsertUserTOTP(ctx any, arg any) *MockQuerier_UpsertUserTOTP_Call {
+	return &MockQuerier_UpsertUserTOTP_Call{Call: _e.mock.On("UpsertUserTOTP", ctx, arg)}
 }
 
-func (_c *MockQuerier_ListEmployees_Call) Run(run func(ctx context.Context)) *MockQuerier_ListEmployees_Call {
+func (_c *MockQuerier_UpsertUserTOTP_Call) Run(run func(ctx context.Context, arg UpsertUserTOTPParams)) *MockQuerier_UpsertUserTOTP_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 UpsertUserTOTPParams
+		if args[1] != nil {
+			arg1 = args[1].(UpsertUserTOTPParams)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListEmployees_Call) Return(employees []Employee, err error) *MockQuerier_ListEmployees_Call {
-	_c.Call.Return(employees, err)
+func (_c *MockQuerier_UpsertUserTOTP_Call) Return(userTotp UserTotp, err error) *MockQuerier_UpsertUserTOTP_Call {
+	_c.Call.Return(userTotp, err)
 	return _c
 }
 
-func (_c *MockQuerier_ListEmployees_Call) RunAndReturn(run func(ctx context.Context) ([]Employee, error)) *MockQuerier_ListEmployees_Call {
+func (_c *MockQuerier_UpsertUserTOTP_Call) RunAndReturn(run func(ctx context.Context, arg UpsertUserTOTPParams) (UserTotp, error)) *MockQuerier_UpsertUserTOTP_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListUsers provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) ListUsers(ctx context.Context) ([]User, error) {
-	ret := _mock.Called(ctx)
+// UsePasswordResetToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UsePasswordResetToken(ctx context.Context, id pgtype.UUID) (int64, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUsers")
+		panic("no return value specified for UsePasswordResetToken")
 	}
 
-	var r0 []User
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]User, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (int64, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []User); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) int64); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]User)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
-type MockQuerier_ListUsers_Call struct {
+// MockQuerier_UsePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsePasswordResetToken'
+type MockQuerier_UsePasswordResetToken_Call struct {
 	*mock.Call
 }
 
-// ListUsers is a helper method to define mock.On call
+// UsePasswordResetToken is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockQuerier_Expecter) ListUsers(ctx any) *MockQuerier_ListUsers_Call {
-	return &MockQuerier_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx)}
+//   - id pgtype.UUID
+func (_e *MockQuerier_Expecter) UsePasswordResetToken(ctx any, id any) *MockQuerier_UsePasswordResetToken_Call {
+	return &MockQuerier_UsePasswordResetToken_Call{Call: _e.mock.On("UsePasswordResetToken", ctx, id)}
 }
 
-func (_c *MockQuerier_ListUsers_Call) Run(run func(ctx context.Context)) *MockQuerier_ListUsers_Call {
+func (_c *MockQuerier_UsePasswordResetToken_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_UsePasswordResetToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 pgtype.UUID
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.UUID)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListUsers_Call) Return(users []User, err error) *MockQuerier_ListUsers_Call {
-	_c.Call.Return(users, err)
+func (_c *MockQuerier_UsePasswordResetToken_Call) Return(n int64, err error) *MockQuerier_UsePasswordResetToken_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockQuerier_ListUsers_Call) RunAndReturn(run func(ctx context.Context) ([]User, error)) *MockQuerier_ListUsers_Call {
+func (_c *MockQuerier_UsePasswordResetToken_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (int64, error)) *MockQuerier_UsePasswordResetToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateEmployee provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) UpdateEmployee(ctx context.Context, arg UpdateEmployeeParams) (Employee, error) {
+// UseRecoveryCode provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateEmployee")
+		panic("no return value specified for UseRecoveryCode")
 	}
 
-	var r0 Employee
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateEmployeeParams) (Employee, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UseRecoveryCodeParams) (int64, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateEmployeeParams) Employee); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UseRecoveryCodeParams) int64); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(Employee)
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, UpdateEmployeeParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, UseRecoveryCodeParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -899,27 +3697,27 @@ func (_mock *MockQuerier) UpdateEmployee(ctx context.Context, arg UpdateEmployee
 	return r0, r1
 }
 
-// MockQuerier_UpdateEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEmployee'
-type MockQuerier_UpdateEmployee_Call struct {
+// MockQuerier_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type MockQuerier_UseRecoveryCode_Call struct {
 	*mock.Call
 }
 
-// UpdateEmployee is a helper method to define mock.On call
+// UseRecoveryCode is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg UpdateEmployeeParams
-func (_e *MockQuerier_Expecter) UpdateEmployee(ctx any, arg any) *MockQuerier_UpdateEmployee_Call {
-	return &MockQuerier_UpdateEmployee_Call{Call: _e.mock.On("UpdateEmployee", ctx, arg)}
+//   - arg UseRecoveryCodeParams
+func (_e *MockQuerier_Expecter) UseRecoveryCode(ctx any, arg any) *MockQuerier_UseRecoveryCode_Call {
+	return &MockQuerier_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, arg)}
 }
 
-func (_c *MockQuerier_UpdateEmployee_Call) Run(run func(ctx context.Context, arg UpdateEmployeeParams)) *MockQuerier_UpdateEmployee_Call {
+func (_c *MockQuerier_UseRecoveryCode_Call) Run(run func(ctx context.Context, arg UseRecoveryCodeParams)) *MockQuerier_UseRecoveryCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 UpdateEmployeeParams
+		var arg1 UseRecoveryCodeParams
 		if args[1] != nil {
-			arg1 = args[1].(UpdateEmployeeParams)
+			arg1 = args[1].(UseRecoveryCodeParams)
 		}
 		run(
 			arg0,
@@ -929,35 +3727,35 @@ func (_c *MockQuerier_UpdateEmployee_Call) Run(run func(ctx context.Context, arg
 	return _c
 }
 
-func (_c *MockQuerier_UpdateEmployee_Call) Return(employee Employee, err error) *MockQuerier_UpdateEmployee_Call {
-	_c.Call.Return(employee, err)
+func (_c *MockQuerier_UseRecoveryCode_Call) Return(n int64, err error) *MockQuerier_UseRecoveryCode_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateEmployee_Call) RunAndReturn(run func(ctx context.Context, arg UpdateEmployeeParams) (Employee, error)) *MockQuerier_UpdateEmployee_Call {
+func (_c *MockQuerier_UseRecoveryCode_Call) RunAndReturn(run func(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)) *MockQuerier_UseRecoveryCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUser provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
+// UseTOTPStep provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (int64, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUser")
+		panic("no return value specified for UseTOTPStep")
 	}
 
-	var r0 User
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateUserParams) (User, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UseTOTPStepParams) (int64, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, UpdateUserParams) User); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, UseTOTPStepParams) int64); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(User)
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, UpdateUserParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, UseTOTPStepParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -965,27 +3763,27 @@ func (_mock *MockQuerier) UpdateUser(ctx context.Context, arg UpdateUserParams)
 	return r0, r1
 }
 
-// MockQuerier_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
-type MockQuerier_UpdateUser_Call struct {
+// MockQuerier_UseTOTPStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseTOTPStep'
+type MockQuerier_UseTOTPStep_Call struct {
 	*mock.Call
 }
 
-// UpdateUser is a helper method to define mock.On call
+// UseTOTPStep is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg UpdateUserParams
-func (_e *MockQuerier_Expecter) UpdateUser(ctx any, arg any) *MockQuerier_UpdateUser_Call {
-	return &MockQuerier_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, arg)}
+//   - arg UseTOTPStepParams
+func (_e *MockQuerier_Expecter) UseTOTPStep(ctx any, arg any) *MockQuerier_UseTOTPStep_Call {
+	return &MockQuerier_UseTOTPStep_Call{Call: _e.mock.On("UseTOTPStep", ctx, arg)}
 }
 
-func (_c *MockQuerier_UpdateUser_Call) Run(run func(ctx context.Context, arg UpdateUserParams)) *MockQuerier_UpdateUser_Call {
+func (_c *MockQuerier_UseTOTPStep_Call) Run(run func(ctx context.Context, arg UseTOTPStepParams)) *MockQuerier_UseTOTPStep_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 UpdateUserParams
+		var arg1 UseTOTPStepParams
 		if args[1] != nil {
-			arg1 = args[1].(UpdateUserParams)
+			arg1 = args[1].(UseTOTPStepParams)
 		}
 		run(
 			arg0,
@@ -995,12 +3793,12 @@ func (_c *MockQuerier_UpdateUser_Call) Run(run func(ctx context.Context, arg Upd
 	return _c
 }
 
-func (_c *MockQuerier_UpdateUser_Call) Return(user User, err error) *MockQuerier_UpdateUser_Call {
-	_c.Call.Return(user, err)
+func (_c *MockQuerier_UseTOTPStep_Call) Return(n int64, err error) *MockQuerier_UseTOTPStep_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, arg UpdateUserParams) (User, error)) *MockQuerier_UpdateUser_Call {
+func (_c *MockQuerier_UseTOTPStep_Call) RunAndReturn(run func(ctx context.Context, arg UseTOTPStepParams) (int64, error)) *MockQuerier_UseTOTPStep_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -11,17 +11,60 @@ import (
 )
 
 type Querier interface {
+	CountEmployees(ctx context.Context, arg CountEmployeesParams) (int64, error)
+	CountUsers(ctx context.Context, emailDomain pgtype.Text) (int64, error)
 	CreateEmployee(ctx context.Context, arg CreateEmployeeParams) (Employee, error)
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteEmployee(ctx context.Context, id pgtype.UUID) error
+	DeleteEmployee(ctx context.Context, id pgtype.UUID) (int64, error)
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
+	DeleteUserRecoveryCodes(ctx context.Context, userID pgtype.UUID) error
+	DeleteUserTOTP(ctx context.Context, userID pgtype.UUID) error
+	EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (int64, error)
+	EstimateEmployeesCount(ctx context.Context) (int64, error)
+	EstimateUsersCount(ctx context.Context) (int64, error)
 	GetEmployee(ctx context.Context, id pgtype.UUID) (Employee, error)
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error)
 	GetUser(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
+	GetUserTOTP(ctx context.Context, userID pgtype.UUID) (UserTotp, error)
+	InvalidateUserPasswordResetTokens(ctx context.Context, userID pgtype.UUID) error
+	ListDepartments(ctx context.Context) ([]string, error)
 	ListEmployees(ctx context.Context) ([]Employee, error)
+	ListEmployeesByDepartment(ctx context.Context, arg ListEmployeesByDepartmentParams) ([]Employee, error)
+	ListEmployeesByDepartmentDesc(ctx context.Context, arg ListEmployeesByDepartmentDescParams) ([]Employee, error)
+	ListEmployeesByEmail(ctx context.Context, arg ListEmployeesByEmailParams) ([]Employee, error)
+	ListEmployeesByEmailDesc(ctx context.Context, arg ListEmployeesByEmailDescParams) ([]Employee, error)
+	ListEmployeesByFirstName(ctx context.Context, arg ListEmployeesByFirstNameParams) ([]Employee, error)
+	ListEmployeesByFirstNameDesc(ctx context.Context, arg ListEmployeesByFirstNameDescParams) ([]Employee, error)
+	ListEmployeesByLastName(ctx context.Context, arg ListEmployeesByLastNameParams) ([]Employee, error)
+	ListEmployeesByLastNameDesc(ctx context.Context, arg ListEmployeesByLastNameDescParams) ([]Employee, error)
+	ListEmployeesByName(ctx context.Context, arg ListEmployeesByNameParams) ([]Employee, error)
+	ListEmployeesByNameDesc(ctx context.Context, arg ListEmployeesByNameDescParams) ([]Employee, error)
+	ListRolePermissions(ctx context.Context, role string) ([]string, error)
+	ListRoles(ctx context.Context) ([]string, error)
 	ListUsers(ctx context.Context) ([]User, error)
+	ListUsersByEmail(ctx context.Context, arg ListUsersByEmailParams) ([]User, error)
+	ListUsersByEmailDesc(ctx context.Context, arg ListUsersByEmailDescParams) ([]User, error)
+	ListUsersByName(ctx context.Context, arg ListUsersByNameParams) ([]User, error)
+	ListUsersByNameDesc(ctx context.Context, arg ListUsersByNameDescParams) ([]User, error)
+	ListUsersByUsername(ctx context.Context, arg ListUsersByUsernameParams) ([]User, error)
+	ListUsersByUsernameDesc(ctx context.Context, arg ListUsersByUsernameDescParams) ([]User, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error
+	RevokeUserRefreshTokens(ctx context.Context, userID pgtype.UUID) error
+	RotateRefreshToken(ctx context.Context, arg RotateRefreshTokenParams) (int64, error)
 	UpdateEmployee(ctx context.Context, arg UpdateEmployeeParams) (Employee, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpsertUserTOTP(ctx context.Context, arg UpsertUserTOTPParams) (UserTotp, error)
+	UsePasswordResetToken(ctx context.Context, id pgtype.UUID) (int64, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
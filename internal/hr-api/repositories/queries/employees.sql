@@ -17,6 +17,166 @@ SET first_name = $2, last_name = $3, email = $4, department = $5
 WHERE id = $1
 RETURNING *;
 
--- name: DeleteEmployee :exec
+-- name: DeleteEmployee :execrows
 DELETE FROM employees
 WHERE id = $1;
+
+
+-- name: ListEmployeesByName :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (last_name || ' ' || first_name, id) > (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY last_name || ' ' || first_name, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEmployeesByNameDesc :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (last_name || ' ' || first_name, id) < (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY last_name || ' ' || first_name DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEmployeesByFirstName :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (first_name, id) > (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY first_name, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEmployeesByFirstNameDesc :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (first_name, id) < (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY first_name DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEmployeesByLastName :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (last_name, id) > (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY last_name, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEmployeesByLastNameDesc :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (last_name, id) < (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY last_name DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEmployeesByEmail :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (email, id) > (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY email, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEmployeesByEmailDesc :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (email, id) < (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY email DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEmployeesByDepartment :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (department, id) > (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY department, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEmployeesByDepartmentDesc :many
+SELECT * FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  )
+  AND (sqlc.narg(after_key)::text IS NULL OR (department, id) < (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY department DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: CountEmployees :one
+SELECT count(*) FROM employees
+WHERE (sqlc.narg(department)::text IS NULL OR lower(department) = lower(sqlc.narg(department)::text))
+  AND (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (
+    sqlc.narg(search)::text IS NULL
+    OR first_name || ' ' || last_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR last_name || ' ' || first_name ILIKE '%' || sqlc.narg(search)::text || '%'
+    OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+  );
+
+-- name: ListDepartments :many
+SELECT DISTINCT department FROM employees
+ORDER BY department;
+
+-- name: EstimateEmployeesCount :one
+SELECT reltuples::bigint AS estimate FROM pg_catalog.pg_class
+WHERE oid = 'employees'::regclass;
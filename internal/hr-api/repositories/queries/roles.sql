@@ -0,0 +1,8 @@
+-- name: ListRolePermissions :many
+SELECT permission FROM role_permissions
+WHERE role = $1
+ORDER BY permission;
+
+-- name: ListRoles :many
+SELECT name FROM roles
+ORDER BY name;
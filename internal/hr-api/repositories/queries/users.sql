@@ -11,6 +11,10 @@ WHERE id = $1 LIMIT 1;
 SELECT * FROM users
 WHERE username = $1 LIMIT 1;
 
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = $1 LIMIT 1;
+
 -- name: ListUsers :many
 SELECT * FROM users
 ORDER BY name;
@@ -21,6 +25,68 @@ SET name = $2, email = $3, username = $4, password = $5
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $2
+WHERE id = $1;
+
 -- name: DeleteUser :exec
 DELETE FROM users
 WHERE id = $1;
+
+
+-- name: ListUsersByName :many
+SELECT * FROM users
+WHERE (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (sqlc.narg(after_key)::text IS NULL OR (name, id) > (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY name, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListUsersByNameDesc :many
+SELECT * FROM users
+WHERE (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (sqlc.narg(after_key)::text IS NULL OR (name, id) < (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY name DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListUsersByEmail :many
+SELECT * FROM users
+WHERE (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (sqlc.narg(after_key)::text IS NULL OR (email, id) > (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY email, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListUsersByEmailDesc :many
+SELECT * FROM users
+WHERE (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (sqlc.narg(after_key)::text IS NULL OR (email, id) < (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY email DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListUsersByUsername :many
+SELECT * FROM users
+WHERE (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (sqlc.narg(after_key)::text IS NULL OR (username, id) > (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY username, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListUsersByUsernameDesc :many
+SELECT * FROM users
+WHERE (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text))
+  AND (sqlc.narg(after_key)::text IS NULL OR (username, id) < (sqlc.narg(after_key)::text, sqlc.arg(after_id)::uuid))
+ORDER BY username DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: CountUsers :one
+SELECT count(*) FROM users
+WHERE (sqlc.narg(email_domain)::text IS NULL OR split_part(lower(email), '@', 2) = lower(sqlc.narg(email_domain)::text));
+
+-- name: EstimateUsersCount :one
+SELECT reltuples::bigint AS estimate FROM pg_catalog.pg_class
+WHERE oid = 'users'::regclass;
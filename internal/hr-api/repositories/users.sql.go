@@ -11,10 +11,22 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countUsers = `-- name: CountUsers :one
+SELECT count(*) FROM users
+WHERE ($1::text IS NULL OR split_part(lower(email), '@', 2) = lower($1::text))
+`
+
+func (q *Queries) CountUsers(ctx context.Context, emailDomain pgtype.Text) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers, emailDomain)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (id, name, email, username, password)
 VALUES ($1, $2, $3, $4, $5)
-RETURNING id, name, email, username, password
+RETURNING id, name, email, username, password, role
 `
 
 type CreateUserParams struct {
@@ -40,6 +52,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.Username,
 		&i.Password,
+		&i.Role,
 	)
 	return i, err
 }
@@ -54,8 +67,20 @@ func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const estimateUsersCount = `-- name: EstimateUsersCount :one
+SELECT reltuples::bigint AS estimate FROM pg_catalog.pg_class
+WHERE oid = 'users'::regclass
+`
+
+func (q *Queries) EstimateUsersCount(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, estimateUsersCount)
+	var estimate int64
+	err := row.Scan(&estimate)
+	return estimate, err
+}
+
 const getUser = `-- name: GetUser :one
-SELECT id, name, email, username, password FROM users
+SELECT id, name, email, username, password, role FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -68,12 +93,32 @@ func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
 		&i.Email,
 		&i.Username,
 		&i.Password,
+		&i.Role,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, name, email, username, password, role FROM users
+WHERE email = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Username,
+		&i.Password,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, name, email, username, password FROM users
+SELECT id, name, email, username, password, role FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -86,12 +131,13 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.Email,
 		&i.Username,
 		&i.Password,
+		&i.Role,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, name, email, username, password FROM users
+SELECT id, name, email, username, password, role FROM users
 ORDER BY name
 `
 
@@ -110,6 +156,289 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 			&i.Email,
 			&i.Username,
 			&i.Password,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByEmail = `-- name: ListUsersByEmail :many
+SELECT id, name, email, username, password, role FROM users
+WHERE ($1::text IS NULL OR split_part(lower(email), '@', 2) = lower($1::text))
+  AND ($2::text IS NULL OR (email, id) > ($2::text, $3::uuid))
+ORDER BY email, id
+LIMIT $4
+`
+
+type ListUsersByEmailParams struct {
+	EmailDomain pgtype.Text `json:"email_domain"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListUsersByEmail(ctx context.Context, arg ListUsersByEmailParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByEmail,
+		arg.EmailDomain,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Username,
+			&i.Password,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByEmailDesc = `-- name: ListUsersByEmailDesc :many
+SELECT id, name, email, username, password, role FROM users
+WHERE ($1::text IS NULL OR split_part(lower(email), '@', 2) = lower($1::text))
+  AND ($2::text IS NULL OR (email, id) < ($2::text, $3::uuid))
+ORDER BY email DESC, id DESC
+LIMIT $4
+`
+
+type ListUsersByEmailDescParams struct {
+	EmailDomain pgtype.Text `json:"email_domain"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListUsersByEmailDesc(ctx context.Context, arg ListUsersByEmailDescParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByEmailDesc,
+		arg.EmailDomain,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Username,
+			&i.Password,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByName = `-- name: ListUsersByName :many
+SELECT id, name, email, username, password, role FROM users
+WHERE ($1::text IS NULL OR split_part(lower(email), '@', 2) = lower($1::text))
+  AND ($2::text IS NULL OR (name, id) > ($2::text, $3::uuid))
+ORDER BY name, id
+LIMIT $4
+`
+
+type ListUsersByNameParams struct {
+	EmailDomain pgtype.Text `json:"email_domain"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListUsersByName(ctx context.Context, arg ListUsersByNameParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByName,
+		arg.EmailDomain,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Username,
+			&i.Password,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByNameDesc = `-- name: ListUsersByNameDesc :many
+SELECT id, name, email, username, password, role FROM users
+WHERE ($1::text IS NULL OR split_part(lower(email), '@', 2) = lower($1::text))
+  AND ($2::text IS NULL OR (name, id) < ($2::text, $3::uuid))
+ORDER BY name DESC, id DESC
+LIMIT $4
+`
+
+type ListUsersByNameDescParams struct {
+	EmailDomain pgtype.Text `json:"email_domain"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListUsersByNameDesc(ctx context.Context, arg ListUsersByNameDescParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByNameDesc,
+		arg.EmailDomain,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Username,
+			&i.Password,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByUsername = `-- name: ListUsersByUsername :many
+SELECT id, name, email, username, password, role FROM users
+WHERE ($1::text IS NULL OR split_part(lower(email), '@', 2) = lower($1::text))
+  AND ($2::text IS NULL OR (username, id) > ($2::text, $3::uuid))
+ORDER BY username, id
+LIMIT $4
+`
+
+type ListUsersByUsernameParams struct {
+	EmailDomain pgtype.Text `json:"email_domain"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListUsersByUsername(ctx context.Context, arg ListUsersByUsernameParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByUsername,
+		arg.EmailDomain,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Username,
+			&i.Password,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByUsernameDesc = `-- name: ListUsersByUsernameDesc :many
+SELECT id, name, email, username, password, role FROM users
+WHERE ($1::text IS NULL OR split_part(lower(email), '@', 2) = lower($1::text))
+  AND ($2::text IS NULL OR (username, id) < ($2::text, $3::uuid))
+ORDER BY username DESC, id DESC
+LIMIT $4
+`
+
+type ListUsersByUsernameDescParams struct {
+	EmailDomain pgtype.Text `json:"email_domain"`
+	AfterKey    pgtype.Text `json:"after_key"`
+	AfterID     pgtype.UUID `json:"after_id"`
+	PageSize    int32       `json:"page_size"`
+}
+
+func (q *Queries) ListUsersByUsernameDesc(ctx context.Context, arg ListUsersByUsernameDescParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByUsernameDesc,
+		arg.EmailDomain,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Username,
+			&i.Password,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -125,7 +454,7 @@ const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET name = $2, email = $3, username = $4, password = $5
 WHERE id = $1
-RETURNING id, name, email, username, password
+RETURNING id, name, email, username, password, role
 `
 
 type UpdateUserParams struct {
@@ -151,6 +480,49 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Email,
 		&i.Username,
 		&i.Password,
+		&i.Role,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $2
+WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID       pgtype.UUID `json:"id"`
+	Password string      `json:"password"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.Password)
+	return err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2
+WHERE id = $1
+RETURNING id, name, email, username, password, role
+`
+
+type UpdateUserRoleParams struct {
+	ID   pgtype.UUID `json:"id"`
+	Role string      `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.ID, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Username,
+		&i.Password,
+		&i.Role,
 	)
 	return i, err
 }
@@ -1,24 +1,66 @@
 package routes
 
 import (
-	"web-boilerplate/internal/hr-api/db"
+	"time"
 	"web-boilerplate/internal/hr-api/handlers"
 	"web-boilerplate/internal/hr-api/middlewares"
+	"web-boilerplate/internal/hr-api/pkg/ratelimit"
 
 	"github.com/gofiber/fiber/v3"
-	"github.com/rs/zerolog"
 )
 
-func SetupRoutes(app *fiber.App, log *zerolog.Logger, db *db.Database) {
+// SetupRoutes mounts the handlers of h, limits counts the requests of the rate limited routes
+func SetupRoutes(app *fiber.App, h *handlers.Handler, limits ratelimit.Store) {
 	v1 := app.Group("/v1")
 
-	// Initialize handlers
-	h := handlers.New(log, db)
+	protected := middlewares.Protected(h.Revocations, h.Keys)
 
+	// Rate limits, anonymous routes are counted per client IP, see config.ProxyHeader, and the others per user
+	authLimit := middlewares.RateLimit(limits, middlewares.RateLimitPolicy{
+		Name: "auth", Limit: 20, Window: time.Minute,
+	})
+	passwordResetLimit := middlewares.RateLimit(limits, middlewares.RateLimitPolicy{
+		Name: "password_reset", Limit: 5, Window: 15 * time.Minute,
+	})
+	userLimit := middlewares.RateLimit(limits, middlewares.RateLimitPolicy{
+		Name: "user", Limit: 300, Window: time.Minute, Key: middlewares.RateLimitByUser,
+	})
+
+	app.Get("/.well-known/jwks.json", h.JWKS)
+	app.Get("/livez", h.Livez)
+	app.Get("/readyz", h.Readyz)
 	v1.Get("/health", h.Health)
 
-	v1.Post("/login", h.Login)
+	v1.Post("/login", authLimit, h.Login)
+	v1.Post("/login/2fa", authLimit, h.LoginTOTP)
+	v1.Post("/token/refresh", authLimit, h.RefreshToken)
+	v1.Post("/password/reset", passwordResetLimit, h.RequestPasswordReset)
+	v1.Post("/password/reset/confirm", passwordResetLimit, h.ConfirmPasswordReset)
 
 	// Protected routes
-	v1.Get("/me", middlewares.Protected, h.GetMe)
+	v1.Get("/me", protected, userLimit, h.GetMe)
+	v1.Post("/logout", protected, userLimit, h.Logout)
+	v1.Post("/logout/all", protected, userLimit, h.LogoutAll)
+
+	twoFactor := v1.Group("/2fa", protected, userLimit)
+	twoFactor.Post("/enroll", h.EnrollTOTP)
+	twoFactor.Post("/activate", h.ActivateTOTP)
+	twoFactor.Post("/disable", h.DisableTOTP)
+
+	users := v1.Group("/users", protected, userLimit)
+	users.Get("/", middlewares.RequirePermission(middlewares.PermUsersRead), h.ListUsers)
+	users.Put("/:id/role", middlewares.RequirePermission(middlewares.PermUsersWrite), h.UpdateUserRole)
+	users.Post("/:id/unlock", middlewares.RequirePermission(middlewares.PermUsersWrite), h.UnlockUser)
+
+	employeesRead := middlewares.RequirePermission(middlewares.PermEmployeesRead)
+	employeesWrite := middlewares.RequirePermission(middlewares.PermEmployeesWrite)
+
+	employees := v1.Group("/employees", protected, userLimit)
+	employees.Get("/", employeesRead, h.ListEmployees)
+	employees.Post("/", employeesWrite, h.CreateEmployee)
+	employees.Get("/:id", employeesRead, h.GetEmployee)
+	employees.Put("/:id", employeesWrite, h.UpdateEmployee)
+	employees.Delete("/:id", employeesWrite, h.DeleteEmployee)
+
+	v1.Get("/departments", protected, userLimit, employeesRead, h.ListDepartments)
 }
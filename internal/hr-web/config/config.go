@@ -1,113 +1,70 @@
 package config
 
 import (
-	"fmt"
-	"os"
 	"time"
-
-	"github.com/joho/godotenv"
+	sharedconfig "web-boilerplate/shared/config"
+	"web-boilerplate/shared/tracing"
 )
 
-type LOG_LEVEL_TYPE int8
+// Config holds every setting of hr-web, see Default for the values used when a setting is unset
+type Config struct {
+	sharedconfig.Common `yaml:",inline"`
 
-const (
-	LOG_LEVEL_NOTFOUND LOG_LEVEL_TYPE = iota - 1
-	LOG_LEVEL_INFO
-	LOG_LEVEL_WARN
-	LOG_LEVEL_DEBUG
-	LOG_LEVEL_ERROR
-	LOG_LEVEL_FATAL
-)
+	APIURL    string `yaml:"api_url" env:"API_URL"`
+	SecretKey string `yaml:"secret_key" env:"SECRET_KEY"`
 
-var (
-	IS_PROD         = false
-	LOG_LEVEL       = LOG_LEVEL_DEBUG
-	SECRET_KEY      = "qweasd123"
-	ALLOWED_ORIGINS = ""
-	REDIS_KEYS_TTL  = time.Hour * 24 * 7 // 7 days
-	TOKEN_TTL       = time.Hour * 5      // 5 hours
-	BASE_URL        = "localhost:4000"
-	API_URL         = "localhost:3000"
-)
+	// SessionTTL is how long the session cookies are kept, it should match the refresh token TTL of hr-api
+	SessionTTL   time.Duration `yaml:"session_ttl" env:"SESSION_EXPIRE_TIME"`
+	JWKSCacheTTL time.Duration `yaml:"jwks_cache_ttl" env:"JWKS_CACHE_TTL"`
+}
 
-func LoadAllConfig() error {
-	// Load env file
-	err := LoadEnvFile()
-	if err != nil {
-		return err
+func Default() Config {
+	return Config{
+		Common: sharedconfig.Common{
+			LogLevel:           "info",
+			BaseURL:            "localhost:4000",
+			RedisKeysTTL:       time.Hour * 24 * 7, // 7 days
+			ShutdownTimeout:    time.Second * 30,   // 30 seconds
+			HealthCacheTTL:     time.Second * 5,    // 5 seconds
+			HealthCheckTimeout: time.Second * 2,    // 2 seconds
+			TracingExporter:    tracing.ExporterNone,
+		},
+		APIURL:       "http://localhost:3000",
+		SecretKey:    sharedconfig.DefaultSecretKey,
+		SessionTTL:   time.Hour * 24 * 7, // 7 days
+		JWKSCacheTTL: time.Hour,          // 1 hour
 	}
+}
 
-	LOG_LEVEL, err = determineLogLevel(os.Getenv("LOG_LEVEL"))
-	if err != nil {
-		return err
+// Load reads the config from the environment, .env and the optional CONFIG_FILE over the defaults
+func Load() (*Config, error) {
+	cfg := Default()
+	if err := sharedconfig.Load(&cfg, ".env", "cmd/hrapp-web/.env"); err != nil {
+		return nil, err
 	}
+	return &cfg, nil
+}
 
-	BASE_URL = os.Getenv("BASE_URL")
-	API_URL = os.Getenv("API_URL")
-	IS_PROD = os.Getenv("IS_PROD") == "true"
-
-	SECRET_KEY = os.Getenv("SECRET_KEY")
+func (c *Config) Validate() error {
+	errs := &sharedconfig.ValidationError{}
+	c.Common.Validate(errs)
 
-	expireRaw := os.Getenv("TOKEN_EXPIRE_TIME")
-	if expireRaw != "" {
-		TOKEN_TTL, err = time.ParseDuration(expireRaw)
-		if err != nil {
-			return fmt.Errorf("error parsing token expire time duration, %w", err)
-		}
-	} else {
-		TOKEN_TTL = time.Hour * 5
+	if c.APIURL == "" {
+		errs.Add("API_URL", "must be set")
 	}
-
-	ALLOWED_ORIGINS = os.Getenv("ALLOWED_ORIGINS")
-	if len(ALLOWED_ORIGINS) == 0 {
-		ALLOWED_ORIGINS = "*"
+	if c.SecretKey == "" {
+		errs.Add("SECRET_KEY", "must be set")
 	}
-
-	REDIS_KEYS_TTL, err = time.ParseDuration(os.Getenv("REDIS_KEYS_TTL"))
-	if err != nil {
-		REDIS_KEYS_TTL = time.Hour * 24 * 7
+	// the development secret is public, sessions signed with it could be forged
+	if c.IsProd && c.SecretKey == sharedconfig.DefaultSecretKey {
+		errs.Add("SECRET_KEY", "must not be the default secret in production environment")
 	}
-
-	return nil
-}
-func LoadEnvFile() error {
-	paths := []string{".env", "cmd/hrapp-web/.env"}
-	for _, path := range paths {
-		if _, err := os.Stat(path); !os.IsNotExist(err) {
-			err := godotenv.Load(path)
-			if err != nil {
-				return err
-			}
-			fmt.Printf("Loaded .env from: %s\n", path)
-			return nil
-		}
+	if c.SessionTTL <= 0 {
+		errs.Add("SESSION_EXPIRE_TIME", "must be a positive duration")
 	}
-	return nil
-}
-
-// func SetupCorsConfig() cors.Config {
-// 	return cors.Config{
-// 		AllowCredentials: true,
-// 		AllowOrigins:     []string{"*"},
-// 		AllowHeaders:     []string{"Origin", " Content-Type", " Accept", " Accept-Language", " Content-Length"},
-// 	}
-// }
-
-func determineLogLevel(logLevel string) (LOG_LEVEL_TYPE, error) {
-	switch logLevel {
-	case "":
-		return LOG_LEVEL_DEBUG, nil
-	case "info":
-		return LOG_LEVEL_INFO, nil
-	case "warn":
-		return LOG_LEVEL_WARN, nil
-	case "debug":
-		return LOG_LEVEL_DEBUG, nil
-	case "error":
-		return LOG_LEVEL_ERROR, nil
-	case "fatal":
-		return LOG_LEVEL_FATAL, nil
-	default:
-		return LOG_LEVEL_NOTFOUND, fmt.Errorf("invalid log level")
+	if c.JWKSCacheTTL <= 0 {
+		errs.Add("JWKS_CACHE_TTL", "must be a positive duration")
 	}
+
+	return errs.Err()
 }
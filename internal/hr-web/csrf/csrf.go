@@ -0,0 +1,118 @@
+// Package csrf protects the form posts of hr-web against cross-site request forgery
+// with signed double-submit tokens.
+//
+// Every browser gets a random key in an HttpOnly cookie, renewed when a session starts or ends.
+// The token sent back with unsafe requests, in the FieldName form field or the HeaderName header,
+// is the HMAC of that key with the secret of hr-web. Tokens are thus tied to the session, and
+// a key planted in the cookie by another site is useless without the secret.
+package csrf
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"time"
+	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/shared/helpers"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	// CookieName holds the key the tokens are derived from
+	CookieName = "csrf_key"
+	// FieldName is the form field carrying the token, see components.CSRFField
+	FieldName = "_csrf"
+	// HeaderName carries the token of fetch calls
+	HeaderName = "X-CSRF-Token"
+
+	// keyBytes is the amount of entropy in a key
+	keyBytes = 32
+)
+
+var (
+	ErrMissingToken = errors.New("missing csrf token")
+	ErrInvalidToken = errors.New("invalid csrf token")
+)
+
+type tokenKey struct{}
+
+// Protector issues and verifies the tokens
+type Protector struct {
+	secret []byte
+	ttl    time.Duration
+	secure bool
+}
+
+func New(cfg *config.Config) *Protector {
+	return &Protector{
+		secret: []byte(cfg.SecretKey),
+		ttl:    cfg.SessionTTL,
+		secure: cfg.IsProd,
+	}
+}
+
+// Issue makes the token of the request available to the templ components,
+// a key is created for browsers that don't have one yet
+func (p *Protector) Issue(c fiber.Ctx) error {
+	key := c.Cookies(CookieName)
+	if key == "" {
+		return p.Renew(c)
+	}
+
+	c.Locals(tokenKey{}, p.token(key))
+	return nil
+}
+
+// Renew replaces the key of the browser, invalidating the tokens of the forms it has open.
+// It is called when a session starts or ends.
+func (p *Protector) Renew(c fiber.Ctx) error {
+	key, err := helpers.GenerateToken(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     CookieName,
+		Value:    key,
+		Path:     "/",
+		Expires:  time.Now().Add(p.ttl),
+		Secure:   p.secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	c.Locals(tokenKey{}, p.token(key))
+	return nil
+}
+
+// Verify checks the token sent with the request against the key cookie
+func (p *Protector) Verify(c fiber.Ctx) error {
+	sent := c.Get(HeaderName)
+	if sent == "" {
+		sent = c.FormValue(FieldName)
+	}
+	key := c.Cookies(CookieName)
+	if sent == "" || key == "" {
+		return ErrMissingToken
+	}
+
+	if !hmac.Equal([]byte(sent), []byte(p.token(key))) {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func (p *Protector) token(key string) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte("csrf:" + key))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Token returns the token of the request, ctx is the fiber.Ctx of the request or the context
+// a templ component is rendered with
+func Token(ctx context.Context) string {
+	token, _ := ctx.Value(tokenKey{}).(string)
+	return token
+}
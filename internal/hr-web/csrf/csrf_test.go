@@ -0,0 +1,129 @@
+package csrf
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"web-boilerplate/internal/hr-web/config"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProtector() *Protector {
+	cfg := config.Default()
+	return New(&cfg)
+}
+
+// verify runs Verify on a post carrying the key cookie, the header token and the form token when set
+func verify(t *testing.T, p *Protector, key, header, field string) error {
+	var verifyErr error
+	app := fiber.New()
+	app.Post("/", func(c fiber.Ctx) error {
+		verifyErr = p.Verify(c)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{FieldName: {field}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if key != "" {
+		req.AddCookie(&http.Cookie{Name: CookieName, Value: key})
+	}
+	if header != "" {
+		req.Header.Set(HeaderName, header)
+	}
+	_, err := app.Test(req)
+	require.NoError(t, err)
+	return verifyErr
+}
+
+func TestProtector_Verify(t *testing.T) {
+	p := newTestProtector()
+	token := p.token("key-1")
+
+	tests := []struct {
+		name   string
+		key    string
+		header string
+		field  string
+		err    error
+	}{
+		{"form field", "key-1", "", token, nil},
+		{"header", "key-1", token, "", nil},
+		{"header wins over the form field", "key-1", token, "stale", nil},
+		{"missing cookie", "", token, "", ErrMissingToken},
+		{"missing token", "key-1", "", "", ErrMissingToken},
+		{"mismatched token", "key-1", "", "forged", ErrInvalidToken},
+		{"token of another session", "key-2", "", token, ErrInvalidToken},
+		{"mismatched header", "key-1", "forged", token, ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, verify(t, p, tt.key, tt.header, tt.field))
+		})
+	}
+}
+
+func TestProtector_TokenDependsOnSecret(t *testing.T) {
+	cfg := config.Default()
+	cfg.SecretKey = "another secret"
+	other := New(&cfg)
+
+	// a key planted in the cookie is useless without the secret
+	assert.Error(t, verify(t, newTestProtector(), "key-1", other.token("key-1"), ""))
+}
+
+func TestProtector_IssueAndRenew(t *testing.T) {
+	p := newTestProtector()
+	app := fiber.New()
+	app.Get("/issue", func(c fiber.Ctx) error {
+		require.NoError(t, p.Issue(c))
+		return c.SendString(Token(c))
+	})
+	app.Get("/renew", func(c fiber.Ctx) error {
+		require.NoError(t, p.Renew(c))
+		return c.SendString(Token(c))
+	})
+
+	send := func(path, key string) (*http.Cookie, string) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if key != "" {
+			req.AddCookie(&http.Cookie{Name: CookieName, Value: key})
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		body := new(strings.Builder)
+		_, err = io.Copy(body, resp.Body)
+		require.NoError(t, err)
+
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == CookieName {
+				return cookie, body.String()
+			}
+		}
+		return nil, body.String()
+	}
+
+	// a browser without a key gets one, the page carries its token
+	cookie, token := send("/issue", "")
+	require.NotNil(t, cookie)
+	assert.True(t, cookie.HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+	assert.Equal(t, p.token(cookie.Value), token)
+
+	// the key is kept while it is there
+	kept, token := send("/issue", cookie.Value)
+	assert.Nil(t, kept)
+	assert.Equal(t, p.token(cookie.Value), token)
+
+	// a session starting or ending replaces it
+	renewed, token := send("/renew", cookie.Value)
+	require.NotNil(t, renewed)
+	assert.NotEqual(t, cookie.Value, renewed.Value)
+	assert.Equal(t, p.token(renewed.Value), token)
+}
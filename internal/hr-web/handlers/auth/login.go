@@ -1,60 +1,141 @@
 package auth
 
 import (
-	"bytes"
-	"encoding/json"
-	"net/http"
-	"time"
+	"strings"
 	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/internal/hr-web/csrf"
+	"web-boilerplate/internal/hr-web/session"
+	"web-boilerplate/internal/hr-web/ui/pages"
+	"web-boilerplate/shared/hrapi"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/rs/zerolog"
 )
 
-var log *zerolog.Logger
+// homePath is where users land after signing in when no page asked for it
+const homePath = "/home"
 
-func InitLogger(logger *zerolog.Logger) {
-	log = logger
+type Handler struct {
+	Log      *zerolog.Logger
+	Config   *config.Config
+	Sessions *session.Manager
+	// CSRF keys are renewed with the session, the forms of the previous one stop working
+	CSRF *csrf.Protector
+	API  *hrapi.Client
 }
 
-// Login handles the POST authentication request
-func Login(c fiber.Ctx) error {
-	username := c.FormValue("username")
-	password := c.FormValue("password")
+func New(log *zerolog.Logger, cfg *config.Config, sessions *session.Manager, protector *csrf.Protector, api *hrapi.Client) *Handler {
+	return &Handler{
+		Log:      log,
+		Config:   cfg,
+		Sessions: sessions,
+		CSRF:     protector,
+		API:      api,
+	}
+}
 
-	payload := map[string]string{
-		"username": username,
-		"password": password,
+// LoginPage renders the login form, or sends signed in users where they were going
+func (h *Handler) LoginPage(c fiber.Ctx) error {
+	returnTo := session.ReturnTo(c.Query("return_to"), homePath)
+	if _, err := h.Sessions.Verify(c.Cookies(session.AccessCookie)); err == nil {
+		return c.Redirect().Status(fiber.StatusSeeOther).To(returnTo)
 	}
-	jsonPayload, err := json.Marshal(payload)
+
+	return h.renderLogin(c, fiber.StatusOK, returnTo, "")
+}
+
+// Login handles the POST authentication request
+func (h *Handler) Login(c fiber.Ctx) error {
+	returnTo := session.ReturnTo(c.FormValue("return_to"), homePath)
+
+	result, err := h.API.Login(c.Context(), hrapi.LoginParams{
+		Username: c.FormValue("username"),
+		Password: c.FormValue("password"),
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).SendString("Internal Server Error")
+		apiErr, ok := hrapi.AsError(err)
+		switch {
+		case ok && apiErr.Status == fiber.StatusTooManyRequests:
+			return h.renderLogin(c, fiber.StatusTooManyRequests, returnTo, "Too many failed attempts, please try again later")
+		case ok && (apiErr.Status == fiber.StatusBadRequest || apiErr.Status == fiber.StatusUnauthorized):
+			return h.renderLogin(c, fiber.StatusUnauthorized, returnTo, "Invalid username or password")
+		}
+		h.Log.Error().Ctx(c.Context()).Err(err).Msg("failed to log in")
+		return h.renderLogin(c, fiber.StatusServiceUnavailable, returnTo, "The service is unavailable, please try again later")
+	}
+
+	if result.MFARequired {
+		return h.renderCode(c, fiber.StatusOK, returnTo, result.ChallengeToken, "")
 	}
 
-	resp, err := http.Post(config.API_URL+"/v1/login", "application/json", bytes.NewBuffer(jsonPayload))
+	return h.start(c, result.TokenResponse, returnTo)
+}
+
+// LoginTOTP handles the POST of the code asked to users with 2FA enabled, along with the
+// challenge token of their password step
+func (h *Handler) LoginTOTP(c fiber.Ctx) error {
+	returnTo := session.ReturnTo(c.FormValue("return_to"), homePath)
+	challenge := c.FormValue("challenge_token")
+	if challenge == "" {
+		return h.renderLogin(c, fiber.StatusUnauthorized, returnTo, "Please sign in with your password first")
+	}
+
+	tokens, err := h.API.LoginTOTP(c.Context(), hrapi.LoginTOTPParams{
+		ChallengeToken: challenge,
+		Code:           strings.TrimSpace(c.FormValue("code")),
+	})
 	if err != nil {
-		log.Error().Err(err).Str("url", config.API_URL+"/v1/login").Msg("error requesting backend")
-		return c.Status(fiber.StatusServiceUnavailable).SendString("Backend Service Unavailable")
+		apiErr, ok := hrapi.AsError(err)
+		switch {
+		// the challenge expired, was used, or was voided by a password reset since the password step
+		case ok && apiErr.Code == "invalid_challenge":
+			return h.renderLogin(c, fiber.StatusUnauthorized, returnTo, "Your sign in expired, please enter your password again")
+		case ok && apiErr.Status == fiber.StatusTooManyRequests:
+			return h.renderLogin(c, fiber.StatusTooManyRequests, returnTo, "Too many failed attempts, please try again later")
+		case ok && (apiErr.Status == fiber.StatusBadRequest || apiErr.Status == fiber.StatusUnauthorized):
+			return h.renderCode(c, fiber.StatusUnauthorized, returnTo, challenge, "Invalid code")
+		}
+		h.Log.Error().Ctx(c.Context()).Err(err).Msg("failed to log in with a code")
+		return h.renderCode(c, fiber.StatusServiceUnavailable, returnTo, challenge, "The service is unavailable, please try again later")
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return c.Status(fiber.StatusUnauthorized).SendString("Invalid Credentials")
+	return h.start(c, tokens, returnTo)
+}
+
+// start signs the user in with the tokens of hr-api and sends them where they were going
+func (h *Handler) start(c fiber.Ctx, tokens hrapi.TokenResponse, returnTo string) error {
+	h.Sessions.Start(c, tokens)
+	if err := h.CSRF.Renew(c); err != nil {
+		h.Log.Error().Ctx(c.Context()).Err(err).Msg("failed to renew csrf key")
 	}
 
-	var result map[string]any
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return c.Status(fiber.StatusInternalServerError).SendString("Failed to parse response")
+	return c.Redirect().Status(fiber.StatusSeeOther).To(returnTo)
+}
+
+// Logout revokes the tokens of the session in hr-api and removes the session cookies.
+// The cookies are removed even when hr-api can't be reached, the tokens then expire on their own.
+func (h *Handler) Logout(c fiber.Ctx) error {
+	err := h.API.WithToken(session.AccessToken(c)).Logout(c.Context(), c.Cookies(session.RefreshCookie))
+	if err != nil {
+		h.Log.Error().Ctx(c.Context()).Err(err).Msg("failed to revoke the session tokens")
 	}
 
-	// Set token as cookie
-	if token, ok := result["token"].(string); ok {
-		cookie := new(fiber.Cookie)
-		cookie.Name = "auth_token"
-		cookie.Value = token
-		cookie.Expires = time.Now().Add(24 * time.Hour)
-		c.Cookie(cookie)
+	h.Sessions.End(c)
+	if err := h.CSRF.Renew(c); err != nil {
+		h.Log.Error().Ctx(c.Context()).Err(err).Msg("failed to renew csrf key")
 	}
 
-	return c.Redirect().To("/home")
+	return c.Redirect().Status(fiber.StatusSeeOther).To("/")
+}
+
+func (h *Handler) renderLogin(c fiber.Ctx, status int, returnTo, message string) error {
+	c.Status(status)
+	c.RequestCtx().SetContentType("text/html")
+	return pages.Login(returnTo, message).Render(c, c.Response().BodyWriter())
+}
+
+func (h *Handler) renderCode(c fiber.Ctx, status int, returnTo, challenge, message string) error {
+	c.Status(status)
+	c.RequestCtx().SetContentType("text/html")
+	return pages.LoginCode(returnTo, challenge, message).Render(c, c.Response().BodyWriter())
 }
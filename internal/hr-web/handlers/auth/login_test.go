@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/internal/hr-web/csrf"
+	"web-boilerplate/internal/hr-web/session"
+	"web-boilerplate/shared/hrapi"
+	"web-boilerplate/shared/jwks"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTokens = hrapi.TokenResponse{Token: "access", RefreshToken: "refresh", ExpiresIn: 900, ID: "user-id"}
+
+// newTestApp serves the login routes against an hr-api answering with api
+func newTestApp(t *testing.T, api http.HandlerFunc) *fiber.App {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	cfg := config.Default()
+	client := hrapi.New(hrapi.Config{BaseURL: server.URL, MaxRetries: -1})
+	log := zerolog.Nop()
+	sessions := session.NewManager(&cfg, jwks.NewClient(server.URL+"/.well-known/jwks.json", time.Hour), client)
+	h := New(&log, &cfg, sessions, csrf.New(&cfg), client)
+
+	app := fiber.New()
+	app.Post("/v1/login", h.Login)
+	app.Post("/login/2fa", h.LoginTOTP)
+	return app
+}
+
+// problem answers with the problem document hr-api sends for a failure
+func problem(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"status": status, "code": code})
+}
+
+func postForm(t *testing.T, app *fiber.App, target string, form url.Values) (*http.Response, string) {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func sessionCookies(resp *http.Response) map[string]string {
+	cookies := map[string]string{}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == session.AccessCookie || cookie.Name == session.RefreshCookie {
+			cookies[cookie.Name] = cookie.Value
+		}
+	}
+	return cookies
+}
+
+func TestLogin_StartsSession(t *testing.T) {
+	app := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(hrapi.LoginResponse{TokenResponse: testTokens})
+	})
+
+	resp, _ := postForm(t, app, "/v1/login", url.Values{"username": {"ada"}, "password": {"secret"}, "return_to": {"/employees/new"}})
+
+	assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+	assert.Equal(t, "/employees/new", resp.Header.Get("Location"))
+	assert.Equal(t, map[string]string{session.AccessCookie: "access", session.RefreshCookie: "refresh"}, sessionCookies(resp))
+}
+
+func TestLogin_AsksForCodeOfTOTPUsers(t *testing.T) {
+	app := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(hrapi.LoginResponse{MFARequired: true, ChallengeToken: "challenge-1"})
+	})
+
+	resp, body := postForm(t, app, "/v1/login", url.Values{"username": {"ada"}, "password": {"secret"}, "return_to": {"/employees/new"}})
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `action="/login/2fa"`)
+	assert.Contains(t, body, `name="challenge_token" value="challenge-1"`)
+	assert.Contains(t, body, `name="return_to" value="/employees/new"`)
+	// not signed in until the code is checked
+	assert.Empty(t, sessionCookies(resp))
+}
+
+func TestLoginTOTP_StartsSession(t *testing.T) {
+	var sent hrapi.LoginTOTPParams
+	app := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/login/2fa", r.URL.Path)
+		json.NewDecoder(r.Body).Decode(&sent)
+		json.NewEncoder(w).Encode(testTokens)
+	})
+
+	resp, _ := postForm(t, app, "/login/2fa", url.Values{"challenge_token": {"challenge-1"}, "code": {" 287082 "}, "return_to": {"/employees/new"}})
+
+	assert.Equal(t, hrapi.LoginTOTPParams{ChallengeToken: "challenge-1", Code: "287082"}, sent)
+	assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+	assert.Equal(t, "/employees/new", resp.Header.Get("Location"))
+	assert.Equal(t, map[string]string{session.AccessCookie: "access", session.RefreshCookie: "refresh"}, sessionCookies(resp))
+}
+
+func TestLoginTOTP_Refused(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		code    string
+		want    int
+		message string
+		// codePage is the code form answered again, with the same challenge
+		codePage bool
+	}{
+		{"invalid code", http.StatusUnauthorized, "invalid_code", http.StatusUnauthorized, "Invalid code", true},
+		{"malformed code", http.StatusBadRequest, "validation_failed", http.StatusUnauthorized, "Invalid code", true},
+		{"expired challenge", http.StatusUnauthorized, "invalid_challenge", http.StatusUnauthorized, "Your sign in expired", false},
+		{"locked out", http.StatusTooManyRequests, "login_locked", http.StatusTooManyRequests, "Too many failed attempts", false},
+		{"hr-api down", http.StatusInternalServerError, "internal_error", http.StatusServiceUnavailable, "The service is unavailable", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+				problem(w, tt.status, tt.code)
+			})
+
+			resp, body := postForm(t, app, "/login/2fa", url.Values{"challenge_token": {"challenge-1"}, "code": {"000000"}, "return_to": {"/home"}})
+
+			assert.Equal(t, tt.want, resp.StatusCode)
+			assert.Contains(t, body, tt.message)
+			assert.Empty(t, sessionCookies(resp))
+			if tt.codePage {
+				assert.Contains(t, body, `name="challenge_token" value="challenge-1"`)
+			} else {
+				assert.Contains(t, body, `action="/v1/login"`)
+			}
+		})
+	}
+}
+
+func TestLoginTOTP_WithoutChallenge(t *testing.T) {
+	app := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("hr-api must not be called without a challenge")
+	})
+
+	resp, body := postForm(t, app, "/login/2fa", url.Values{"code": {"287082"}})
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Contains(t, body, `action="/v1/login"`)
+}
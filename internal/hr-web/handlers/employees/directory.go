@@ -0,0 +1,162 @@
+package employees
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"web-boilerplate/internal/hr-web/session"
+	"web-boilerplate/internal/hr-web/ui/pages"
+	"web-boilerplate/shared/hrapi"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// HeaderFragment is set by directory.js and forms.js on the requests answered with the part
+	// of the page they replace, the same header htmx sends
+	HeaderFragment = "HX-Request"
+	// HeaderRedirect tells forms.js where to go once its form is saved
+	HeaderRedirect = "HX-Redirect"
+
+	// problemContentType is answered to forms.js for failures it shows as a message,
+	// the way hr-api describes its own
+	problemContentType = "application/problem+json"
+
+	// permEmployeesWrite is the permission of hr-api needed to create and edit employees
+	permEmployeesWrite = "employees:write"
+
+	pageSize = 20
+)
+
+type Handler struct {
+	Log *zerolog.Logger
+	// Sessions are ended when hr-api refuses their access token
+	Sessions *session.Manager
+	API      *hrapi.Client
+}
+
+func New(log *zerolog.Logger, sessions *session.Manager, api *hrapi.Client) *Handler {
+	return &Handler{
+		Log:      log,
+		Sessions: sessions,
+		API:      api,
+	}
+}
+
+// Directory renders the employee directory. The requests of directory.js get only the results,
+// which it swaps into the page in place of the previous ones.
+func (h *Handler) Directory(c fiber.Ctx) error {
+	fragment := isFragment(c)
+	// the same url answers both, caches must tell them apart
+	c.Vary(HeaderFragment)
+
+	user, _ := session.CurrentUser(c)
+	view := pages.DirectoryView{
+		Query:   directoryQuery(c),
+		CanEdit: user.Can(permEmployeesWrite),
+	}
+	api := h.API.WithToken(session.AccessToken(c))
+
+	page, err := api.ListEmployees(c.Context(), hrapi.ListEmployeesParams{
+		PageParams: hrapi.PageParams{
+			Limit:  pageSize,
+			Cursor: view.Query.Cursor,
+			Sort:   view.Query.Sort,
+			Desc:   view.Query.Desc,
+		},
+		Department: view.Query.Department,
+		Search:     view.Query.Search,
+	})
+	if err != nil {
+		return h.failed(c, err, fragment, view)
+	}
+	view.Employees = page.Data
+	view.NextCursor = page.Meta.NextCursor
+	view.Total = page.Meta.TotalEstimate
+
+	if fragment {
+		return render(c, fiber.StatusOK, pages.DirectoryResults(view))
+	}
+
+	view.Departments, err = api.ListDepartments(c.Context())
+	if err != nil {
+		return h.failed(c, err, fragment, view)
+	}
+	return render(c, fiber.StatusOK, pages.Directory(view))
+}
+
+// directoryQuery reads the state of the directory from the query, unknown sort fields
+// fall back to the default one
+func directoryQuery(c fiber.Ctx) pages.DirectoryQuery {
+	query := pages.DirectoryQuery{
+		Search:     strings.TrimSpace(c.Query("q")),
+		Department: c.Query("department"),
+		Sort:       c.Query("sort"),
+		Desc:       c.Query("order") == "desc",
+		Cursor:     c.Query("cursor"),
+	}
+	if !slices.Contains(pages.DirectorySortFields, query.Sort) {
+		query.Sort = pages.DirectorySortFields[0]
+	}
+	// the first page has no previous ones, and the next ones at least the first
+	if query.Cursor != "" {
+		for _, prev := range c.RequestCtx().QueryArgs().PeekMulti("prev") {
+			query.Prev = append(query.Prev, string(prev))
+		}
+		if len(query.Prev) == 0 {
+			query.Prev = []string{""}
+		}
+	}
+	return query
+}
+
+// failed renders the directory with a message in place of the results, or sends the user
+// to the login page when hr-api no longer accepts their session
+func (h *Handler) failed(c fiber.Ctx, err error, fragment bool, view pages.DirectoryView) error {
+	status := fiber.StatusServiceUnavailable
+	view.Message = "The directory is unavailable, please try again later"
+
+	apiErr, ok := hrapi.AsError(err)
+	switch {
+	case ok && apiErr.Status == fiber.StatusUnauthorized:
+		return h.toLogin(c)
+	case ok && apiErr.Status == fiber.StatusForbidden:
+		status, view.Message = fiber.StatusForbidden, "You aren't allowed to see the employee directory"
+	case ok && apiErr.Status == fiber.StatusBadRequest:
+		// a cursor of another sort or from before a deploy
+		status, view.Message = fiber.StatusBadRequest, "This page of the directory no longer exists"
+	case ok && apiErr.Status == fiber.StatusTooManyRequests:
+		status, view.Message = fiber.StatusTooManyRequests, "Too many requests, please wait a moment and try again"
+	default:
+		h.Log.Error().Ctx(c.Context()).Err(err).Msg("failed to load the employee directory")
+	}
+
+	if fragment {
+		return render(c, status, pages.DirectoryResults(view))
+	}
+	return render(c, status, pages.Directory(view))
+}
+
+// toLogin ends the session hr-api no longer accepts and sends the user to the login page,
+// which brings them back to the page once signed in again
+func (h *Handler) toLogin(c fiber.Ctx) error {
+	h.Sessions.End(c)
+
+	if c.Method() != fiber.MethodGet {
+		return c.Redirect().Status(fiber.StatusSeeOther).To("/")
+	}
+	return c.Redirect().Status(fiber.StatusSeeOther).To("/?return_to=" + url.QueryEscape(c.OriginalURL()))
+}
+
+// isFragment reports whether the request asks for the part of the page it replaces
+func isFragment(c fiber.Ctx) bool {
+	return c.Get(HeaderFragment) == "true"
+}
+
+func render(c fiber.Ctx, status int, component templ.Component) error {
+	c.Status(status)
+	c.RequestCtx().SetContentType("text/html")
+	return component.Render(c, c.Response().BodyWriter())
+}
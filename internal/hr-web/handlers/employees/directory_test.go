@@ -0,0 +1,335 @@
+package employees
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/internal/hr-web/session"
+	"web-boilerplate/internal/hr-web/ui/pages"
+	"web-boilerplate/shared/hrapi"
+	"web-boilerplate/shared/jwks"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAPI is an hr-api answering with the handlers of its routes and recording the calls
+type testAPI struct {
+	mu    sync.Mutex
+	calls []apiCall
+}
+
+type apiCall struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   string
+}
+
+// routes are patterns of http.ServeMux, the other calls are answered 404
+type routes map[string]http.HandlerFunc
+
+var testEmployees = []hrapi.Employee{
+	{ID: "e1", FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Department: "Engineering"},
+	{ID: "e2", FirstName: "Grace", LastName: "Hopper", Email: "grace@example.com", Department: "Research"},
+}
+
+// newTestApp serves the employee pages to a signed in user with the given permissions,
+// against an hr-api answering with routes
+func newTestApp(t *testing.T, apiRoutes routes, permissions ...string) (*fiber.App, *testAPI) {
+	api := &testAPI{}
+	mux := http.NewServeMux()
+	for pattern, handler := range apiRoutes {
+		mux.HandleFunc(pattern, handler)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		api.mu.Lock()
+		api.calls = append(api.calls, apiCall{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   string(body),
+		})
+		api.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := config.Default()
+	log := zerolog.Nop()
+	client := hrapi.New(hrapi.Config{BaseURL: server.URL, MaxRetries: -1})
+	h := New(&log, session.NewManager(&cfg, jwks.NewClient(server.URL+"/.well-known/jwks.json", time.Hour), client), client)
+
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		session.SetUser(c, session.User{ID: "user-id", Permissions: permissions}, "access-1")
+		return c.Next()
+	})
+	app.Get(pages.DirectoryPath, h.Directory)
+	app.Get(pages.NewEmployeePath, h.NewPage)
+	app.Post("/employees", h.Create)
+	app.Get("/employees/:id/edit", h.EditPage)
+	app.Post("/employees/:id", h.Update)
+	return app, api
+}
+
+// called returns the calls made to hr-api with the given method and path
+func (a *testAPI) called(method, path string) []apiCall {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var calls []apiCall
+	for _, call := range a.calls {
+		if call.Method == method && call.Path == path {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func answerJSON(body any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+func answerProblem(status int, code string, fields ...hrapi.FieldError) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(hrapi.Error{Status: status, Code: code, Errors: fields})
+	}
+}
+
+func employeesPage(nextCursor string) http.HandlerFunc {
+	total := int64(42)
+	return answerJSON(hrapi.Page[hrapi.Employee]{
+		Data: testEmployees,
+		Meta: hrapi.PageMeta{Limit: pageSize, NextCursor: nextCursor, TotalEstimate: &total},
+	})
+}
+
+var departments = answerJSON(map[string][]string{"data": {"Engineering", "Research"}})
+
+// send runs req and returns its response with the body read
+func send(t *testing.T, app *fiber.App, req *http.Request) (*http.Response, string) {
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func get(target string, fragment bool) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if fragment {
+		req.Header.Set(HeaderFragment, "true")
+	}
+	return req
+}
+
+func TestDirectory_FullPage(t *testing.T) {
+	app, api := newTestApp(t, routes{
+		"GET /v1/employees":   employeesPage("cursor-2"),
+		"GET /v1/departments": departments,
+	})
+
+	resp, body := send(t, app, get(pages.DirectoryPath+"?q=+ada+&department=Engineering", false))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, HeaderFragment, resp.Header.Get("Vary"))
+	assert.Contains(t, body, "<html")
+	assert.Contains(t, body, `id="employee-filters"`)
+	assert.Contains(t, body, `<option value="Research">Research</option>`)
+	assert.Contains(t, body, "ada@example.com")
+	assert.Contains(t, body, "Page 1, about 42 employees")
+	assert.Contains(t, body, `href="/home?cursor=cursor-2&amp;department=Engineering&amp;prev=&amp;q=ada"`)
+
+	calls := api.called(http.MethodGet, "/v1/employees")
+	require.Len(t, calls, 1)
+	assert.Equal(t, url.Values{
+		"limit":      {"20"},
+		"sort":       {"name"},
+		"department": {"Engineering"},
+		"q":          {"ada"},
+	}, calls[0].Query)
+	assert.Equal(t, "Bearer access-1", calls[0].Header.Get("Authorization"))
+}
+
+func TestDirectory_Fragment(t *testing.T) {
+	app, api := newTestApp(t, routes{
+		"GET /v1/employees": employeesPage(""),
+	})
+
+	resp, body := send(t, app, get(pages.DirectoryPath+"?sort=email&order=desc", true))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, HeaderFragment, resp.Header.Get("Vary"))
+	// only the results directory.js swaps in
+	assert.NotContains(t, body, "<html")
+	assert.NotContains(t, body, `id="employee-filters"`)
+	assert.Contains(t, body, "grace@example.com")
+	assert.Contains(t, body, `<input type="hidden" name="sort" value="email" form="employee-filters">`)
+	// the filters of the page are kept, the departments aren't needed
+	assert.Empty(t, api.called(http.MethodGet, "/v1/departments"))
+}
+
+func TestDirectory_UnknownTotal(t *testing.T) {
+	app, _ := newTestApp(t, routes{
+		// hr-api leaves the total out of filtered listings
+		"GET /v1/employees": answerJSON(hrapi.Page[hrapi.Employee]{
+			Data: testEmployees,
+			Meta: hrapi.PageMeta{Limit: pageSize},
+		}),
+	})
+
+	resp, body := send(t, app, get(pages.DirectoryPath+"?department=Engineering", true))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, "Page 1")
+	assert.NotContains(t, body, "about")
+}
+
+func TestDirectory_EditLinks(t *testing.T) {
+	apiRoutes := routes{
+		"GET /v1/employees":   employeesPage(""),
+		"GET /v1/departments": departments,
+	}
+
+	app, _ := newTestApp(t, apiRoutes, "employees:read")
+	_, body := send(t, app, get(pages.DirectoryPath, false))
+	assert.NotContains(t, body, pages.NewEmployeePath)
+	assert.NotContains(t, body, "/employees/e1/edit")
+
+	app, _ = newTestApp(t, apiRoutes, "employees:read", permEmployeesWrite)
+	_, body = send(t, app, get(pages.DirectoryPath, false))
+	assert.Contains(t, body, pages.NewEmployeePath)
+	assert.Contains(t, body, "/employees/e1/edit")
+}
+
+func TestDirectoryQuery(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c fiber.Ctx) error {
+		return c.JSON(directoryQuery(c))
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		want  pages.DirectoryQuery
+	}{
+		{"defaults", "", pages.DirectoryQuery{Sort: "name"}},
+		{"filters", "q=+ada+&department=R%26D", pages.DirectoryQuery{Search: "ada", Department: "R&D", Sort: "name"}},
+		{"known sort", "sort=department&order=desc", pages.DirectoryQuery{Sort: "department", Desc: true}},
+		{"unknown sort falls back", "sort=password_hash&order=asc", pages.DirectoryQuery{Sort: "name"}},
+		{"first page ignores prev", "prev=a", pages.DirectoryQuery{Sort: "name"}},
+		{"second page without prev", "cursor=b", pages.DirectoryQuery{Sort: "name", Cursor: "b", Prev: []string{""}}},
+		{"prev in order", "cursor=c&prev=&prev=b", pages.DirectoryQuery{Sort: "name", Cursor: "c", Prev: []string{"", "b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil))
+			require.NoError(t, err)
+
+			var got pages.DirectoryQuery
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDirectory_PreviousPage(t *testing.T) {
+	app, api := newTestApp(t, routes{
+		"GET /v1/employees": employeesPage("d"),
+	})
+
+	_, body := send(t, app, get(pages.DirectoryPath+"?cursor=c&prev=&prev=b", true))
+
+	assert.Equal(t, "c", api.called(http.MethodGet, "/v1/employees")[0].Query.Get("cursor"))
+	assert.Contains(t, body, "Page 3, about 42 employees")
+	assert.Contains(t, body, `href="/home?cursor=b&amp;prev=" rel="prev"`)
+	assert.Contains(t, body, `href="/home?cursor=d&amp;prev=&amp;prev=b&amp;prev=c" rel="next"`)
+}
+
+func TestDirectory_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  http.HandlerFunc
+		status  int
+		message string
+	}{
+		{"forbidden", answerProblem(http.StatusForbidden, "forbidden"), http.StatusForbidden, "You aren&#39;t allowed to see the employee directory"},
+		{"stale cursor", answerProblem(http.StatusBadRequest, "bad_request"), http.StatusBadRequest, "This page of the directory no longer exists"},
+		{"rate limited", answerProblem(http.StatusTooManyRequests, "rate_limited"), http.StatusTooManyRequests, "Too many requests"},
+		{"hr-api down", answerProblem(http.StatusInternalServerError, "internal_error"), http.StatusServiceUnavailable, "The directory is unavailable"},
+	}
+
+	for _, tt := range tests {
+		for _, fragment := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s fragment=%t", tt.name, fragment), func(t *testing.T) {
+				app, _ := newTestApp(t, routes{
+					"GET /v1/employees":   tt.answer,
+					"GET /v1/departments": departments,
+				})
+
+				resp, body := send(t, app, get(pages.DirectoryPath+"?cursor=b", fragment))
+
+				assert.Equal(t, tt.status, resp.StatusCode)
+				assert.Contains(t, body, tt.message)
+				assert.Contains(t, body, "Back to the first page")
+				if fragment {
+					assert.NotContains(t, body, "<html")
+				} else {
+					assert.Contains(t, body, "<html")
+				}
+			})
+		}
+	}
+}
+
+func TestDirectory_DepartmentsFailure(t *testing.T) {
+	app, _ := newTestApp(t, routes{
+		"GET /v1/employees":   employeesPage(""),
+		"GET /v1/departments": answerProblem(http.StatusServiceUnavailable, "service_unavailable"),
+	})
+
+	resp, body := send(t, app, get(pages.DirectoryPath, false))
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Contains(t, body, "The directory is unavailable")
+}
+
+func TestDirectory_ExpiredSessionToLogin(t *testing.T) {
+	app, _ := newTestApp(t, routes{
+		"GET /v1/employees": answerProblem(http.StatusUnauthorized, "token_revoked"),
+	})
+
+	for _, fragment := range []bool{false, true} {
+		resp, _ := send(t, app, get(pages.DirectoryPath+"?q=ada", fragment))
+
+		assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+		assert.Equal(t, "/?return_to=%2Fhome%3Fq%3Dada", resp.Header.Get("Location"))
+		// the session hr-api refused is ended
+		cookies := map[string]*http.Cookie{}
+		for _, cookie := range resp.Cookies() {
+			cookies[cookie.Name] = cookie
+		}
+		require.Contains(t, cookies, session.AccessCookie)
+		assert.Equal(t, -1, cookies[session.AccessCookie].MaxAge)
+		assert.Equal(t, -1, cookies[session.RefreshCookie].MaxAge)
+	}
+}
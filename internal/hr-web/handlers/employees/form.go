@@ -0,0 +1,191 @@
+package employees
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"web-boilerplate/internal/hr-web/session"
+	"web-boilerplate/internal/hr-web/ui/pages"
+	"web-boilerplate/shared/hrapi"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// NewPage renders an empty employee form, with the notice of the employee added before it
+// when the previous form asked for another one
+func (h *Handler) NewPage(c fiber.Ctx) error {
+	form := pages.EmployeeForm{}
+	if added := c.Query("added"); added != "" {
+		form.Another = true
+		form.Notice = added + " was added"
+	}
+
+	return h.renderForm(c, fiber.StatusOK, form)
+}
+
+// EditPage renders the form of an existing employee
+func (h *Handler) EditPage(c fiber.Ctx) error {
+	employee, err := h.API.WithToken(session.AccessToken(c)).GetEmployee(c.Context(), c.Params("id"))
+	if err != nil {
+		apiErr, ok := hrapi.AsError(err)
+		switch {
+		case ok && apiErr.Status == fiber.StatusUnauthorized:
+			return h.toLogin(c)
+		// hr-api refuses ids that aren't UUIDs, which don't exist either
+		case ok && (apiErr.Status == fiber.StatusNotFound || apiErr.Status == fiber.StatusBadRequest):
+			return render(c, fiber.StatusNotFound, pages.Unavailable("This employee doesn't exist, or no longer does"))
+		case ok && apiErr.Status == fiber.StatusForbidden:
+			return render(c, fiber.StatusForbidden, pages.Unavailable("You aren't allowed to see this employee"))
+		}
+		h.Log.Error().Ctx(c.Context()).Err(err).Msg("failed to get employee")
+		return render(c, fiber.StatusServiceUnavailable, pages.Unavailable("The service is unavailable, please try again later"))
+	}
+
+	return h.renderForm(c, fiber.StatusOK, pages.EmployeeForm{
+		ID:         employee.ID,
+		FirstName:  employee.FirstName,
+		LastName:   employee.LastName,
+		Email:      employee.Email,
+		Department: employee.Department,
+	})
+}
+
+// Create creates the employee of the posted form
+func (h *Handler) Create(c fiber.Ctx) error {
+	form := postedForm(c)
+
+	employee, err := h.API.WithToken(session.AccessToken(c)).CreateEmployee(submission(c), employeeParams(form))
+	if err != nil {
+		return h.refused(c, err, form)
+	}
+
+	h.Log.Info().Ctx(c.Context()).Str("id", employee.ID).Msg("employee created")
+
+	if form.Another {
+		name := employee.FirstName + " " + employee.LastName
+		return saved(c, pages.NewEmployeePath+"?added="+url.QueryEscape(name))
+	}
+	return saved(c, pages.DirectoryPath)
+}
+
+// Update saves the posted form of an existing employee
+func (h *Handler) Update(c fiber.Ctx) error {
+	form := postedForm(c)
+	form.ID = c.Params("id")
+
+	employee, err := h.API.WithToken(session.AccessToken(c)).UpdateEmployee(submission(c), form.ID, employeeParams(form))
+	if err != nil {
+		return h.refused(c, err, form)
+	}
+
+	h.Log.Info().Ctx(c.Context()).Str("id", employee.ID).Msg("employee updated")
+
+	return saved(c, pages.DirectoryPath)
+}
+
+// postedForm reads the values of the posted form, to send them and to show them back when refused
+func postedForm(c fiber.Ctx) pages.EmployeeForm {
+	return pages.EmployeeForm{
+		FirstName:     c.FormValue("first_name"),
+		LastName:      c.FormValue("last_name"),
+		Email:         c.FormValue("email"),
+		Department:    c.FormValue("department"),
+		NewDepartment: c.FormValue("new_department"),
+		Another:       c.FormValue("another") == "on",
+	}
+}
+
+func employeeParams(form pages.EmployeeForm) hrapi.EmployeeParams {
+	department := form.Department
+	if strings.TrimSpace(form.NewDepartment) != "" {
+		department = form.NewDepartment
+	}
+
+	return hrapi.EmployeeParams{
+		FirstName:  form.FirstName,
+		LastName:   form.LastName,
+		Email:      form.Email,
+		Department: department,
+	}
+}
+
+// submission returns the context of the call saving the form. It carries the idempotency key
+// deduplication.js sent with it, so hr-api saves a submission sent twice only once.
+func submission(c fiber.Ctx) context.Context {
+	key := c.Get(hrapi.HeaderIdempotencyKey)
+	if uuid.Validate(key) != nil {
+		return c.Context()
+	}
+	return hrapi.WithIdempotencyKey(c.Context(), key)
+}
+
+// saved sends the browser to target once the form is saved, forms.js follows the HX-Redirect header
+func saved(c fiber.Ctx, target string) error {
+	if isFragment(c) {
+		c.Set(HeaderRedirect, target)
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+	return c.Redirect().Status(fiber.StatusSeeOther).To(target)
+}
+
+// refused renders the form back with the reasons hr-api refused it. Only refused fields get
+// the form back to forms.js, its other failures are problem documents it shows as a message.
+func (h *Handler) refused(c fiber.Ctx, err error, form pages.EmployeeForm) error {
+	status := fiber.StatusServiceUnavailable
+	form.Message = "The service is unavailable, please try again later"
+
+	apiErr, ok := hrapi.AsError(err)
+	switch {
+	case ok && apiErr.Status == fiber.StatusUnauthorized:
+		return h.toLogin(c)
+	case ok && apiErr.Status == fiber.StatusBadRequest && len(apiErr.Errors) > 0:
+		status, form.Message = fiber.StatusUnprocessableEntity, ""
+		form.Errors = apiErr.Fields()
+		// the department comes from the field it was typed in
+		if reason, ok := form.Errors["department"]; ok && strings.TrimSpace(form.NewDepartment) != "" {
+			delete(form.Errors, "department")
+			form.Errors["new_department"] = reason
+		}
+	case ok && apiErr.Code == "already_exists":
+		// email is the only unique field of employees
+		status, form.Message = fiber.StatusUnprocessableEntity, ""
+		form.Errors = map[string]string{"email": "Another employee already has this email"}
+	case ok && apiErr.Status == fiber.StatusNotFound:
+		status, form.Message = fiber.StatusNotFound, "This employee no longer exists"
+	case ok && apiErr.Status == fiber.StatusForbidden:
+		status, form.Message = fiber.StatusForbidden, "You aren't allowed to manage employees"
+	case ok && apiErr.Status == fiber.StatusConflict:
+		// the same submission is still being saved
+		status, form.Message = fiber.StatusConflict, "This form is already being saved, please wait"
+	case ok && apiErr.Status == fiber.StatusTooManyRequests:
+		status, form.Message = fiber.StatusTooManyRequests, "Too many requests, please wait a moment and try again"
+	default:
+		h.Log.Error().Ctx(c.Context()).Err(err).Msg("failed to save employee")
+	}
+
+	if isFragment(c) && status != fiber.StatusUnprocessableEntity {
+		return c.Status(status).JSON(fiber.Map{
+			"status": status,
+			"title":  http.StatusText(status),
+			"detail": form.Message,
+		}, problemContentType)
+	}
+	return h.renderForm(c, status, form)
+}
+
+// renderForm renders the employee form, or only the form itself to forms.js. A failure to list
+// the departments leaves the select empty, a new department can still be typed.
+func (h *Handler) renderForm(c fiber.Ctx, status int, form pages.EmployeeForm) error {
+	departments, err := h.API.WithToken(session.AccessToken(c)).ListDepartments(c.Context())
+	if err != nil {
+		h.Log.Warn().Ctx(c.Context()).Err(err).Msg("failed to list departments")
+	}
+	form.Departments = departments
+
+	if isFragment(c) {
+		return render(c, status, pages.EmployeeFormFields(form))
+	}
+	return render(c, status, pages.EmployeeFormPage(form))
+}
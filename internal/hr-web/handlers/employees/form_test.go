@@ -0,0 +1,316 @@
+package employees
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"web-boilerplate/internal/hr-web/session"
+	"web-boilerplate/internal/hr-web/ui/pages"
+	"web-boilerplate/shared/hrapi"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testForm = url.Values{
+	"first_name": {"Ada"},
+	"last_name":  {"Lovelace"},
+	"email":      {"ada@example.com"},
+	"department": {"Engineering"},
+}
+
+func post(target string, form url.Values, fragment bool) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if fragment {
+		req.Header.Set(HeaderFragment, "true")
+	}
+	return req
+}
+
+// with returns testForm with the given values replaced
+func with(values ...string) url.Values {
+	form := url.Values{}
+	for name, value := range testForm {
+		form[name] = value
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		form.Set(values[i], values[i+1])
+	}
+	return form
+}
+
+func sentParams(t *testing.T, call apiCall) hrapi.EmployeeParams {
+	var params hrapi.EmployeeParams
+	require.NoError(t, json.Unmarshal([]byte(call.Body), &params))
+	return params
+}
+
+func TestNewPage(t *testing.T) {
+	app, _ := newTestApp(t, routes{"GET /v1/departments": departments})
+
+	resp, body := send(t, app, get(pages.NewEmployeePath+"?added=Ada+Lovelace", false))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, "<html")
+	assert.Contains(t, body, `action="/employees"`)
+	assert.Contains(t, body, "Ada Lovelace was added")
+	assert.Contains(t, body, `<option value="Research">Research</option>`)
+}
+
+func TestNewPage_WithoutDepartments(t *testing.T) {
+	app, _ := newTestApp(t, routes{"GET /v1/departments": answerProblem(http.StatusServiceUnavailable, "service_unavailable")})
+
+	// a new department can still be typed
+	resp, body := send(t, app, get(pages.NewEmployeePath, false))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `name="new_department"`)
+}
+
+func TestCreate_Saved(t *testing.T) {
+	tests := []struct {
+		name     string
+		form     url.Values
+		fragment bool
+		status   int
+		header   string
+		target   string
+	}{
+		{"page load", testForm, false, http.StatusSeeOther, "Location", pages.DirectoryPath},
+		{"forms.js", testForm, true, http.StatusNoContent, HeaderRedirect, pages.DirectoryPath},
+		{"another one", with("another", "on"), false, http.StatusSeeOther, "Location", pages.NewEmployeePath + "?added=Ada+Lovelace"},
+		{"another one with forms.js", with("another", "on"), true, http.StatusNoContent, HeaderRedirect, pages.NewEmployeePath + "?added=Ada+Lovelace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, api := newTestApp(t, routes{"POST /v1/employees": answerJSON(testEmployees[0])})
+
+			resp, _ := send(t, app, post("/employees", tt.form, tt.fragment))
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.target, resp.Header.Get(tt.header))
+			calls := api.called(http.MethodPost, "/v1/employees")
+			require.Len(t, calls, 1)
+			assert.Equal(t, hrapi.EmployeeParams{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Department: "Engineering"}, sentParams(t, calls[0]))
+		})
+	}
+}
+
+func TestCreate_NewDepartmentWins(t *testing.T) {
+	app, api := newTestApp(t, routes{"POST /v1/employees": answerJSON(testEmployees[0])})
+
+	send(t, app, post("/employees", with("new_department", "Research & Development"), false))
+
+	calls := api.called(http.MethodPost, "/v1/employees")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "Research & Development", sentParams(t, calls[0]).Department)
+}
+
+func TestCreate_IdempotencyKey(t *testing.T) {
+	app, api := newTestApp(t, routes{"POST /v1/employees": answerJSON(testEmployees[0])})
+	key := uuid.NewString()
+
+	// the key of deduplication.js is passed on, so a submission sent twice is saved once
+	req := post("/employees", testForm, true)
+	req.Header.Set(hrapi.HeaderIdempotencyKey, key)
+	send(t, app, req)
+	// keys that aren't UUIDs are replaced
+	req = post("/employees", testForm, true)
+	req.Header.Set(hrapi.HeaderIdempotencyKey, "not-a-uuid")
+	send(t, app, req)
+
+	calls := api.called(http.MethodPost, "/v1/employees")
+	require.Len(t, calls, 2)
+	assert.Equal(t, key, calls[0].Header.Get(hrapi.HeaderIdempotencyKey))
+	assert.NoError(t, uuid.Validate(calls[1].Header.Get(hrapi.HeaderIdempotencyKey)))
+}
+
+func TestCreate_RefusedFields(t *testing.T) {
+	for _, fragment := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fragment=%t", fragment), func(t *testing.T) {
+			app, _ := newTestApp(t, routes{
+				"POST /v1/employees": answerProblem(http.StatusBadRequest, "validation_failed",
+					hrapi.FieldError{Field: "email", Message: "email must be a valid email address"}),
+				"GET /v1/departments": departments,
+			})
+
+			resp, body := send(t, app, post("/employees", with("email", "ada"), fragment))
+
+			// forms.js swaps the refused form in
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+			assert.Contains(t, body, `<p id="email-error" class="fieldset-label text-error">email must be a valid email address</p>`)
+			// the posted values are kept
+			assert.Contains(t, body, `value="ada"`)
+			assert.Contains(t, body, `value="Lovelace"`)
+			if fragment {
+				assert.NotContains(t, body, "<html")
+			} else {
+				assert.Contains(t, body, "<html")
+			}
+		})
+	}
+}
+
+func TestCreate_RefusedNewDepartment(t *testing.T) {
+	refusal := answerProblem(http.StatusBadRequest, "validation_failed",
+		hrapi.FieldError{Field: "department", Message: "department must only contain letters"})
+
+	app, _ := newTestApp(t, routes{"POST /v1/employees": refusal, "GET /v1/departments": departments})
+	_, body := send(t, app, post("/employees", with("new_department", "<R&D>"), true))
+	// the reason goes to the field the department was typed in
+	assert.Contains(t, body, `id="new_department-error"`)
+	assert.NotContains(t, body, `id="department-error"`)
+
+	app, _ = newTestApp(t, routes{"POST /v1/employees": refusal, "GET /v1/departments": departments})
+	_, body = send(t, app, post("/employees", with("department", "<R&D>"), true))
+	assert.Contains(t, body, `id="department-error"`)
+	assert.NotContains(t, body, `id="new_department-error"`)
+}
+
+func TestCreate_EmailAlreadyExists(t *testing.T) {
+	app, _ := newTestApp(t, routes{
+		"POST /v1/employees":  answerProblem(http.StatusConflict, "already_exists"),
+		"GET /v1/departments": departments,
+	})
+
+	resp, body := send(t, app, post("/employees", testForm, true))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Contains(t, body, `<p id="email-error" class="fieldset-label text-error">Another employee already has this email</p>`)
+}
+
+func TestCreate_Failures(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer http.HandlerFunc
+		status int
+		detail string
+	}{
+		{"forbidden", answerProblem(http.StatusForbidden, "forbidden"), http.StatusForbidden, "You aren't allowed to manage employees"},
+		{"still being saved", answerProblem(http.StatusConflict, "conflict"), http.StatusConflict, "This form is already being saved, please wait"},
+		{"rate limited", answerProblem(http.StatusTooManyRequests, "rate_limited"), http.StatusTooManyRequests, "Too many requests, please wait a moment and try again"},
+		{"bad request without fields", answerProblem(http.StatusBadRequest, "bad_request"), http.StatusServiceUnavailable, "The service is unavailable, please try again later"},
+		{"hr-api down", answerProblem(http.StatusInternalServerError, "internal_error"), http.StatusServiceUnavailable, "The service is unavailable, please try again later"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, _ := newTestApp(t, routes{"POST /v1/employees": tt.answer, "GET /v1/departments": departments})
+
+			// forms.js shows the detail of a problem document
+			resp, body := send(t, app, post("/employees", testForm, true))
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, problemContentType, resp.Header.Get("Content-Type"))
+			var doc map[string]any
+			require.NoError(t, json.Unmarshal([]byte(body), &doc))
+			assert.Equal(t, map[string]any{
+				"status": float64(tt.status),
+				"title":  http.StatusText(tt.status),
+				"detail": tt.detail,
+			}, doc)
+
+			// a page load gets the form back with the message
+			resp, body = send(t, app, post("/employees", testForm, false))
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Contains(t, body, "<html")
+			assert.Contains(t, body, strings.ReplaceAll(tt.detail, "'", "&#39;"))
+		})
+	}
+}
+
+func TestCreate_ExpiredSessionToLogin(t *testing.T) {
+	app, api := newTestApp(t, routes{"POST /v1/employees": answerProblem(http.StatusUnauthorized, "token_revoked")})
+
+	for _, fragment := range []bool{false, true} {
+		resp, _ := send(t, app, post("/employees", testForm, fragment))
+
+		// the post can't be replayed once signed in again
+		assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+		assert.Equal(t, "/", resp.Header.Get("Location"))
+		var ended bool
+		for _, cookie := range resp.Cookies() {
+			ended = ended || (cookie.Name == session.AccessCookie && cookie.MaxAge == -1)
+		}
+		assert.True(t, ended)
+	}
+	assert.Empty(t, api.called(http.MethodGet, "/v1/departments"))
+}
+
+func TestEditPage(t *testing.T) {
+	app, api := newTestApp(t, routes{
+		"GET /v1/employees/{id}": answerJSON(testEmployees[1]),
+		"GET /v1/departments":    departments,
+	})
+
+	resp, body := send(t, app, get("/employees/e2/edit", false))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, "Edit employee")
+	assert.Contains(t, body, `action="/employees/e2"`)
+	assert.Contains(t, body, `value="grace@example.com"`)
+	assert.Contains(t, body, `<option value="Research" selected>Research</option>`)
+	assert.Len(t, api.called(http.MethodGet, "/v1/employees/e2"), 1)
+}
+
+func TestEditPage_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  http.HandlerFunc
+		status  int
+		message string
+	}{
+		{"not found", answerProblem(http.StatusNotFound, "not_found"), http.StatusNotFound, "This employee doesn&#39;t exist, or no longer does"},
+		{"id isn't a uuid", answerProblem(http.StatusBadRequest, "bad_request"), http.StatusNotFound, "This employee doesn&#39;t exist, or no longer does"},
+		{"forbidden", answerProblem(http.StatusForbidden, "forbidden"), http.StatusForbidden, "You aren&#39;t allowed to see this employee"},
+		{"hr-api down", answerProblem(http.StatusInternalServerError, "internal_error"), http.StatusServiceUnavailable, "The service is unavailable, please try again later"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, _ := newTestApp(t, routes{"GET /v1/employees/{id}": tt.answer})
+
+			resp, body := send(t, app, get("/employees/e2/edit", false))
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Contains(t, body, tt.message)
+		})
+	}
+}
+
+func TestEditPage_ExpiredSessionToLogin(t *testing.T) {
+	app, _ := newTestApp(t, routes{"GET /v1/employees/{id}": answerProblem(http.StatusUnauthorized, "invalid_token")})
+
+	resp, _ := send(t, app, get("/employees/e2/edit", false))
+
+	assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+	assert.Equal(t, "/?return_to=%2Femployees%2Fe2%2Fedit", resp.Header.Get("Location"))
+}
+
+func TestUpdate(t *testing.T) {
+	app, api := newTestApp(t, routes{"PUT /v1/employees/{id}": answerJSON(testEmployees[0])})
+
+	resp, _ := send(t, app, post("/employees/e1", with("another", "on"), true))
+
+	// another only applies to new employees
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, pages.DirectoryPath, resp.Header.Get(HeaderRedirect))
+	calls := api.called(http.MethodPut, "/v1/employees/e1")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "Ada", sentParams(t, calls[0]).FirstName)
+}
+
+func TestUpdate_EmployeeGone(t *testing.T) {
+	app, _ := newTestApp(t, routes{"PUT /v1/employees/{id}": answerProblem(http.StatusNotFound, "not_found")})
+
+	resp, body := send(t, app, post("/employees/e1", testForm, true))
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, body, "This employee no longer exists")
+}
@@ -0,0 +1,61 @@
+package health
+
+import (
+	"web-boilerplate/shared/health"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+)
+
+type Handler struct {
+	Log    *zerolog.Logger
+	Checks *health.Registry
+	// Ready reports false once the app is shutting down
+	Ready func() bool
+}
+
+func New(log *zerolog.Logger, checks *health.Registry, ready func() bool) *Handler {
+	return &Handler{
+		Log:    log,
+		Checks: checks,
+		Ready:  ready,
+	}
+}
+
+// Livez answers as long as the process serves requests, it never checks dependencies
+func (h *Handler) Livez(c fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": health.StatusUp})
+}
+
+// Readyz fails while shutting down or while a critical dependency is down
+func (h *Handler) Readyz(c fiber.Ctx) error {
+	if h.Ready != nil && !h.Ready() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status": health.StatusDown,
+			"reason": "shutting down",
+		})
+	}
+
+	report := h.Checks.Check(c.Context())
+	if !report.Healthy() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": report.Status})
+	}
+
+	return c.JSON(fiber.Map{"status": report.Status})
+}
+
+// Health reports the status and latency of every dependency
+func (h *Handler) Health(c fiber.Ctx) error {
+	report := h.Checks.Check(c.Context())
+	for name, result := range report.Checks {
+		if result.Err != nil {
+			h.Log.Error().Err(result.Err).Str("check", name).Msg("health check failed")
+		}
+	}
+
+	status := fiber.StatusOK
+	if !report.Healthy() {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(report)
+}
@@ -1 +1,63 @@
-package middlewares
\ No newline at end of file
+package middlewares
+
+import (
+	"errors"
+	"net/url"
+	"web-boilerplate/internal/hr-web/session"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+)
+
+// RequireSession returns a middleware letting through the requests of signed in users.
+// An expired access token is refreshed with the refresh cookie and the new pair sent back as cookies.
+// Without a valid session the user is sent to the login page, which brings them back to the
+// page they asked for once signed in.
+func RequireSession(log *zerolog.Logger, sessions *session.Manager) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if token := c.Cookies(session.AccessCookie); token != "" {
+			user, err := sessions.Verify(token)
+			if err == nil {
+				session.SetUser(c, user, token)
+				return c.Next()
+			}
+			log.Debug().Ctx(c.Context()).Err(err).Msg("invalid access token, refreshing the session")
+		}
+
+		refreshToken := c.Cookies(session.RefreshCookie)
+		if refreshToken == "" {
+			return toLogin(c, sessions)
+		}
+
+		tokens, err := sessions.Refresh(c.Context(), refreshToken)
+		if errors.Is(err, session.ErrRefreshRefused) {
+			return toLogin(c, sessions)
+		}
+		if err != nil {
+			// the session may still be good, keep the cookies for when hr-api is back
+			log.Error().Ctx(c.Context()).Err(err).Msg("failed to refresh the session")
+			return c.Status(fiber.StatusServiceUnavailable).SendString("Backend Service Unavailable")
+		}
+
+		user, err := sessions.Verify(tokens.Token)
+		if err != nil {
+			log.Error().Ctx(c.Context()).Err(err).Msg("refreshed access token is invalid")
+			return toLogin(c, sessions)
+		}
+
+		sessions.Start(c, tokens)
+		session.SetUser(c, user, tokens.Token)
+		return c.Next()
+	}
+}
+
+// toLogin ends the session and redirects to the login page, with the page to return to
+// when it can be asked again after signing in
+func toLogin(c fiber.Ctx, sessions *session.Manager) error {
+	sessions.End(c)
+
+	if c.Method() != fiber.MethodGet {
+		return c.Redirect().Status(fiber.StatusSeeOther).To("/")
+	}
+	return c.Redirect().Status(fiber.StatusSeeOther).To("/?return_to=" + url.QueryEscape(c.OriginalURL()))
+}
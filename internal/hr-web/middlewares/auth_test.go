@@ -0,0 +1,180 @@
+package middlewares
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/internal/hr-web/session"
+	"web-boilerplate/shared/hrapi"
+	"web-boilerplate/shared/jwks"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLog = zerolog.Nop()
+
+// sessionAPI is an hr-api serving its key set and answering refreshes with refreshStatus,
+// or a new pair signed with its key
+type sessionAPI struct {
+	private       ed25519.PrivateKey
+	refreshStatus int
+	refreshes     atomic.Int32
+}
+
+func newSessionApp(t *testing.T) (*fiber.App, *sessionAPI) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := jwks.NewKey("test-key", "EdDSA", public)
+	require.NoError(t, err)
+
+	api := &sessionAPI{private: private, refreshStatus: http.StatusOK}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jwks.Set{Keys: []jwks.Key{key}})
+	})
+	mux.HandleFunc("POST /v1/token/refresh", func(w http.ResponseWriter, r *http.Request) {
+		api.refreshes.Add(1)
+		if api.refreshStatus != http.StatusOK {
+			w.WriteHeader(api.refreshStatus)
+			return
+		}
+		json.NewEncoder(w).Encode(hrapi.TokenResponse{
+			Token:        api.accessToken(t, "refreshed-user", time.Now().Add(15*time.Minute)),
+			RefreshToken: "refresh-2",
+			ExpiresIn:    900,
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	sessions := session.NewManager(
+		&config.Config{SessionTTL: time.Hour},
+		jwks.NewClient(server.URL+"/.well-known/jwks.json", time.Hour),
+		hrapi.New(hrapi.Config{BaseURL: server.URL, MaxRetries: -1}),
+	)
+
+	app := fiber.New()
+	app.All("/home", RequireSession(&testLog, sessions), func(c fiber.Ctx) error {
+		user, _ := session.CurrentUser(c)
+		return c.SendString(user.ID + " " + session.AccessToken(c))
+	})
+	return app, api
+}
+
+func (a *sessionAPI) accessToken(t *testing.T, id string, expires time.Time) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"typ": "access", "id": id, "exp": expires.Unix()})
+	token.Header["kid"] = "test-key"
+	signed, err := token.SignedString(a.private)
+	require.NoError(t, err)
+	return signed
+}
+
+func sessionRequest(method, target, accessToken, refreshToken string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	if accessToken != "" {
+		req.AddCookie(&http.Cookie{Name: session.AccessCookie, Value: accessToken})
+	}
+	if refreshToken != "" {
+		req.AddCookie(&http.Cookie{Name: session.RefreshCookie, Value: refreshToken})
+	}
+	return req
+}
+
+func responseCookies(resp *http.Response) map[string]*http.Cookie {
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range resp.Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return cookies
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestRequireSession_ValidAccessToken(t *testing.T) {
+	app, api := newSessionApp(t)
+	token := api.accessToken(t, "user-id", time.Now().Add(time.Minute))
+
+	resp, err := app.Test(sessionRequest(http.MethodGet, "/home", token, "refresh-1"))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "user-id "+token, readBody(t, resp))
+	assert.Empty(t, resp.Cookies())
+	assert.Zero(t, api.refreshes.Load())
+}
+
+func TestRequireSession_RefreshesExpiredAccessToken(t *testing.T) {
+	app, api := newSessionApp(t)
+	expired := api.accessToken(t, "user-id", time.Now().Add(-time.Minute))
+
+	resp, err := app.Test(sessionRequest(http.MethodGet, "/home", expired, "refresh-1"))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), api.refreshes.Load())
+	// the request goes on with the new pair, which replaces the cookies
+	cookies := responseCookies(resp)
+	require.Contains(t, cookies, session.AccessCookie)
+	assert.Equal(t, "refreshed-user "+cookies[session.AccessCookie].Value, readBody(t, resp))
+	assert.Equal(t, "refresh-2", cookies[session.RefreshCookie].Value)
+}
+
+func TestRequireSession_SendsToLogin(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		refreshToken  string
+		refreshStatus int
+		location      string
+	}{
+		{"no session", http.MethodGet, "", http.StatusOK, "/?return_to=%2Fhome%3Fq%3Dada"},
+		{"refresh refused", http.MethodGet, "revoked", http.StatusUnauthorized, "/?return_to=%2Fhome%3Fq%3Dada"},
+		// the post can't be replayed once signed in again
+		{"post", http.MethodPost, "", http.StatusOK, "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, api := newSessionApp(t)
+			api.refreshStatus = tt.refreshStatus
+			expired := api.accessToken(t, "user-id", time.Now().Add(-time.Minute))
+
+			resp, err := app.Test(sessionRequest(tt.method, "/home?q=ada", expired, tt.refreshToken))
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+			assert.Equal(t, tt.location, resp.Header.Get("Location"))
+			// the session cookies are removed
+			cookies := responseCookies(resp)
+			assert.Equal(t, -1, cookies[session.AccessCookie].MaxAge)
+			assert.Equal(t, -1, cookies[session.RefreshCookie].MaxAge)
+		})
+	}
+}
+
+func TestRequireSession_KeepsSessionWhenAPIIsDown(t *testing.T) {
+	app, api := newSessionApp(t)
+	api.refreshStatus = http.StatusServiceUnavailable
+	expired := api.accessToken(t, "user-id", time.Now().Add(-time.Minute))
+
+	resp, err := app.Test(sessionRequest(http.MethodGet, "/home", expired, "refresh-1"))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Empty(t, resp.Cookies())
+}
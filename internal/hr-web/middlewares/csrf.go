@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"web-boilerplate/internal/hr-web/csrf"
+	"web-boilerplate/internal/hr-web/ui/pages"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+)
+
+// CSRF returns a middleware refusing unsafe requests without the csrf token of the browser.
+// The token is made available to the forms of every page, including the 403 page answered on failure
+// so the user can retry from it.
+func CSRF(log *zerolog.Logger, protector *csrf.Protector) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if err := protector.Issue(c); err != nil {
+			log.Error().Ctx(c.Context()).Err(err).Msg("failed to issue csrf token")
+			return c.Status(fiber.StatusInternalServerError).SendString("Internal Server Error")
+		}
+
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions, fiber.MethodTrace:
+			return c.Next()
+		}
+
+		if err := protector.Verify(c); err != nil {
+			log.Warn().Ctx(c.Context()).Err(err).Str("path", c.Path()).Msg("csrf check failed")
+			c.Status(fiber.StatusForbidden)
+			c.RequestCtx().SetContentType("text/html")
+			return pages.Forbidden().Render(c, c.Response().BodyWriter())
+		}
+
+		return c.Next()
+	}
+}
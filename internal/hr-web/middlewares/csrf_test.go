@@ -0,0 +1,138 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/internal/hr-web/csrf"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCSRFApp answers every route with the csrf token of the request once past the middleware
+func newCSRFApp() *fiber.App {
+	cfg := config.Default()
+	app := fiber.New()
+	app.Use(CSRF(&testLog, csrf.New(&cfg)))
+	app.All("/", func(c fiber.Ctx) error {
+		return c.SendString(csrf.Token(c))
+	})
+	return app
+}
+
+// issue returns the key cookie and the token a page gives to a new browser
+func issue(t *testing.T, app *fiber.App) (*http.Cookie, string) {
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cookies := responseCookies(resp)
+	require.Contains(t, cookies, csrf.CookieName)
+	return cookies[csrf.CookieName], readBody(t, resp)
+}
+
+func formPost(key *http.Cookie, header, field string) *http.Request {
+	form := url.Values{}
+	if field != "" {
+		form.Set(csrf.FieldName, field)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if key != nil {
+		req.AddCookie(key)
+	}
+	if header != "" {
+		req.Header.Set(csrf.HeaderName, header)
+	}
+	return req
+}
+
+func TestCSRF_SafeMethodsPassThrough(t *testing.T) {
+	app := newCSRFApp()
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace} {
+		t.Run(method, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(method, "/", nil))
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			// the page gets a key for its forms
+			assert.Contains(t, responseCookies(resp), csrf.CookieName)
+		})
+	}
+}
+
+func TestCSRF_AcceptsToken(t *testing.T) {
+	app := newCSRFApp()
+	key, token := issue(t, app)
+
+	tests := []struct {
+		name   string
+		header string
+		field  string
+	}{
+		{"form field", "", token},
+		{"header of fetch calls", token, ""},
+		{"header over a stale form field", token, "stale"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(formPost(key, tt.header, tt.field))
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			// the key is kept, the other forms of the page keep working
+			assert.Equal(t, token, readBody(t, resp))
+		})
+	}
+}
+
+func TestCSRF_RefusesForgedRequests(t *testing.T) {
+	app := newCSRFApp()
+	key, token := issue(t, app)
+	otherKey, otherToken := issue(t, app)
+
+	tests := []struct {
+		name   string
+		key    *http.Cookie
+		header string
+		field  string
+	}{
+		{"missing cookie", nil, "", token},
+		{"missing token", key, "", ""},
+		{"mismatched token", key, "", "forged"},
+		{"mismatched header", key, "forged", token},
+		{"token of another session", key, "", otherToken},
+		{"cookie of another session", otherKey, token, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(formPost(tt.key, tt.header, tt.field))
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+			assert.Equal(t, "text/html", resp.Header.Get("Content-Type"))
+			assert.Contains(t, readBody(t, resp), "This form has expired")
+		})
+	}
+}
+
+func TestCSRF_ProtectsEveryUnsafeMethod(t *testing.T) {
+	app := newCSRFApp()
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		t.Run(method, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(method, "/", nil))
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package middlewares
+
+import (
+	"web-boilerplate/shared/hrapi"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+)
+
+// APIRequestID puts the id given by requestid.New in the request context, so the calls made
+// to hr-api with c.Context() carry it. It must come after requestid.New.
+func APIRequestID() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if id := requestid.FromContext(c); id != "" {
+			c.SetContext(hrapi.WithRequestID(c.Context(), id))
+		}
+		return c.Next()
+	}
+}
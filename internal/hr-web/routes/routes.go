@@ -2,38 +2,46 @@ package routes
 
 import (
 	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/internal/hr-web/csrf"
 	"web-boilerplate/internal/hr-web/handlers/auth"
+	"web-boilerplate/internal/hr-web/handlers/employees"
+	"web-boilerplate/internal/hr-web/handlers/health"
+	"web-boilerplate/internal/hr-web/middlewares"
+	"web-boilerplate/internal/hr-web/session"
 	"web-boilerplate/internal/hr-web/ui/pages"
-	gpages "web-boilerplate/ui/pages"
+	sharedhealth "web-boilerplate/shared/health"
+	"web-boilerplate/shared/hrapi"
+	"web-boilerplate/shared/jwks"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/rs/zerolog"
 )
 
-func SetupRoutes(app *fiber.App, log *zerolog.Logger) {
-	app.Get("/", func(c fiber.Ctx) error {
-		c.RequestCtx().SetContentType("text/html")
-		return pages.Login(config.BASE_URL).Render(c, c.Response().BodyWriter())
-	})
+func SetupRoutes(app *fiber.App, cfg *config.Config, log *zerolog.Logger, checks *sharedhealth.Registry, ready func() bool) {
+	healthHandler := health.New(log, checks, ready)
+	app.Get("/livez", healthHandler.Livez)
+	app.Get("/readyz", healthHandler.Readyz)
+	app.Get("/health", healthHandler.Health)
 
-	auth.InitLogger(log)
-	app.Post("/v1/login", auth.Login)
+	// routes registered from here on refuse form posts without the csrf token
+	protector := csrf.New(cfg)
+	app.Use(middlewares.CSRF(log, protector))
 
-	app.Get("/home", func(c fiber.Ctx) error {
-		c.RequestCtx().SetContentType("text/html")
-		var users []gpages.User
-		users = append(users, gpages.User{
-			ID:       "1",
-			Fullname: "admin",
-			Email:    "admin@example.com",
-			IsActive: true,
-		},
-			gpages.User{
-				ID:       "2",
-				Fullname: "notadmin",
-				Email:    "notadmin@example.com",
-				IsActive: false,
-			})
-		return gpages.Home(users).Render(c, c.Response().BodyWriter())
-	})
+	keys := jwks.NewClient(cfg.APIURL+"/.well-known/jwks.json", cfg.JWKSCacheTTL)
+	api := hrapi.New(hrapi.Config{BaseURL: cfg.APIURL})
+	sessions := session.NewManager(cfg, keys, api)
+	requireSession := middlewares.RequireSession(log, sessions)
+
+	authHandler := auth.New(log, cfg, sessions, protector, api)
+	app.Get("/", authHandler.LoginPage)
+	app.Post("/v1/login", authHandler.Login)
+	app.Post("/login/2fa", authHandler.LoginTOTP)
+	app.Post("/logout", requireSession, authHandler.Logout)
+
+	employeesHandler := employees.New(log, sessions, api)
+	app.Get(pages.DirectoryPath, requireSession, employeesHandler.Directory)
+	app.Get(pages.NewEmployeePath, requireSession, employeesHandler.NewPage)
+	app.Post("/employees", requireSession, employeesHandler.Create)
+	app.Get("/employees/:id/edit", requireSession, employeesHandler.EditPage)
+	app.Post("/employees/:id", requireSession, employeesHandler.Update)
 }
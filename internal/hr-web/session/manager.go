@@ -0,0 +1,164 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/shared/hrapi"
+	"web-boilerplate/shared/jwks"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// reuseWindow is how long the tokens of a refresh are handed to other requests presenting
+// the same refresh token. hr-api revokes the whole family when a rotated token is used again,
+// which would sign out a user whose tabs load while the browser hasn't stored the new cookies yet.
+const reuseWindow = 30 * time.Second
+
+// ErrRefreshRefused is returned when hr-api doesn't accept the refresh token anymore,
+// the user has to sign in again
+var ErrRefreshRefused = errors.New("refresh token refused")
+
+// Manager verifies the tokens of the session cookies and refreshes them against hr-api.
+// Refreshes are shared by the requests of a same session, which only holds within one instance
+// of hr-web: several instances need sticky sessions.
+type Manager struct {
+	cfg  *config.Config
+	keys *jwks.Client
+	api  *hrapi.Client
+
+	mu        sync.Mutex
+	rotations map[[sha256.Size]byte]*rotation
+}
+
+// rotation is a refresh in progress or done less than reuseWindow ago
+type rotation struct {
+	done   chan struct{}
+	tokens hrapi.TokenResponse
+	err    error
+	at     time.Time
+}
+
+func NewManager(cfg *config.Config, keys *jwks.Client, api *hrapi.Client) *Manager {
+	return &Manager{
+		cfg:       cfg,
+		keys:      keys,
+		api:       api,
+		rotations: map[[sha256.Size]byte]*rotation{},
+	}
+}
+
+// Verify checks the signature, expiry and type of an access token and returns its user
+func (m *Manager) Verify(raw string) (User, error) {
+	token, err := jwt.Parse(raw, m.keys.Keyfunc,
+		jwt.WithValidMethods([]string{"EdDSA", "RS256"}),
+		jwt.WithExpirationRequired())
+	if err != nil {
+		return User{}, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return User{}, errors.New("invalid token claims")
+	}
+	if typ, _ := claims["typ"].(string); typ != "access" {
+		return User{}, fmt.Errorf("unexpected token type %q", claims["typ"])
+	}
+
+	user := User{}
+	user.ID, _ = claims["id"].(string)
+	user.Role, _ = claims["role"].(string)
+	if user.ID == "" {
+		return User{}, errors.New("token has no id claim")
+	}
+	permissions, _ := claims["permissions"].([]any)
+	for _, permission := range permissions {
+		if p, ok := permission.(string); ok {
+			user.Permissions = append(user.Permissions, p)
+		}
+	}
+
+	return user, nil
+}
+
+// Refresh exchanges a refresh token for a new pair. Concurrent and recent calls with the same
+// refresh token get the result of the first one instead of presenting the token again.
+func (m *Manager) Refresh(ctx context.Context, refreshToken string) (hrapi.TokenResponse, error) {
+	key := sha256.Sum256([]byte(refreshToken))
+
+	m.mu.Lock()
+	for k, r := range m.rotations {
+		if !r.at.IsZero() && time.Since(r.at) > reuseWindow {
+			delete(m.rotations, k)
+		}
+	}
+	if r, ok := m.rotations[key]; ok {
+		m.mu.Unlock()
+		select {
+		case <-r.done:
+			return r.tokens, r.err
+		case <-ctx.Done():
+			return hrapi.TokenResponse{}, ctx.Err()
+		}
+	}
+	r := &rotation{done: make(chan struct{})}
+	m.rotations[key] = r
+	m.mu.Unlock()
+
+	// the refresh isn't bound to the first request, the others are waiting for it
+	tokens, err := m.refresh(context.WithoutCancel(ctx), refreshToken)
+
+	m.mu.Lock()
+	r.tokens, r.err, r.at = tokens, err, time.Now()
+	// only the waiting requests get a failure, the next ones try again
+	if err != nil {
+		delete(m.rotations, key)
+	}
+	m.mu.Unlock()
+	close(r.done)
+
+	return tokens, err
+}
+
+func (m *Manager) refresh(ctx context.Context, refreshToken string) (hrapi.TokenResponse, error) {
+	tokens, err := m.api.RefreshToken(ctx, refreshToken)
+	if hrapi.HasStatus(err, http.StatusUnauthorized) {
+		return tokens, ErrRefreshRefused
+	}
+	return tokens, err
+}
+
+// Start stores the tokens in the session cookies
+func (m *Manager) Start(c fiber.Ctx, tokens hrapi.TokenResponse) {
+	c.Cookie(m.cookie(AccessCookie, tokens.Token, time.Now().Add(time.Duration(tokens.ExpiresIn)*time.Second)))
+	c.Cookie(m.cookie(RefreshCookie, tokens.RefreshToken, time.Now().Add(m.cfg.SessionTTL)))
+}
+
+// End removes the session cookies
+func (m *Manager) End(c fiber.Ctx) {
+	for _, name := range []string{AccessCookie, RefreshCookie} {
+		cookie := m.cookie(name, "", time.Unix(0, 0))
+		cookie.MaxAge = -1
+		c.Cookie(cookie)
+	}
+}
+
+// cookie returns a session cookie, hidden from scripts, only sent over https in production
+// and not sent by requests started from other sites except top level navigations
+func (m *Manager) cookie(name, value string, expires time.Time) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Expires:  expires,
+		Secure:   m.cfg.IsProd,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	}
+}
@@ -0,0 +1,250 @@
+package session
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"web-boilerplate/internal/hr-web/config"
+	"web-boilerplate/shared/hrapi"
+	"web-boilerplate/shared/jwks"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKeyID = "test-key"
+
+// testAPI is an hr-api serving its key set and refreshing tokens with refresh
+type testAPI struct {
+	private   ed25519.PrivateKey
+	refreshes atomic.Int32
+	refresh   func(w http.ResponseWriter, refreshToken string)
+}
+
+func newTestManager(t *testing.T, cfg *config.Config) (*Manager, *testAPI) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := jwks.NewKey(testKeyID, "EdDSA", public)
+	require.NoError(t, err)
+
+	api := &testAPI{private: private}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jwks.Set{Keys: []jwks.Key{key}})
+	})
+	mux.HandleFunc("POST /v1/token/refresh", func(w http.ResponseWriter, r *http.Request) {
+		api.refreshes.Add(1)
+		var body struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		api.refresh(w, body.RefreshToken)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	if cfg == nil {
+		cfg = &config.Config{SessionTTL: 24 * time.Hour}
+	}
+	client := hrapi.New(hrapi.Config{BaseURL: server.URL, MaxRetries: -1})
+	return NewManager(cfg, jwks.NewClient(server.URL+"/.well-known/jwks.json", time.Hour), client), api
+}
+
+func (a *testAPI) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(a.private)
+	require.NoError(t, err)
+	return signed
+}
+
+func (a *testAPI) accessToken(t *testing.T, expires time.Time) string {
+	return a.sign(t, jwt.MapClaims{
+		"typ":         "access",
+		"id":          "user-id",
+		"role":        "hr_manager",
+		"permissions": []string{"employees:read", "employees:write"},
+		"exp":         expires.Unix(),
+	})
+}
+
+func TestManager_Verify(t *testing.T) {
+	manager, api := newTestManager(t, nil)
+
+	user, err := manager.Verify(api.accessToken(t, time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+	assert.Equal(t, User{ID: "user-id", Role: "hr_manager", Permissions: []string{"employees:read", "employees:write"}}, user)
+	assert.True(t, user.Can("employees:write"))
+	assert.False(t, user.Can("users:write"))
+}
+
+func TestManager_VerifyRefusesInvalidTokens(t *testing.T) {
+	manager, api := newTestManager(t, nil)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"typ": "access", "id": "user-id", "exp": time.Now().Add(time.Minute).Unix()})
+	forged.Header["kid"] = testKeyID
+	forgedToken, err := forged.SignedString(otherKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"expired", api.accessToken(t, time.Now().Add(-time.Minute))},
+		{"without expiry", api.sign(t, jwt.MapClaims{"typ": "access", "id": "user-id"})},
+		{"2FA challenge", api.sign(t, jwt.MapClaims{"typ": "mfa_challenge", "id": "user-id", "exp": time.Now().Add(time.Minute).Unix()})},
+		{"without id", api.sign(t, jwt.MapClaims{"typ": "access", "exp": time.Now().Add(time.Minute).Unix()})},
+		{"signed with another key", forgedToken},
+		{"malformed", "not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := manager.Verify(tt.token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestManager_RefreshSharesRotations(t *testing.T) {
+	manager, api := newTestManager(t, nil)
+	release := make(chan struct{})
+	api.refresh = func(w http.ResponseWriter, refreshToken string) {
+		<-release
+		json.NewEncoder(w).Encode(hrapi.TokenResponse{Token: "access-2", RefreshToken: "refresh-2", ExpiresIn: 900})
+	}
+
+	// the tabs of a browser load together with the same expired session
+	var wg sync.WaitGroup
+	results := make([]hrapi.TokenResponse, 5)
+	for i := range results {
+		wg.Go(func() {
+			tokens, err := manager.Refresh(context.Background(), "refresh-1")
+			assert.NoError(t, err)
+			results[i] = tokens
+		})
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for _, tokens := range results {
+		assert.Equal(t, "refresh-2", tokens.RefreshToken)
+	}
+	// a request loading after the rotation still gets the same pair
+	tokens, err := manager.Refresh(context.Background(), "refresh-1")
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", tokens.Token)
+	assert.Equal(t, int32(1), api.refreshes.Load())
+}
+
+func TestManager_RefreshRefused(t *testing.T) {
+	manager, api := newTestManager(t, nil)
+	api.refresh = func(w http.ResponseWriter, refreshToken string) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+
+	_, err := manager.Refresh(context.Background(), "revoked")
+	assert.ErrorIs(t, err, ErrRefreshRefused)
+}
+
+func TestManager_RefreshFailureIsNotKept(t *testing.T) {
+	manager, api := newTestManager(t, nil)
+	api.refresh = func(w http.ResponseWriter, refreshToken string) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_, err := manager.Refresh(context.Background(), "refresh-1")
+	assert.True(t, hrapi.HasStatus(err, http.StatusServiceUnavailable))
+	assert.NotErrorIs(t, err, ErrRefreshRefused)
+
+	// hr-api is back, the next request tries again
+	api.refresh = func(w http.ResponseWriter, refreshToken string) {
+		json.NewEncoder(w).Encode(hrapi.TokenResponse{Token: "access-2", RefreshToken: "refresh-2", ExpiresIn: 900})
+	}
+	tokens, err := manager.Refresh(context.Background(), "refresh-1")
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-2", tokens.RefreshToken)
+	assert.Equal(t, int32(2), api.refreshes.Load())
+}
+
+// sessionCookies runs fn on a request and returns the cookies of its response by name
+func sessionCookies(t *testing.T, fn func(c fiber.Ctx)) map[string]*http.Cookie {
+	app := fiber.New()
+	app.Get("/", func(c fiber.Ctx) error {
+		fn(c)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range resp.Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return cookies
+}
+
+func TestManager_Start(t *testing.T) {
+	manager, _ := newTestManager(t, nil)
+
+	cookies := sessionCookies(t, func(c fiber.Ctx) {
+		manager.Start(c, hrapi.TokenResponse{Token: "access", RefreshToken: "refresh", ExpiresIn: 900})
+	})
+
+	require.Len(t, cookies, 2)
+	access, refresh := cookies[AccessCookie], cookies[RefreshCookie]
+	assert.Equal(t, "access", access.Value)
+	assert.Equal(t, "refresh", refresh.Value)
+	// the access cookie goes with the token, the refresh one lasts the session
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), access.Expires, 5*time.Second)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), refresh.Expires, 5*time.Second)
+	for _, cookie := range cookies {
+		assert.Equal(t, "/", cookie.Path)
+		assert.True(t, cookie.HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+		// plain http in development
+		assert.False(t, cookie.Secure)
+	}
+}
+
+func TestManager_StartSecureInProduction(t *testing.T) {
+	cfg := &config.Config{SessionTTL: time.Hour}
+	cfg.IsProd = true
+	manager, _ := newTestManager(t, cfg)
+
+	cookies := sessionCookies(t, func(c fiber.Ctx) {
+		manager.Start(c, hrapi.TokenResponse{Token: "access", RefreshToken: "refresh", ExpiresIn: 900})
+	})
+
+	assert.True(t, cookies[AccessCookie].Secure)
+	assert.True(t, cookies[RefreshCookie].Secure)
+}
+
+func TestManager_End(t *testing.T) {
+	manager, _ := newTestManager(t, nil)
+
+	cookies := sessionCookies(t, manager.End)
+
+	require.Len(t, cookies, 2)
+	for _, name := range []string{AccessCookie, RefreshCookie} {
+		cookie := cookies[name]
+		require.NotNil(t, cookie, name)
+		assert.Empty(t, cookie.Value)
+		assert.Equal(t, "/", cookie.Path)
+		// Max-Age=0 on the wire, the browser drops the cookie right away
+		assert.Equal(t, -1, cookie.MaxAge)
+		assert.True(t, cookie.Expires.Before(time.Now()))
+	}
+}
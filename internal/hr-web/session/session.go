@@ -0,0 +1,70 @@
+// Package session keeps the hr-api tokens of a signed in user in cookies and exposes the user
+// they belong to.
+//
+// Both tokens live in HttpOnly cookies so scripts never see them. The access token is verified
+// against the key set of hr-api on every request and exchanged for a new pair with the refresh
+// token once it expires, which is transparent to the user until the refresh token itself expires
+// or is revoked.
+package session
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	// AccessCookie holds the access token, it expires with the token
+	AccessCookie = "auth_token"
+	// RefreshCookie holds the refresh token, it expires after the configured SessionTTL
+	RefreshCookie = "refresh_token"
+)
+
+// User is the signed in user, as described by the claims of the access token
+type User struct {
+	ID          string
+	Role        string
+	Permissions []string
+}
+
+// Can reports whether the role of the user grants permission
+func (u User) Can(permission string) bool {
+	return slices.Contains(u.Permissions, permission)
+}
+
+// locals keys, the values are read back from the request context by the templ components
+type (
+	userKey        struct{}
+	accessTokenKey struct{}
+)
+
+// SetUser makes the user and its access token available to the rest of the request,
+// fiber's locals are the values of its context
+func SetUser(c fiber.Ctx, user User, accessToken string) {
+	c.Locals(userKey{}, user)
+	c.Locals(accessTokenKey{}, accessToken)
+}
+
+// CurrentUser returns the signed in user, ctx is the fiber.Ctx of the request or the context
+// a templ component is rendered with
+func CurrentUser(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userKey{}).(User)
+	return user, ok
+}
+
+// AccessToken returns the access token of the signed in user, to call hr-api on their behalf
+func AccessToken(ctx context.Context) string {
+	token, _ := ctx.Value(accessTokenKey{}).(string)
+	return token
+}
+
+// ReturnTo returns target when it is a path of this site, or fallback. It keeps the return-to
+// parameter of the login page from redirecting to other sites.
+func ReturnTo(target, fallback string) string {
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") {
+		return fallback
+	}
+	return target
+}
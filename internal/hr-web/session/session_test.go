@@ -0,0 +1,28 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReturnTo(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"/employees/new", "/employees/new"},
+		{"/home?q=ada&sort=email", "/home?q=ada&sort=email"},
+		{"", "/home"},
+		{"home", "/home"},
+		{"https://evil.example", "/home"},
+		{"//evil.example", "/home"},
+		{"/\\evil.example", "/home"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			assert.Equal(t, tt.want, ReturnTo(tt.target, "/home"))
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Package config loads the typed configuration of the hr applications.
+//
+// Each application describes its settings as a struct embedding Common and tagging its fields
+// with `env` and `yaml`. Load fills it from its defaults, then from the optional YAML file named
+// by CONFIG_FILE, then from the environment (.env files included) and validates the result.
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"web-boilerplate/shared/tracing"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultSecretKey is the development secret, production refuses to start with it
+const DefaultSecretKey = "qweasd123"
+
+var logLevels = []string{"debug", "info", "warn", "error", "fatal"}
+
+// Common holds the settings every application has
+type Common struct {
+	IsProd         bool          `yaml:"is_prod" env:"IS_PROD"`
+	LogLevel       string        `yaml:"log_level" env:"LOG_LEVEL"`
+	BaseURL        string        `yaml:"base_url" env:"BASE_URL"`
+	AllowedOrigins string        `yaml:"allowed_origins" env:"ALLOWED_ORIGINS"`
+	DatabaseURL    string        `yaml:"database_url" env:"DATABASE_URL"`
+	RedisURL       string        `yaml:"redis_url" env:"REDIS_URL"`
+	RedisKeysTTL   time.Duration `yaml:"redis_keys_ttl" env:"REDIS_KEYS_TTL"`
+
+	ShutdownTimeout    time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	ShutdownDrainDelay time.Duration `yaml:"shutdown_drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+
+	HealthCacheTTL     time.Duration `yaml:"health_cache_ttl" env:"HEALTH_CACHE_TTL"`
+	HealthCheckTimeout time.Duration `yaml:"health_check_timeout" env:"HEALTH_CHECK_TIMEOUT"`
+
+	TracingExporter string `yaml:"tracing_exporter" env:"TRACING_EXPORTER"`
+	TracingFile     string `yaml:"tracing_file" env:"TRACING_FILE"`
+}
+
+// Validator is implemented by the application configs, Validate returns a *ValidationError
+// listing every invalid field
+type Validator interface {
+	Validate() error
+}
+
+// Validate adds the problems of the common settings to errs
+func (c Common) Validate(errs *ValidationError) {
+	if c.BaseURL == "" {
+		errs.Add("BASE_URL", "must be set")
+	}
+	if !slices.Contains(logLevels, c.LogLevel) {
+		errs.Add("LOG_LEVEL", "must be one of %s", strings.Join(logLevels, ", "))
+	}
+	if c.RedisKeysTTL <= 0 {
+		errs.Add("REDIS_KEYS_TTL", "must be a positive duration")
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs.Add("SHUTDOWN_TIMEOUT", "must be a positive duration")
+	}
+	if c.ShutdownDrainDelay < 0 {
+		errs.Add("SHUTDOWN_DRAIN_DELAY", "must not be negative")
+	}
+	if c.HealthCacheTTL < 0 {
+		errs.Add("HEALTH_CACHE_TTL", "must not be negative")
+	}
+	if c.HealthCheckTimeout <= 0 {
+		errs.Add("HEALTH_CHECK_TIMEOUT", "must be a positive duration")
+	}
+	switch c.TracingExporter {
+	case tracing.ExporterNone, tracing.ExporterStdout:
+	case tracing.ExporterFile:
+		if c.TracingFile == "" {
+			errs.Add("TRACING_FILE", "must be set when TRACING_EXPORTER is %s", tracing.ExporterFile)
+		}
+	default:
+		errs.Add("TRACING_EXPORTER", "must be one of %s, %s, %s", tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterFile)
+	}
+	// In production, require explicit ALLOWED_ORIGINS to be set
+	if c.IsProd && c.AllowedOrigins == "" {
+		errs.Add("ALLOWED_ORIGINS", "must be set in production environment")
+	}
+}
+
+// Origins returns the allowed CORS origins, any origin outside production when none are set
+func (c Common) Origins() []string {
+	if c.AllowedOrigins == "" {
+		return []string{"*"}
+	}
+
+	origins := strings.Split(c.AllowedOrigins, ",")
+	for i, origin := range origins {
+		origins[i] = strings.TrimSpace(origin)
+	}
+	return origins
+}
+
+// Load fills cfg, a pointer to a struct already holding its defaults. The first existing
+// file of envFiles is loaded into the environment first, variables already set win.
+//
+// Usage:
+//
+//	cfg := Default()
+//	err := config.Load(&cfg, ".env", "cmd/hrapp-api/.env")
+func Load(cfg Validator, envFiles ...string) error {
+	if err := LoadEnvFile(envFiles...); err != nil {
+		return err
+	}
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading config file, %w", err)
+		}
+		if err := yaml.Unmarshal(raw, cfg); err != nil {
+			return fmt.Errorf("error parsing config file %s, %w", path, err)
+		}
+	}
+
+	errs := &ValidationError{}
+	applyEnv(reflect.ValueOf(cfg).Elem(), errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	return cfg.Validate()
+}
+
+// LoadEnvFile loads the first existing file of paths into the environment
+func LoadEnvFile(paths ...string) error {
+	for _, path := range paths {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			err := godotenv.Load(path)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Loaded .env from: %s\n", path)
+			return nil
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv sets the fields tagged with `env` whose variable is set, nested structs are walked
+func applyEnv(v reflect.Value, errs *ValidationError) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && field.IsExported() {
+				applyEnv(value, errs)
+			}
+			continue
+		}
+
+		raw, ok := os.LookupEnv(name)
+		if !ok || raw == "" {
+			continue
+		}
+
+		switch {
+		case field.Type == durationType:
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				errs.Add(name, "must be a duration such as 15m or 24h")
+				continue
+			}
+			value.SetInt(int64(d))
+		case field.Type.Kind() == reflect.String:
+			value.SetString(raw)
+		case field.Type.Kind() == reflect.Bool:
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				errs.Add(name, "must be true or false")
+				continue
+			}
+			value.SetBool(b)
+		case field.Type.Kind() == reflect.Int, field.Type.Kind() == reflect.Int64:
+			n, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				errs.Add(name, "must be an integer")
+				continue
+			}
+			value.SetInt(n)
+		default:
+			errs.Add(name, "has the unsupported type %s", field.Type)
+		}
+	}
+}
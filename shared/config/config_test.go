@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig is an application config whose validation is mocked
+type testConfig struct {
+	*MockValidator `yaml:"-"`
+
+	Common  Common        `yaml:"common"`
+	Name    string        `yaml:"name" env:"TEST_NAME"`
+	Workers int           `yaml:"workers" env:"TEST_WORKERS"`
+	Timeout time.Duration `yaml:"timeout" env:"TEST_TIMEOUT"`
+}
+
+func newTestConfig(t *testing.T) *testConfig {
+	return &testConfig{
+		MockValidator: NewMockValidator(t),
+		Name:          "default",
+		Workers:       1,
+		Timeout:       time.Second,
+	}
+}
+
+func TestLoad_EnvOverridesFileAndDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("name: from-file\nworkers: 4\ncommon:\n  log_level: warn\n"), 0o600))
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("TEST_WORKERS", "8")
+	t.Setenv("TEST_TIMEOUT", "")
+	t.Setenv("LOG_LEVEL", "debug")
+
+	cfg := newTestConfig(t)
+	cfg.EXPECT().Validate().Return(nil).Once()
+
+	require.NoError(t, Load(cfg))
+	assert.Equal(t, "from-file", cfg.Name)
+	assert.Equal(t, 8, cfg.Workers)
+	// empty variables keep the value
+	assert.Equal(t, time.Second, cfg.Timeout)
+	// nested settings are walked too
+	assert.Equal(t, "debug", cfg.Common.LogLevel)
+}
+
+func TestLoad_ReturnsValidateError(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	invalid := &ValidationError{}
+	invalid.Add("TEST_NAME", "must be set")
+
+	cfg := newTestConfig(t)
+	cfg.EXPECT().Validate().Return(invalid).Once()
+
+	err := Load(cfg)
+	assert.Same(t, invalid, err)
+}
+
+func TestLoad_InvalidEnvSkipsValidate(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("TEST_TIMEOUT", "soon")
+	t.Setenv("TEST_WORKERS", "many")
+
+	// Validate has no expectation, the mock fails the test when it is called
+	cfg := newTestConfig(t)
+
+	err := Load(cfg)
+	var invalid *ValidationError
+	require.True(t, errors.As(err, &invalid))
+	assert.Equal(t, []FieldError{
+		{Field: "TEST_WORKERS", Message: "must be an integer"},
+		{Field: "TEST_TIMEOUT", Message: "must be a duration such as 15m or 24h"},
+	}, invalid.Fields)
+}
+
+func TestLoad_MissingConfigFile(t *testing.T) {
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	err := Load(newTestConfig(t))
+	assert.ErrorContains(t, err, "error reading config file")
+}
+
+func TestLoad_EnvFile(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("TEST_NAME", "from-environment")
+	// unset by the end of the test, godotenv sets the variables it loads
+	t.Setenv("TEST_WORKERS", "")
+	os.Unsetenv("TEST_WORKERS")
+
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("TEST_NAME=from-file\nTEST_WORKERS=3\n"), 0o600))
+
+	cfg := newTestConfig(t)
+	cfg.EXPECT().Validate().Return(nil).Once()
+
+	require.NoError(t, Load(cfg, filepath.Join(dir, "missing.env"), envFile))
+	// variables already set win over the file
+	assert.Equal(t, "from-environment", cfg.Name)
+	assert.Equal(t, 3, cfg.Workers)
+}
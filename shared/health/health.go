@@ -0,0 +1,179 @@
+// Package health runs the dependency checks behind the readiness and health endpoints.
+// Results are cached so frequent probes don't hammer the dependencies.
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp Status = "up"
+	// StatusDegraded means only optional checks are failing, the app still serves
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function, such as a pool's Ping method, to a Checker
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type Result struct {
+	Status    Status  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Critical  bool    `json:"critical"`
+	Error     string  `json:"error,omitempty"`
+	Err       error   `json:"-"`
+}
+
+type Report struct {
+	Status    Status            `json:"status"`
+	CheckedAt time.Time         `json:"checked_at"`
+	Checks    map[string]Result `json:"checks"`
+}
+
+// Healthy reports whether every critical check passed
+func (r Report) Healthy() bool {
+	return r.Status != StatusDown
+}
+
+type check struct {
+	name     string
+	checker  Checker
+	critical bool
+}
+
+// Registry holds the checks of an app.
+//
+// Usage:
+//
+//	checks := health.NewRegistry(5*time.Second, 2*time.Second)
+//	checks.Register("postgres", health.CheckerFunc(dbInst.Pool.Ping))
+//	report := checks.Check(ctx)
+type Registry struct {
+	ttl     time.Duration
+	timeout time.Duration
+
+	mu     sync.Mutex
+	checks []check
+	report *Report
+}
+
+// NewRegistry returns a registry caching reports for ttl, each check is given timeout to answer
+func NewRegistry(ttl, timeout time.Duration) *Registry {
+	return &Registry{
+		ttl:     ttl,
+		timeout: timeout,
+	}
+}
+
+// Register adds a critical check, the app isn't ready while it fails
+func (r *Registry) Register(name string, checker Checker) {
+	r.add(check{name: name, checker: checker, critical: true})
+}
+
+// RegisterOptional adds a check that only degrades the report when it fails
+func (r *Registry) RegisterOptional(name string, checker Checker) {
+	r.add(check{name: name, checker: checker})
+}
+
+func (r *Registry) add(c check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, c)
+	r.report = nil
+}
+
+// Check runs every check concurrently, or returns the cached report when it is younger than ttl.
+// Concurrent callers wait for the running checks instead of starting their own.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.report != nil && time.Since(r.report.CheckedAt) < r.ttl {
+		return *r.report
+	}
+
+	// a cancelled probe must not be cached as a failing dependency
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+	defer cancel()
+
+	results := make([]Result, len(r.checks))
+	var wg sync.WaitGroup
+	for i, c := range r.checks {
+		wg.Go(func() {
+			results[i] = run(ctx, c)
+		})
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:    StatusUp,
+		CheckedAt: time.Now(),
+		Checks:    make(map[string]Result, len(r.checks)),
+	}
+	for i, c := range r.checks {
+		result := results[i]
+		report.Checks[c.name] = result
+		if result.Status == StatusUp {
+			continue
+		}
+		if c.critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+
+	r.report = &report
+	return report
+}
+
+func run(ctx context.Context, c check) Result {
+	start := time.Now()
+	err := c.checker.Check(ctx)
+	result := Result{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		Critical:  c.critical,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+		result.Err = err
+	}
+	return result
+}
+
+// HTTPChecker checks that a GET on url answers with a 2xx status
+func HTTPChecker(url string) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	})
+}
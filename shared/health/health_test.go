@@ -0,0 +1,106 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegistry_CriticalFailureIsDown(t *testing.T) {
+	db := NewMockChecker(t)
+	db.EXPECT().Check(mock.Anything).Return(errors.New("connection refused"))
+	cache := NewMockChecker(t)
+	cache.EXPECT().Check(mock.Anything).Return(nil)
+
+	checks := NewRegistry(time.Minute, time.Second)
+	checks.Register("postgres", db)
+	checks.RegisterOptional("redis", cache)
+
+	report := checks.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.False(t, report.Healthy())
+	assert.Equal(t, StatusDown, report.Checks["postgres"].Status)
+	assert.Equal(t, "connection refused", report.Checks["postgres"].Error)
+	assert.True(t, report.Checks["postgres"].Critical)
+	assert.Equal(t, StatusUp, report.Checks["redis"].Status)
+	assert.False(t, report.Checks["redis"].Critical)
+}
+
+func TestRegistry_OptionalFailureDegrades(t *testing.T) {
+	db := NewMockChecker(t)
+	db.EXPECT().Check(mock.Anything).Return(nil)
+	cache := NewMockChecker(t)
+	cache.EXPECT().Check(mock.Anything).Return(errors.New("timeout"))
+
+	checks := NewRegistry(time.Minute, time.Second)
+	checks.Register("postgres", db)
+	checks.RegisterOptional("redis", cache)
+
+	report := checks.Check(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.True(t, report.Healthy())
+}
+
+func TestRegistry_CachesReport(t *testing.T) {
+	db := NewMockChecker(t)
+	db.EXPECT().Check(mock.Anything).Return(nil).Once()
+
+	checks := NewRegistry(time.Minute, time.Second)
+	checks.Register("postgres", db)
+
+	first := checks.Check(context.Background())
+	second := checks.Check(context.Background())
+	assert.Equal(t, first.CheckedAt, second.CheckedAt)
+}
+
+func TestRegistry_RegisterDropsCachedReport(t *testing.T) {
+	db := NewMockChecker(t)
+	db.EXPECT().Check(mock.Anything).Return(nil).Twice()
+	cache := NewMockChecker(t)
+	cache.EXPECT().Check(mock.Anything).Return(nil).Once()
+
+	checks := NewRegistry(time.Minute, time.Second)
+	checks.Register("postgres", db)
+	checks.Check(context.Background())
+
+	checks.RegisterOptional("redis", cache)
+	report := checks.Check(context.Background())
+	assert.Len(t, report.Checks, 2)
+}
+
+func TestRegistry_CancelledProbeStillChecks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	db := NewMockChecker(t)
+	db.EXPECT().Check(mock.MatchedBy(func(ctx context.Context) bool {
+		_, hasDeadline := ctx.Deadline()
+		return ctx.Err() == nil && hasDeadline
+	})).Return(nil)
+
+	checks := NewRegistry(time.Minute, time.Second)
+	checks.Register("postgres", db)
+
+	report := checks.Check(ctx)
+	assert.Equal(t, StatusUp, report.Status)
+}
+
+func TestHTTPChecker(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	checker := HTTPChecker(srv.URL)
+	assert.NoError(t, checker.Check(context.Background()))
+
+	status = http.StatusServiceUnavailable
+	assert.EqualError(t, checker.Check(context.Background()), "unexpected status 503")
+}
@@ -3,14 +3,14 @@ package helpers
 import (
 	"context"
 	"mime/multipart"
-	"web-boilerplate/internal/hr-api/config"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	s3storage "github.com/gofiber/storage/s3"
 )
 
-func FileUploadToS3(file *multipart.FileHeader, bucketname, key string) error {
+func FileUploadToS3(client *s3storage.Storage, file *multipart.FileHeader, bucketname, key string) error {
 	src, err := file.Open()
 
 	if err != nil {
@@ -19,7 +19,6 @@ func FileUploadToS3(file *multipart.FileHeader, bucketname, key string) error {
 
 	defer src.Close()
 
-	client := config.GetS3Storage(bucketname)
 	// Upload the file to S3
 	_, err = client.Conn().PutObject(context.TODO(), &s3.PutObjectInput{
 		ACL:                types.ObjectCannedACLPublicRead,
@@ -36,3 +35,11 @@ func FileUploadToS3(file *multipart.FileHeader, bucketname, key string) error {
 
 	return err
 }
+
+// S3BucketReachable checks the bucket exists and the credentials can access it
+func S3BucketReachable(ctx context.Context, client *s3storage.Storage, bucketname string) error {
+	_, err := client.Conn().HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucketname),
+	})
+	return err
+}
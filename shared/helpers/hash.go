@@ -60,3 +60,11 @@ func HashAny(hashThis any) ([32]byte, error) {
 
 	return sha256.Sum256(gobBuffer.Bytes()), nil
 }
+
+// HashToken computes the hex encoded SHA256 of a high entropy token generated by GenerateToken,
+// suitable for storing the token server side and looking it up by hash.
+// Note: This is NOT suitable for password hashing - use HashPass instead.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,17 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// GenerateToken returns a url safe random token built from n bytes of crypto/rand entropy.
+// Use it for opaque secrets such as refresh or reset tokens and store them with HashToken.
+func GenerateToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("error generating token err: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,111 @@
+package hrapi
+
+import (
+	"context"
+	"net/http"
+)
+
+type LoginParams struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// TokenResponse is a new session: a short lived access token and the refresh token to renew it
+type TokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the lifetime of the access token in seconds
+	ExpiresIn int64  `json:"expires_in"`
+	ID        string `json:"id"`
+}
+
+// LoginResponse is a session, or a 2FA challenge to answer with LoginTOTP when MFARequired is set
+type LoginResponse struct {
+	TokenResponse
+	MFARequired    bool   `json:"mfa_required"`
+	ChallengeToken string `json:"challenge_token"`
+}
+
+type LoginTOTPParams struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+type PasswordResetConfirmParams struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type TOTPEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TOTPActivateResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Login checks the credentials of a user
+func (c *Client) Login(ctx context.Context, params LoginParams) (LoginResponse, error) {
+	var resp LoginResponse
+	err := c.callOnce(ctx, http.MethodPost, "/v1/login", nil, params, &resp)
+	return resp, err
+}
+
+// LoginTOTP answers the 2FA challenge of a login with a TOTP or recovery code
+func (c *Client) LoginTOTP(ctx context.Context, params LoginTOTPParams) (TokenResponse, error) {
+	var resp TokenResponse
+	err := c.callOnce(ctx, http.MethodPost, "/v1/login/2fa", nil, params, &resp)
+	return resp, err
+}
+
+// RefreshToken exchanges a refresh token for a new pair, the given one can't be used again
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (TokenResponse, error) {
+	var resp TokenResponse
+	err := c.callOnce(ctx, http.MethodPost, "/v1/token/refresh", nil, map[string]string{"refresh_token": refreshToken}, &resp)
+	return resp, err
+}
+
+// RequestPasswordReset mails a reset link to the user with the given email,
+// it succeeds whether or not such a user exists
+func (c *Client) RequestPasswordReset(ctx context.Context, email string) error {
+	return c.call(ctx, http.MethodPost, "/v1/password/reset", nil, map[string]string{"email": email}, nil)
+}
+
+// ConfirmPasswordReset sets the new password of the user the reset token was sent to
+func (c *Client) ConfirmPasswordReset(ctx context.Context, params PasswordResetConfirmParams) error {
+	return c.call(ctx, http.MethodPost, "/v1/password/reset/confirm", nil, params, nil)
+}
+
+// Logout revokes the access token of the client and, when given, the refresh token of the session
+func (c *Client) Logout(ctx context.Context, refreshToken string) error {
+	var body any
+	if refreshToken != "" {
+		body = map[string]string{"refresh_token": refreshToken}
+	}
+	return c.call(ctx, http.MethodPost, "/v1/logout", nil, body, nil)
+}
+
+// LogoutAll revokes every token of the user of the client
+func (c *Client) LogoutAll(ctx context.Context) error {
+	return c.call(ctx, http.MethodPost, "/v1/logout/all", nil, nil, nil)
+}
+
+// EnrollTOTP creates a TOTP secret for the user of the client, it is enabled by ActivateTOTP
+func (c *Client) EnrollTOTP(ctx context.Context) (TOTPEnrollResponse, error) {
+	var resp TOTPEnrollResponse
+	err := c.call(ctx, http.MethodPost, "/v1/2fa/enroll", nil, nil, &resp)
+	return resp, err
+}
+
+// ActivateTOTP enables 2FA with a first code of the enrolled secret and returns the recovery codes
+func (c *Client) ActivateTOTP(ctx context.Context, code string) (TOTPActivateResponse, error) {
+	var resp TOTPActivateResponse
+	err := c.call(ctx, http.MethodPost, "/v1/2fa/activate", nil, map[string]string{"code": code}, &resp)
+	return resp, err
+}
+
+// DisableTOTP turns 2FA off, code is a current TOTP or recovery code
+func (c *Client) DisableTOTP(ctx context.Context, code string) error {
+	return c.call(ctx, http.MethodPost, "/v1/2fa/disable", nil, map[string]string{"code": code}, nil)
+}
@@ -0,0 +1,254 @@
+// Package hrapi is the Go client of hr-api, used by hr-web and the lambdas instead of
+// hand built requests.
+//
+// Every endpoint has a method taking and returning typed structs. Failed calls return an *Error
+// decoded from the problem document of hr-api. Calls are retried on network errors and on
+// 502, 503 and 504 answers, mutating ones with an X-Idempotency-Key kept across attempts so
+// hr-api replays the first response instead of running them twice. Login, LoginTOTP and
+// RefreshToken are never retried: hr-api doesn't keep the responses holding tokens, so a retry
+// would run them again, and a refresh token presented twice revokes the whole session.
+//
+// Usage:
+//
+//	api := hrapi.New(hrapi.Config{BaseURL: "http://localhost:3000"})
+//	tokens, err := api.Login(ctx, hrapi.LoginParams{Username: "admin", Password: "secret"})
+//	page, err := api.WithToken(tokens.Token).ListEmployees(ctx, hrapi.ListEmployeesParams{Limit: 20})
+package hrapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"web-boilerplate/shared/tracing"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// HeaderRequestID carries the id of the request a call is made for, hr-api logs it
+	// and returns it in its problem documents
+	HeaderRequestID = "X-Request-ID"
+	// HeaderIdempotencyKey is sent with every mutating call, the same for all its attempts,
+	// see WithIdempotencyKey to choose it
+	HeaderIdempotencyKey = "X-Idempotency-Key"
+)
+
+// Config of a Client, see New for the defaults
+type Config struct {
+	// BaseURL of hr-api, without trailing slash
+	BaseURL string
+	// Timeout of a single attempt
+	Timeout time.Duration
+	// MaxRetries is the number of attempts after the first one, negative disables retries
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled for each next one
+	RetryBackoff time.Duration
+	// Transport sends the requests, the default propagates the trace of the context
+	Transport http.RoundTripper
+}
+
+// Client calls hr-api. It is safe for concurrent use.
+type Client struct {
+	cfg   Config
+	http  *http.Client
+	token string
+}
+
+func New(cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 2
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 100 * time.Millisecond
+	}
+	if cfg.Transport == nil {
+		cfg.Transport = tracing.NewTransport(http.DefaultTransport)
+	}
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+
+	return &Client{
+		cfg: cfg,
+		http: &http.Client{
+			Transport: cfg.Transport,
+			Timeout:   cfg.Timeout,
+		},
+	}
+}
+
+// WithToken returns a client making its calls on behalf of the user of the access token
+func (c *Client) WithToken(token string) *Client {
+	copied := *c
+	copied.token = token
+	return &copied
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose calls carry the given request id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request id carried by the calls made with ctx
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type idempotencyKeyKey struct{}
+
+// WithIdempotencyKey returns a context whose mutating call sends key instead of a random one,
+// so a submission the browser retries with the same key is run once by hr-api. key must be a UUID,
+// and the context used for a single call.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+// IdempotencyKey returns the key set by WithIdempotencyKey
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyKey{}).(string)
+	return key
+}
+
+// call sends a request and decodes the answer into out, out may be nil when there is no body.
+// body is encoded as JSON when not nil.
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	return c.do(ctx, method, path, query, body, out, c.cfg.MaxRetries)
+}
+
+// callOnce is call without retries, for the calls hr-api doesn't deduplicate
+func (c *Client) callOnce(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	return c.do(ctx, method, path, query, body, out, 0)
+}
+
+// do is call with up to maxRetries attempts after the first one
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any, maxRetries int) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode %s %s: %w", method, path, err)
+		}
+	}
+
+	target := c.cfg.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	idempotencyKey := ""
+	if mutating(method) {
+		idempotencyKey = IdempotencyKey(ctx)
+		if idempotencyKey == "" {
+			idempotencyKey = uuid.NewString()
+		}
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		var resp *http.Response
+		resp, err = c.send(ctx, method, target, payload, idempotencyKey)
+		if err == nil {
+			if !retryableStatus(resp.StatusCode) || attempt >= maxRetries {
+				return decode(resp, out)
+			}
+			err = decode(resp, nil)
+		} else if ctx.Err() != nil || attempt >= maxRetries {
+			return fmt.Errorf("failed to call %s %s: %w", method, path, err)
+		}
+
+		if waitErr := c.wait(ctx, attempt); waitErr != nil {
+			return fmt.Errorf("failed to call %s %s: %w", method, path, errors.Join(err, waitErr))
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, target string, payload []byte, idempotencyKey string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if id := RequestID(ctx); id != "" {
+		req.Header.Set(HeaderRequestID, id)
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(HeaderIdempotencyKey, idempotencyKey)
+	}
+
+	return c.http.Do(req)
+}
+
+// wait sleeps before the next attempt, with jitter so clients retrying together spread out
+func (c *Client) wait(ctx context.Context, attempt int) error {
+	backoff := c.cfg.RetryBackoff << attempt
+	backoff += rand.N(backoff/2 + 1)
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// decode reads a successful answer into out or turns a failed one into an *Error
+func decode(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newError(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s answer: %w", resp.Request.Method, resp.Request.URL.Path, err)
+	}
+	return nil
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// retryableStatus reports whether the answer comes from a proxy or an instance that
+// couldn't serve the request, which another attempt may get through
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
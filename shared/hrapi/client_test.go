@@ -0,0 +1,334 @@
+package hrapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is an hr-api answering with the handler of each attempt in turn, the last one repeated
+type recorder struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	answers  []http.HandlerFunc
+}
+
+func newTestClient(t *testing.T, answers ...http.HandlerFunc) (*Client, *recorder) {
+	rec := &recorder{answers: answers}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, r)
+		answer := rec.answers[min(len(rec.requests), len(rec.answers))-1]
+		rec.mu.Unlock()
+		answer(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return New(Config{BaseURL: server.URL + "/", RetryBackoff: time.Millisecond}), rec
+}
+
+func (r *recorder) headers(name string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values := make([]string, 0, len(r.requests))
+	for _, req := range r.requests {
+		values = append(values, req.Header.Get(name))
+	}
+	return values
+}
+
+func status(code int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+	}
+}
+
+func answer(body any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+func problem(code int, body map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+var testEmployee = Employee{ID: "e1", FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Department: "Engineering"}
+
+func TestClient_RetriesUnavailableAnswers(t *testing.T) {
+	client, rec := newTestClient(t, status(http.StatusBadGateway), status(http.StatusServiceUnavailable), answer(testEmployee))
+
+	employee, err := client.GetEmployee(context.Background(), "e1")
+
+	require.NoError(t, err)
+	assert.Equal(t, testEmployee, employee)
+	assert.Len(t, rec.requests, 3)
+	// reads aren't deduplicated
+	assert.Equal(t, []string{"", "", ""}, rec.headers(HeaderIdempotencyKey))
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	client, rec := newTestClient(t, status(http.StatusGatewayTimeout))
+
+	_, err := client.GetEmployee(context.Background(), "e1")
+
+	assert.True(t, HasStatus(err, http.StatusGatewayTimeout))
+	assert.True(t, HasCode(err, "gateway_timeout"))
+	// the first attempt and the 2 default retries
+	assert.Len(t, rec.requests, 3)
+}
+
+func TestClient_DoesNotRetryOtherFailures(t *testing.T) {
+	for _, code := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError} {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			client, rec := newTestClient(t, status(code), answer(testEmployee))
+
+			_, err := client.GetEmployee(context.Background(), "e1")
+
+			assert.True(t, HasStatus(err, code))
+			assert.Len(t, rec.requests, 1)
+		})
+	}
+}
+
+func TestClient_RetriesNetworkErrors(t *testing.T) {
+	dropConnection := func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}
+	client, rec := newTestClient(t, dropConnection, answer(testEmployee))
+
+	employee, err := client.GetEmployee(context.Background(), "e1")
+
+	require.NoError(t, err)
+	assert.Equal(t, testEmployee, employee)
+	assert.Len(t, rec.requests, 2)
+}
+
+func TestClient_NeverRetriesCredentialCalls(t *testing.T) {
+	// hr-api may have rotated the refresh token before the answer was lost,
+	// presenting it again would revoke the session
+	dropConnection := func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}
+	client, rec := newTestClient(t, dropConnection, answer(TokenResponse{Token: "access-2", RefreshToken: "refresh-2"}))
+
+	_, err := client.RefreshToken(context.Background(), "refresh-1")
+
+	require.Error(t, err)
+	_, ok := AsError(err)
+	assert.False(t, ok)
+	assert.Len(t, rec.requests, 1)
+
+	calls := map[string]func(*Client) error{
+		"login": func(client *Client) error {
+			_, err := client.Login(context.Background(), LoginParams{Username: "ada", Password: "secret"})
+			return err
+		},
+		"login 2fa": func(client *Client) error {
+			_, err := client.LoginTOTP(context.Background(), LoginTOTPParams{ChallengeToken: "challenge-1", Code: "287082"})
+			return err
+		},
+		"refresh": func(client *Client) error {
+			_, err := client.RefreshToken(context.Background(), "refresh-1")
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			client, rec := newTestClient(t, status(http.StatusServiceUnavailable), answer(TokenResponse{}))
+
+			err := call(client)
+
+			assert.True(t, HasStatus(err, http.StatusServiceUnavailable))
+			assert.Len(t, rec.requests, 1)
+		})
+	}
+}
+
+func TestClient_RetriesDisabled(t *testing.T) {
+	client, rec := newTestClient(t, status(http.StatusServiceUnavailable), answer(testEmployee))
+	client = New(Config{BaseURL: client.cfg.BaseURL, MaxRetries: -1})
+
+	_, err := client.GetEmployee(context.Background(), "e1")
+
+	assert.True(t, HasStatus(err, http.StatusServiceUnavailable))
+	assert.Len(t, rec.requests, 1)
+}
+
+func TestClient_StopsRetryingOnceContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client, rec := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.cfg.RetryBackoff = time.Hour
+
+	_, err := client.GetEmployee(ctx, "e1")
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, rec.requests, 1)
+}
+
+func TestClient_KeepsIdempotencyKeyAcrossRetries(t *testing.T) {
+	client, rec := newTestClient(t, status(http.StatusServiceUnavailable), status(http.StatusBadGateway), answer(testEmployee))
+
+	_, err := client.CreateEmployee(context.Background(), EmployeeParams{FirstName: "Ada"})
+	require.NoError(t, err)
+
+	keys := rec.headers(HeaderIdempotencyKey)
+	require.Len(t, keys, 3)
+	assert.NoError(t, uuid.Validate(keys[0]))
+	assert.Equal(t, []string{keys[0], keys[0], keys[0]}, keys)
+
+	// every call gets its own key
+	_, err = client.CreateEmployee(context.Background(), EmployeeParams{FirstName: "Ada"})
+	require.NoError(t, err)
+	assert.NotEqual(t, keys[0], rec.headers(HeaderIdempotencyKey)[3])
+}
+
+func TestClient_SendsChosenIdempotencyKey(t *testing.T) {
+	client, rec := newTestClient(t, status(http.StatusServiceUnavailable), answer(testEmployee))
+	key := uuid.NewString()
+
+	_, err := client.UpdateEmployee(WithIdempotencyKey(context.Background(), key), "e1", EmployeeParams{FirstName: "Ada"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{key, key}, rec.headers(HeaderIdempotencyKey))
+}
+
+func TestClient_DecodesProblems(t *testing.T) {
+	client, _ := newTestClient(t, problem(http.StatusBadRequest, map[string]any{
+		"status":     400,
+		"code":       "validation_failed",
+		"title":      "Bad Request",
+		"detail":     "invalid fields",
+		"instance":   "/v1/employees",
+		"request_id": "req-1",
+		"errors": []map[string]string{
+			{"field": "email", "message": "email must be a valid email address"},
+			{"field": "department", "message": "department is required"},
+		},
+	}))
+
+	_, err := client.CreateEmployee(context.Background(), EmployeeParams{})
+
+	apiErr, ok := AsError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, apiErr.Status)
+	assert.Equal(t, "validation_failed", apiErr.Code)
+	assert.Equal(t, "req-1", apiErr.RequestID)
+	assert.Equal(t, map[string]string{
+		"email":      "email must be a valid email address",
+		"department": "department is required",
+	}, apiErr.Fields())
+	assert.Equal(t, "hr-api answered 400 validation_failed: invalid fields", err.Error())
+}
+
+func TestClient_DecodesRateLimits(t *testing.T) {
+	client, _ := newTestClient(t, problem(http.StatusTooManyRequests, map[string]any{
+		"code":        "login_locked",
+		"retry_after": 60,
+	}))
+
+	_, err := client.Login(context.Background(), LoginParams{Username: "ada", Password: "wrong"})
+
+	apiErr, ok := AsError(err)
+	require.True(t, ok)
+	assert.Equal(t, "login_locked", apiErr.Code)
+	assert.Equal(t, 60, apiErr.RetryAfter)
+	assert.Equal(t, "Too Many Requests", apiErr.Title)
+}
+
+func TestClient_DecodesAnswersThatArentProblems(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// a proxy in front of hr-api
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set(HeaderRequestID, "req-2")
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		w.Write([]byte("<html>too large</html>"))
+	})
+
+	_, err := client.GetEmployee(context.Background(), "e1")
+
+	apiErr, ok := AsError(err)
+	require.True(t, ok)
+	assert.Equal(t, &Error{
+		Status:    http.StatusRequestEntityTooLarge,
+		Code:      "request_entity_too_large",
+		Title:     "Request Entity Too Large",
+		RequestID: "req-2",
+	}, apiErr)
+}
+
+func TestClient_ForwardsToken(t *testing.T) {
+	client, rec := newTestClient(t, answer(testEmployee))
+
+	_, err := client.WithToken("access-1").GetEmployee(context.Background(), "e1")
+	require.NoError(t, err)
+	// the client it was derived from stays anonymous
+	_, err = client.GetEmployee(context.Background(), "e1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Bearer access-1", ""}, rec.headers("Authorization"))
+}
+
+func TestClient_PropagatesRequestID(t *testing.T) {
+	client, rec := newTestClient(t, status(http.StatusServiceUnavailable), answer(testEmployee))
+
+	_, err := client.GetEmployee(WithRequestID(context.Background(), "req-3"), "e1")
+	require.NoError(t, err)
+	_, err = client.GetEmployee(context.Background(), "e1")
+	require.NoError(t, err)
+
+	// every attempt carries it
+	assert.Equal(t, []string{"req-3", "req-3", ""}, rec.headers(HeaderRequestID))
+}
+
+func TestClient_BuildsQueries(t *testing.T) {
+	client, rec := newTestClient(t, answer(Page[Employee]{Data: []Employee{testEmployee}}))
+
+	page, err := client.ListEmployees(context.Background(), ListEmployeesParams{
+		PageParams: PageParams{Limit: 20, Sort: "email", Desc: true},
+		Department: "Research & Development",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []Employee{testEmployee}, page.Data)
+	require.Len(t, rec.requests, 1)
+	assert.Equal(t, "/v1/employees", rec.requests[0].URL.Path)
+	assert.Equal(t, "Research & Development", rec.requests[0].URL.Query().Get("department"))
+	assert.Equal(t, "20", rec.requests[0].URL.Query().Get("limit"))
+}
+
+func TestClient_DecodeFailure(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	})
+
+	_, err := client.GetEmployee(context.Background(), "e1")
+
+	require.Error(t, err)
+	_, ok := AsError(err)
+	assert.False(t, ok)
+	var syntaxErr *json.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+}
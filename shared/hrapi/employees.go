@@ -0,0 +1,80 @@
+package hrapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type Employee struct {
+	ID         string `json:"id"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	Email      string `json:"email"`
+	Department string `json:"department"`
+}
+
+// EmployeeParams are the fields of a created or updated employee
+type EmployeeParams struct {
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	Email      string `json:"email"`
+	Department string `json:"department"`
+}
+
+type ListEmployeesParams struct {
+	PageParams
+	Department  string
+	EmailDomain string
+	// Search matches the name or the email of the employees
+	Search string
+}
+
+// ListEmployees returns a page of employees, sorted by name, first_name, last_name, email or department
+func (c *Client) ListEmployees(ctx context.Context, params ListEmployeesParams) (Page[Employee], error) {
+	query := params.query()
+	if params.Department != "" {
+		query.Set("department", params.Department)
+	}
+	if params.EmailDomain != "" {
+		query.Set("email_domain", params.EmailDomain)
+	}
+	if params.Search != "" {
+		query.Set("q", params.Search)
+	}
+
+	var resp Page[Employee]
+	err := c.call(ctx, http.MethodGet, "/v1/employees", query, nil, &resp)
+	return resp, err
+}
+
+// ListDepartments returns the departments employees belong to, sorted
+func (c *Client) ListDepartments(ctx context.Context) ([]string, error) {
+	var resp struct {
+		Data []string `json:"data"`
+	}
+	err := c.call(ctx, http.MethodGet, "/v1/departments", nil, nil, &resp)
+	return resp.Data, err
+}
+
+func (c *Client) GetEmployee(ctx context.Context, id string) (Employee, error) {
+	var resp Employee
+	err := c.call(ctx, http.MethodGet, "/v1/employees/"+url.PathEscape(id), nil, nil, &resp)
+	return resp, err
+}
+
+func (c *Client) CreateEmployee(ctx context.Context, params EmployeeParams) (Employee, error) {
+	var resp Employee
+	err := c.call(ctx, http.MethodPost, "/v1/employees", nil, params, &resp)
+	return resp, err
+}
+
+func (c *Client) UpdateEmployee(ctx context.Context, id string, params EmployeeParams) (Employee, error) {
+	var resp Employee
+	err := c.call(ctx, http.MethodPut, "/v1/employees/"+url.PathEscape(id), nil, params, &resp)
+	return resp, err
+}
+
+func (c *Client) DeleteEmployee(ctx context.Context, id string) error {
+	return c.call(ctx, http.MethodDelete, "/v1/employees/"+url.PathEscape(id), nil, nil, nil)
+}
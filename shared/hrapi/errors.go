@@ -0,0 +1,111 @@
+package hrapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody bounds how much of a failed answer is read
+const maxErrorBody = 64 << 10
+
+// Error is a call answered with an error status, described by the problem document of hr-api.
+// Code is one of the codes of docs/error-responses.md, branch on it rather than on Detail.
+type Error struct {
+	Status    int          `json:"status"`
+	Code      string       `json:"code"`
+	Title     string       `json:"title"`
+	Detail    string       `json:"detail"`
+	Instance  string       `json:"instance"`
+	RequestID string       `json:"request_id"`
+	Errors    []FieldError `json:"errors"`
+	// RetryAfter is the number of seconds to wait before trying again, for rate limits and lockouts
+	RetryAfter int `json:"retry_after"`
+	// Missing is the permission the token lacked, for forbidden calls
+	Missing string `json:"missing"`
+}
+
+// FieldError is the reason a field of the request was refused
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	return fmt.Sprintf("hr-api answered %d %s: %s", e.Status, e.Code, msg)
+}
+
+// Fields returns the reasons of the refused fields by field name
+func (e *Error) Fields() map[string]string {
+	fields := make(map[string]string, len(e.Errors))
+	for _, field := range e.Errors {
+		fields[field.Field] = field.Message
+	}
+	return fields
+}
+
+// AsError returns the *Error of a failed call, if err is one
+func AsError(err error) (*Error, bool) {
+	var apiErr *Error
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// HasStatus reports whether err is an *Error with the given status
+func HasStatus(err error, status int) bool {
+	apiErr, ok := AsError(err)
+	return ok && apiErr.Status == status
+}
+
+// HasCode reports whether err is an *Error with the given code
+func HasCode(err error, code string) bool {
+	apiErr, ok := AsError(err)
+	return ok && apiErr.Code == code
+}
+
+// newError decodes the problem document of a failed answer. Answers that aren't one,
+// like those of a proxy in front of hr-api, get the snake cased status text as their code.
+func newError(resp *http.Response) *Error {
+	apiErr := &Error{}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		apiErr = &Error{}
+	}
+
+	apiErr.Status = resp.StatusCode
+	if apiErr.Title == "" {
+		apiErr.Title = http.StatusText(resp.StatusCode)
+	}
+	if apiErr.Code == "" {
+		apiErr.Code = codeForStatus(resp.StatusCode)
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header.Get(HeaderRequestID)
+	}
+
+	return apiErr
+}
+
+// codeForStatus returns the code hr-api gives to errors without a specific one
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusTooManyRequests:
+		return "rate_limited"
+	case http.StatusInternalServerError:
+		return "internal_error"
+	}
+
+	text := strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(http.StatusText(status))
+	if text == "" {
+		return "error"
+	}
+	return strings.ToLower(text)
+}
@@ -0,0 +1,56 @@
+package hrapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"web-boilerplate/shared/health"
+	"web-boilerplate/shared/jwks"
+)
+
+// JWKS returns the public keys access tokens are signed with, jwks.Client caches them for verifiers
+func (c *Client) JWKS(ctx context.Context) (jwks.Set, error) {
+	var resp jwks.Set
+	err := c.call(ctx, http.MethodGet, "/.well-known/jwks.json", nil, nil, &resp)
+	return resp, err
+}
+
+// Livez fails when hr-api doesn't serve requests
+func (c *Client) Livez(ctx context.Context) error {
+	return c.call(ctx, http.MethodGet, "/livez", nil, nil, nil)
+}
+
+// Readyz fails when hr-api is shutting down or a critical dependency of it is down
+func (c *Client) Readyz(ctx context.Context) error {
+	return c.call(ctx, http.MethodGet, "/readyz", nil, nil, nil)
+}
+
+// Health returns the status of the dependencies of hr-api. The report is returned along with
+// the *Error when hr-api is down, it tells which dependency failed.
+func (c *Client) Health(ctx context.Context) (health.Report, error) {
+	var report health.Report
+
+	// not retried, the report is what is asked for even when it is bad
+	resp, err := c.send(ctx, http.MethodGet, c.cfg.BaseURL+"/v1/health", nil, "")
+	if err != nil {
+		return report, fmt.Errorf("failed to call GET /v1/health: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return report, newError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return report, fmt.Errorf("failed to decode GET /v1/health answer: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return report, &Error{
+			Status:    resp.StatusCode,
+			Code:      codeForStatus(resp.StatusCode),
+			Title:     http.StatusText(resp.StatusCode),
+			RequestID: resp.Header.Get(HeaderRequestID),
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,92 @@
+package hrapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type User struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// Page is a page of a listing, the next one is asked with Meta.NextCursor
+type Page[T any] struct {
+	Data []T      `json:"data"`
+	Meta PageMeta `json:"meta"`
+}
+
+type PageMeta struct {
+	Limit int `json:"limit"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"next_cursor"`
+	// TotalEstimate is nil for filtered listings, hr-api only counts them on request
+	TotalEstimate *int64 `json:"total_estimate"`
+}
+
+// PageParams selects a page of a listing, zero values get the defaults of hr-api
+type PageParams struct {
+	Limit int
+	// Cursor is the NextCursor of the previous page, it is only valid for the same Sort and Desc
+	Cursor string
+	Sort   string
+	Desc   bool
+}
+
+func (p PageParams) query() url.Values {
+	query := url.Values{}
+	if p.Limit > 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Cursor != "" {
+		query.Set("cursor", p.Cursor)
+	}
+	if p.Sort != "" {
+		query.Set("sort", p.Sort)
+	}
+	if p.Desc {
+		query.Set("order", "desc")
+	}
+	return query
+}
+
+type ListUsersParams struct {
+	PageParams
+	EmailDomain string
+}
+
+// Me returns the user of the client
+func (c *Client) Me(ctx context.Context) (User, error) {
+	var resp User
+	err := c.call(ctx, http.MethodGet, "/v1/me", nil, nil, &resp)
+	return resp, err
+}
+
+// ListUsers returns a page of users, sorted by name, email or username
+func (c *Client) ListUsers(ctx context.Context, params ListUsersParams) (Page[User], error) {
+	query := params.query()
+	if params.EmailDomain != "" {
+		query.Set("email_domain", params.EmailDomain)
+	}
+
+	var resp Page[User]
+	err := c.call(ctx, http.MethodGet, "/v1/users", query, nil, &resp)
+	return resp, err
+}
+
+// UpdateUserRole assigns a role to a user, the tokens issued with the previous role stop working
+func (c *Client) UpdateUserRole(ctx context.Context, id, role string) (User, error) {
+	var resp User
+	err := c.call(ctx, http.MethodPut, "/v1/users/"+url.PathEscape(id)+"/role", nil, map[string]string{"role": role}, &resp)
+	return resp, err
+}
+
+// UnlockUser lifts the login lockout of a user
+func (c *Client) UnlockUser(ctx context.Context, id string) error {
+	return c.call(ctx, http.MethodPost, "/v1/users/"+url.PathEscape(id)+"/unlock", nil, nil, nil)
+}
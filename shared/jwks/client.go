@@ -0,0 +1,158 @@
+package jwks
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// minRefreshInterval limits how often an unknown kid triggers a refetch,
+	// so tokens with made up kids can't be used to flood the issuer
+	minRefreshInterval = time.Minute
+	// retryInterval is the wait after a failed fetch before the next one,
+	// the cached keys are used meanwhile
+	retryInterval = 5 * time.Second
+)
+
+// Client verifies tokens against the key set published at a JWKS url.
+// Keys are cached for ttl and refetched early when a token names a kid that isn't known yet,
+// which is how keys added by a rotation are picked up. Concurrent requests share a single fetch,
+// only the ones waiting for an unknown kid wait for it.
+//
+// Usage:
+//
+//	keys := jwks.NewClient("http://localhost:3000/.well-known/jwks.json", time.Hour)
+//	token, err := jwt.Parse(raw, keys.Keyfunc, jwt.WithValidMethods([]string{"EdDSA", "RS256"}))
+type Client struct {
+	url   string
+	ttl   time.Duration
+	http  *http.Client
+	now   func() time.Time
+	fetch singleflight.Group
+
+	mu        sync.Mutex
+	keys      map[string]cachedKey
+	fetchedAt time.Time
+	// failedAt is the time of the last failed fetch and failure its error,
+	// no other fetch is tried before retryInterval
+	failedAt time.Time
+	failure  error
+}
+
+type cachedKey struct {
+	alg    string
+	public crypto.PublicKey
+}
+
+func NewClient(url string, ttl time.Duration) *Client {
+	return &Client{
+		url:  url,
+		ttl:  ttl,
+		http: &http.Client{Timeout: 10 * time.Second},
+		now:  time.Now,
+		keys: map[string]cachedKey{},
+	}
+}
+
+// Keyfunc is a jwt.Keyfunc returning the public key named by the token's kid header
+func (c *Client) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("token has no kid header")
+	}
+
+	key, err := c.key(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.alg != "" && key.alg != token.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.public, nil
+}
+
+func (c *Client) key(kid string) (cachedKey, error) {
+	c.mu.Lock()
+	_, ok := c.keys[kid]
+	now := c.now()
+	age := now.Sub(c.fetchedAt)
+	refetch := (age >= c.ttl || (!ok && age >= minRefreshInterval)) && now.Sub(c.failedAt) >= retryInterval
+	c.mu.Unlock()
+
+	if refetch {
+		done := c.fetch.DoChan(c.url, func() (any, error) {
+			return nil, c.refresh()
+		})
+		// a known key is used right away, the refreshed set serves the next tokens
+		if !ok {
+			<-done
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// keep verifying with the cached keys while the issuer is unreachable
+	if key, ok := c.keys[kid]; ok {
+		return key, nil
+	}
+	if c.failure != nil {
+		return cachedKey{}, fmt.Errorf("unknown key id %q: %w", kid, c.failure)
+	}
+	return cachedKey{}, fmt.Errorf("unknown key id %q", kid)
+}
+
+// refresh fetches the key set and replaces the cached keys with it, or records the failure
+func (c *Client) refresh() error {
+	keys, err := c.get()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		c.failedAt, c.failure = c.now(), err
+		return err
+	}
+	c.keys, c.fetchedAt = keys, c.now()
+	c.failedAt, c.failure = time.Time{}, nil
+	return nil
+}
+
+func (c *Client) get() (map[string]cachedKey, error) {
+	resp, err := c.http.Get(c.url)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching jwks err: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching jwks, status %d", resp.StatusCode)
+	}
+
+	var set Set
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("error decoding jwks err: %w", err)
+	}
+
+	keys := make(map[string]cachedKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		public, err := k.PublicKey()
+		if err != nil {
+			// skip key types this client doesn't know instead of failing every token
+			continue
+		}
+		keys[k.Kid] = cachedKey{alg: k.Alg, public: public}
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,244 @@
+package jwks
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issuer publishes the public keys of its signing keys, or fails with status when set
+type issuer struct {
+	mu      sync.Mutex
+	keys    map[string]ed25519.PrivateKey
+	status  int
+	fetches atomic.Int32
+	// release, when set, holds the answers until it is closed
+	release chan struct{}
+}
+
+// clock is the time of a Client under test, moved by hand
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestClient(t *testing.T, ttl time.Duration) (*Client, *issuer, *clock) {
+	iss := &issuer{keys: map[string]ed25519.PrivateKey{}}
+	iss.rotate(t, "key-1")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		iss.fetches.Add(1)
+		iss.mu.Lock()
+		release, status := iss.release, iss.status
+		set := Set{}
+		for kid, private := range iss.keys {
+			key, err := NewKey(kid, "EdDSA", private.Public())
+			require.NoError(t, err)
+			set.Keys = append(set.Keys, key)
+		}
+		iss.mu.Unlock()
+
+		if release != nil {
+			<-release
+		}
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(server.Close)
+
+	clk := &clock{now: time.Now()}
+	client := NewClient(server.URL, ttl)
+	client.now = clk.Now
+	return client, iss, clk
+}
+
+// rotate adds a signing key named kid
+func (i *issuer) rotate(t *testing.T, kid string) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys[kid] = private
+}
+
+// hold keeps the next answers until release is called
+func (i *issuer) hold() (release func()) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.release = make(chan struct{})
+	return sync.OnceFunc(func() { close(i.release) })
+}
+
+func (i *issuer) fail(status int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.status = status
+}
+
+func (i *issuer) sign(t *testing.T, kid string) string {
+	i.mu.Lock()
+	private := i.keys[kid]
+	i.mu.Unlock()
+	if private == nil {
+		// a key the issuer never published
+		_, private, _ = ed25519.GenerateKey(rand.Reader)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"id": "user-id"})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(private)
+	require.NoError(t, err)
+	return signed
+}
+
+func verify(client *Client, raw string) error {
+	_, err := jwt.Parse(raw, client.Keyfunc, jwt.WithValidMethods([]string{"EdDSA", "RS256"}))
+	return err
+}
+
+func TestClient_CachesKeys(t *testing.T) {
+	client, iss, _ := newTestClient(t, time.Hour)
+
+	for range 3 {
+		require.NoError(t, verify(client, iss.sign(t, "key-1")))
+	}
+
+	assert.Equal(t, int32(1), iss.fetches.Load())
+}
+
+func TestClient_RefetchesAfterTTL(t *testing.T) {
+	client, iss, clk := newTestClient(t, time.Hour)
+	require.NoError(t, verify(client, iss.sign(t, "key-1")))
+
+	clk.Add(time.Hour)
+	release := iss.hold()
+	defer release()
+	// the cached key is used while the key set is refetched, once
+	require.NoError(t, verify(client, iss.sign(t, "key-1")))
+	require.NoError(t, verify(client, iss.sign(t, "key-1")))
+
+	release()
+	assert.Eventually(t, func() bool {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		return client.fetchedAt.Equal(clk.Now())
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, verify(client, iss.sign(t, "key-1")))
+	assert.Equal(t, int32(2), iss.fetches.Load())
+}
+
+func TestClient_RefetchesOnUnknownKid(t *testing.T) {
+	client, iss, clk := newTestClient(t, time.Hour)
+	require.NoError(t, verify(client, iss.sign(t, "key-1")))
+
+	// signed with a key added since the last fetch
+	clk.Add(minRefreshInterval)
+	iss.rotate(t, "key-2")
+	require.NoError(t, verify(client, iss.sign(t, "key-2")))
+	assert.Equal(t, int32(2), iss.fetches.Load())
+
+	// made up kids don't refetch more than once per minRefreshInterval
+	for range 3 {
+		assert.ErrorContains(t, verify(client, iss.sign(t, "made-up")), `unknown key id "made-up"`)
+	}
+	assert.Equal(t, int32(2), iss.fetches.Load())
+
+	clk.Add(minRefreshInterval)
+	assert.Error(t, verify(client, iss.sign(t, "made-up")))
+	assert.Equal(t, int32(3), iss.fetches.Load())
+}
+
+func TestClient_SharesFetches(t *testing.T) {
+	client, iss, _ := newTestClient(t, time.Hour)
+	release := iss.hold()
+	token := iss.sign(t, "key-1")
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			assert.NoError(t, verify(client, token))
+		})
+	}
+	time.Sleep(50 * time.Millisecond)
+	release()
+	wg.Wait()
+
+	assert.Equal(t, int32(1), iss.fetches.Load())
+}
+
+func TestClient_BacksOffAfterFailure(t *testing.T) {
+	client, iss, clk := newTestClient(t, time.Hour)
+	iss.fail(http.StatusServiceUnavailable)
+
+	assert.ErrorContains(t, verify(client, iss.sign(t, "key-1")), "status 503")
+	// the issuer isn't asked again before retryInterval
+	assert.ErrorContains(t, verify(client, iss.sign(t, "key-1")), "status 503")
+	assert.Equal(t, int32(1), iss.fetches.Load())
+
+	clk.Add(retryInterval)
+	iss.fail(0)
+	require.NoError(t, verify(client, iss.sign(t, "key-1")))
+	assert.Equal(t, int32(2), iss.fetches.Load())
+}
+
+func TestClient_KeepsCachedKeysWhileIssuerIsDown(t *testing.T) {
+	client, iss, clk := newTestClient(t, time.Hour)
+	require.NoError(t, verify(client, iss.sign(t, "key-1")))
+	token := iss.sign(t, "key-1")
+	iss.fail(http.StatusBadGateway)
+
+	clk.Add(time.Hour)
+	for range 3 {
+		require.NoError(t, verify(client, token))
+	}
+
+	// a failed refetch is retried once retryInterval is over
+	assert.Eventually(t, func() bool { return iss.fetches.Load() == 2 }, time.Second, 5*time.Millisecond)
+	clk.Add(retryInterval)
+	require.NoError(t, verify(client, token))
+	assert.Eventually(t, func() bool { return iss.fetches.Load() == 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestClient_RefusesOtherAlgorithms(t *testing.T) {
+	client, iss, _ := newTestClient(t, time.Hour)
+	require.NoError(t, verify(client, iss.sign(t, "key-1")))
+
+	// the kid of an EdDSA key on a token claiming another algorithm
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": "user-id"})
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = client.Keyfunc(jwt.New(jwt.SigningMethodHS256))
+	assert.ErrorContains(t, err, "no kid")
+	parsed, _, err := jwt.NewParser().ParseUnverified(signed, jwt.MapClaims{})
+	require.NoError(t, err)
+	_, err = client.Keyfunc(parsed)
+	assert.ErrorContains(t, err, "unexpected signing method")
+}
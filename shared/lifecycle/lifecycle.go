@@ -0,0 +1,180 @@
+// Package lifecycle runs a fiber app until SIGINT or SIGTERM and then shuts it down gracefully:
+// readiness fails first, in-flight requests are drained, background workers are stopped and the
+// registered resources are closed in reverse order of registration.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+)
+
+type Config struct {
+	// ShutdownTimeout bounds the whole shutdown, requests still running after it are dropped
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long readiness fails before the listener closes,
+	// giving load balancers time to stop routing new requests to the instance
+	DrainDelay time.Duration
+}
+
+type resource struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+// closeWithin runs the closer, giving up on it once ctx is done as it may not watch ctx.
+// The resources registered before it are still asked to close then, with the expired ctx.
+func (r resource) closeWithin(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- r.close(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Manager owns the lifecycle of an app.
+//
+// Usage:
+//
+//	lc := lifecycle.New(logInst, lifecycle.Config{ShutdownTimeout: 30 * time.Second})
+//	lc.Register("database", func(ctx context.Context) error { dbInst.Close(); return nil })
+//	lc.Go("key rotation", func(ctx context.Context) { ... })
+//	err := lc.Run(app, "localhost:3000")
+type Manager struct {
+	log *zerolog.Logger
+	cfg Config
+
+	ready atomic.Bool
+
+	mu        sync.Mutex
+	resources []resource
+
+	workers      sync.WaitGroup
+	workersCtx   context.Context
+	stopWorkers  context.CancelFunc
+	shutdownOnce sync.Once
+}
+
+func New(log *zerolog.Logger, cfg Config) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		log:         log,
+		cfg:         cfg,
+		workersCtx:  ctx,
+		stopWorkers: cancel,
+	}
+}
+
+// Ready reports whether the app is serving and not shutting down
+func (m *Manager) Ready() bool {
+	return m.ready.Load()
+}
+
+// Register adds a resource closed on shutdown, after every resource registered later
+func (m *Manager) Register(name string, closer func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resources = append(m.resources, resource{name: name, close: closer})
+}
+
+// Go runs a background worker, its context is cancelled on shutdown and it is waited for
+// before the resources are closed
+func (m *Manager) Go(name string, worker func(ctx context.Context)) {
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		worker(m.workersCtx)
+		m.log.Debug().Str("worker", name).Msg("worker stopped")
+	}()
+}
+
+// Run serves app on addr until a signal is received or the listener fails, then shuts down.
+// A second signal during the shutdown kills the process.
+func (m *Manager) Run(app *fiber.App, addr string) error {
+	signals, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- app.Listen(addr, fiber.ListenConfig{
+			BeforeServeFunc: func(*fiber.App) error {
+				m.ready.Store(true)
+				return nil
+			},
+		})
+	}()
+
+	select {
+	case err := <-listenErr:
+		m.ready.Store(false)
+		return errors.Join(fmt.Errorf("error serving, %w", err), m.Shutdown(context.Background()))
+	case <-signals.Done():
+	}
+	stop()
+
+	m.log.Info().Msg("shutting down")
+	m.ready.Store(false)
+	time.Sleep(m.cfg.DrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := app.ShutdownWithContext(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("error draining requests, %w", err))
+	}
+	if err := <-listenErr; err != nil {
+		errs = append(errs, fmt.Errorf("error serving, %w", err))
+	}
+	errs = append(errs, m.Shutdown(ctx))
+
+	m.log.Info().Msg("shutdown complete")
+	return errors.Join(errs...)
+}
+
+// Shutdown stops the workers and closes the resources in reverse order, only the first call does anything
+func (m *Manager) Shutdown(ctx context.Context) error {
+	var errs []error
+	m.shutdownOnce.Do(func() {
+		m.ready.Store(false)
+		m.stopWorkers()
+
+		done := make(chan struct{})
+		go func() {
+			m.workers.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("error waiting for workers, %w", ctx.Err()))
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for i := len(m.resources) - 1; i >= 0; i-- {
+			r := m.resources[i]
+			if err := r.closeWithin(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("error closing %s, %w", r.name, err))
+				continue
+			}
+			m.log.Debug().Str("resource", r.name).Msg("resource closed")
+		}
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,159 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManager(cfg Config) *Manager {
+	log := zerolog.Nop()
+	return New(&log, cfg)
+}
+
+// closed records the order resources and workers stop in
+type closed struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (c *closed) add(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names = append(c.names, name)
+}
+
+func (c *closed) get() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.names...)
+}
+
+func (c *closed) closer(name string, err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		c.add(name)
+		return err
+	}
+}
+
+func TestShutdown_ClosesResourcesInReverseOrder(t *testing.T) {
+	lc := newTestManager(Config{ShutdownTimeout: time.Second})
+	var order closed
+
+	lc.Register("database", order.closer("database", nil))
+	lc.Register("redis", order.closer("redis", errors.New("connection reset")))
+	lc.Register("tracing", order.closer("tracing", nil))
+	lc.Go("sweep", func(ctx context.Context) {
+		<-ctx.Done()
+		order.add("sweep")
+	})
+
+	err := lc.Shutdown(context.Background())
+
+	// the workers stop first, a failing resource doesn't keep the others open
+	assert.Equal(t, []string{"sweep", "tracing", "redis", "database"}, order.get())
+	assert.EqualError(t, err, "error closing redis, connection reset")
+
+	// only the first call closes anything
+	assert.NoError(t, lc.Shutdown(context.Background()))
+	assert.Len(t, order.get(), 4)
+}
+
+func TestShutdown_GivesUpOnResourcesPastTheTimeout(t *testing.T) {
+	lc := newTestManager(Config{})
+	var order closed
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+	lc.Register("database", order.closer("database", nil))
+	lc.Register("exporter", func(ctx context.Context) error {
+		// ignores ctx
+		<-stuck
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := lc.Shutdown(ctx)
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "error closing exporter")
+	// the resources registered before are still closed
+	assert.Eventually(t, func() bool { return len(order.get()) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestShutdown_GivesUpOnWorkersPastTheTimeout(t *testing.T) {
+	lc := newTestManager(Config{})
+	var order closed
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+	lc.Register("database", order.closer("database", nil))
+	lc.Go("import", func(ctx context.Context) {
+		<-stuck
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := lc.Shutdown(ctx)
+
+	assert.ErrorContains(t, err, "error waiting for workers")
+	assert.Eventually(t, func() bool { return len(order.get()) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestRun_FailsReadinessBeforeDraining(t *testing.T) {
+	lc := newTestManager(Config{ShutdownTimeout: time.Second, DrainDelay: 200 * time.Millisecond})
+	var order closed
+	lc.Register("database", order.closer("database", nil))
+
+	app := fiber.New()
+	done := make(chan error, 1)
+	go func() {
+		done <- lc.Run(app, "127.0.0.1:0")
+	}()
+	require.Eventually(t, lc.Ready, time.Second, 5*time.Millisecond)
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGTERM))
+
+	// readiness fails right away, the listener stays open for DrainDelay
+	require.Eventually(t, func() bool { return !lc.Ready() }, 100*time.Millisecond, time.Millisecond)
+	assert.Empty(t, order.get())
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run didn't return after the shutdown")
+	}
+	assert.Equal(t, []string{"database"}, order.get())
+}
+
+func TestRun_ListenerFailure(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer taken.Close()
+
+	lc := newTestManager(Config{ShutdownTimeout: time.Second})
+	var order closed
+	lc.Register("database", order.closer("database", nil))
+
+	err = lc.Run(fiber.New(), taken.Addr().String())
+
+	assert.ErrorContains(t, err, "error serving")
+	assert.False(t, lc.Ready())
+	assert.Equal(t, []string{"database"}, order.get())
+}
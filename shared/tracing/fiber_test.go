@@ -0,0 +1,150 @@
+package tracing
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	callerTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	callerSpanID      = "00f067aa0ba902b7"
+	callerTraceparent = "00-" + callerTraceID + "-" + callerSpanID + "-01"
+)
+
+func TestMiddleware_ContinuesTheCallersTrace(t *testing.T) {
+	recorder := record(t)
+
+	var handlerSpan trace.SpanContext
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/employees/:id", func(c fiber.Ctx) error {
+		handlerSpan = trace.SpanContextFromContext(c.Context())
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/employees/42", nil)
+	req.Header.Set("traceparent", callerTraceparent)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, callerTraceID, span.SpanContext().TraceID().String())
+	assert.Equal(t, callerSpanID, span.Parent().SpanID().String())
+	assert.True(t, span.Parent().IsRemote())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+
+	// named after the route, not the path holding the id
+	assert.Equal(t, "GET /employees/:id", span.Name())
+	attrs := attributes(span)
+	assert.Equal(t, "/employees/:id", attrs["http.route"].AsString())
+	assert.Equal(t, "/employees/42", attrs["url.path"].AsString())
+	assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64())
+
+	// the handler works within the span
+	assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+}
+
+func TestMiddleware_StartsATraceWithoutTraceparent(t *testing.T) {
+	recorder := record(t)
+
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/", func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	_, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.True(t, spans[0].SpanContext().IsValid())
+	assert.False(t, spans[0].Parent().IsValid())
+}
+
+func TestMiddleware_StatusOfTheSpan(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler fiber.Handler
+		status  int64
+		code    codes.Code
+		errored bool
+	}{
+		{
+			name:    "success",
+			handler: func(c fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) },
+			status:  201,
+			code:    codes.Unset,
+		},
+		{
+			name:    "client error returned",
+			handler: func(c fiber.Ctx) error { return fiber.ErrNotFound },
+			status:  404,
+			code:    codes.Unset,
+			errored: true,
+		},
+		{
+			name:    "client error sent",
+			handler: func(c fiber.Ctx) error { return c.SendStatus(fiber.StatusConflict) },
+			status:  409,
+			code:    codes.Unset,
+		},
+		{
+			name:    "server error returned",
+			handler: func(c fiber.Ctx) error { return fiber.ErrServiceUnavailable },
+			status:  503,
+			code:    codes.Error,
+			errored: true,
+		},
+		{
+			name:    "server error sent",
+			handler: func(c fiber.Ctx) error { return c.SendStatus(fiber.StatusBadGateway) },
+			status:  502,
+			code:    codes.Error,
+		},
+		{
+			name:    "plain error",
+			handler: func(c fiber.Ctx) error { return errors.New("connection refused") },
+			status:  500,
+			code:    codes.Error,
+			errored: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record(t)
+
+			app := fiber.New()
+			app.Use(Middleware())
+			app.Get("/", tt.handler)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			require.NoError(t, err)
+			// the span holds the status the error handler answers with
+			assert.Equal(t, tt.status, int64(resp.StatusCode))
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, tt.status, attributes(span)["http.response.status_code"].AsInt64())
+			assert.Equal(t, tt.code, span.Status().Code)
+			if tt.errored {
+				require.Len(t, span.Events(), 1)
+				assert.Equal(t, "exception", span.Events()[0].Name)
+			} else {
+				assert.Empty(t, span.Events())
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTransport_SendsTheTraceparent(t *testing.T) {
+	recorder := record(t)
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	ctx, parent := Tracer().Start(context.Background(), "login")
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/login?next=%2Fhome", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, "POST "+req.URL.Host, span.Name())
+	assert.Equal(t, int64(200), attributes(span)["http.response.status_code"].AsInt64())
+
+	// the server continues the trace from the client span
+	assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", received)
+	// and the caller's request is left as it was
+	assert.Empty(t, req.Header.Get("traceparent"))
+}
+
+func TestTransport_StatusOfTheSpan(t *testing.T) {
+	tests := []struct {
+		status int
+		code   codes.Code
+	}{
+		{http.StatusOK, codes.Unset},
+		{http.StatusNotFound, codes.Unset},
+		{http.StatusServiceUnavailable, codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			recorder := record(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, int64(tt.status), attributes(spans[0])["http.response.status_code"].AsInt64())
+			assert.Equal(t, tt.code, spans[0].Status().Code)
+		})
+	}
+}
+
+func TestTransport_FailedRequest(t *testing.T) {
+	recorder := record(t)
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
@@ -0,0 +1,28 @@
+package tracing
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogHook adds the trace and span ids of the span in the event's context to every log line,
+// the context comes from Event.Ctx or from a logger built with Context.Ctx.
+//
+// Usage:
+//
+//	logger := zerolog.New(os.Stdout).Hook(tracing.LogHook{})
+//	logger.Info().Ctx(c.Context()).Msg("login successful")
+type LogHook struct{}
+
+func (LogHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	ctx := e.GetCtx()
+	if ctx == nil {
+		return
+	}
+
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return
+	}
+	e.Str("trace_id", spanCtx.TraceID().String()).Str("span_id", spanCtx.SpanID().String())
+}
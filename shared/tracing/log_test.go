@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogHook_AddsTheIdsOfTheSpan(t *testing.T) {
+	record(t)
+	ctx, span := Tracer().Start(context.Background(), "login")
+	defer span.End()
+
+	var out bytes.Buffer
+	logger := zerolog.New(&out).Hook(LogHook{})
+	logger.Info().Ctx(ctx).Msg("login successful")
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(t, span.SpanContext().TraceID().String(), line["trace_id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), line["span_id"])
+}
+
+func TestLogHook_LoggerWithContext(t *testing.T) {
+	record(t)
+	ctx, span := Tracer().Start(context.Background(), "login")
+	defer span.End()
+
+	var out bytes.Buffer
+	logger := zerolog.New(&out).Hook(LogHook{}).With().Ctx(ctx).Logger()
+	logger.Info().Msg("login successful")
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(t, span.SpanContext().TraceID().String(), line["trace_id"])
+}
+
+func TestLogHook_WithoutSpan(t *testing.T) {
+	var out bytes.Buffer
+	logger := zerolog.New(&out).Hook(LogHook{})
+
+	logger.Info().Msg("started")
+	logger.Info().Ctx(context.Background()).Msg("started")
+
+	assert.NotContains(t, out.String(), "trace_id")
+	assert.NotContains(t, out.String(), "span_id")
+}
@@ -0,0 +1,95 @@
+// Package tracing sets up OpenTelemetry tracing for the hr applications: a tracer provider,
+// W3C traceparent propagation, a fiber middleware for incoming requests, an http transport
+// for outgoing ones and a zerolog hook adding the trace ids to log lines.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracers of this module
+const instrumentationName = "web-boilerplate"
+
+// Exporters accepted by Config.Exporter
+const (
+	// ExporterNone keeps spans in process, trace ids are still propagated and logged
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+type Config struct {
+	ServiceName string
+	Exporter    string
+	// File receives the spans, one JSON document each, with ExporterFile
+	File string
+}
+
+// Setup installs the global tracer provider and propagator. The returned shutdown flushes
+// the pending spans and must be called before exiting.
+//
+// Usage:
+//
+//	shutdown, err := tracing.Setup(tracing.Config{ServiceName: "hr-api", Exporter: tracing.ExporterStdout})
+//	defer shutdown(context.Background())
+func Setup(cfg Config) (func(ctx context.Context) error, error) {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", cfg.ServiceName),
+		)),
+	}
+
+	var out io.WriteCloser
+	switch cfg.Exporter {
+	case "", ExporterNone:
+	case ExporterStdout, ExporterFile:
+		var w io.Writer = os.Stdout
+		if cfg.Exporter == ExporterFile {
+			f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, fmt.Errorf("error opening trace file, %w", err)
+			}
+			out, w = f, f
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, fmt.Errorf("error creating trace exporter, %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if out != nil {
+			err = errors.Join(err, out.Close())
+		}
+		return err
+	}, nil
+}
+
+// Tracer returns the tracer of this module, for the spans started outside of this package
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}